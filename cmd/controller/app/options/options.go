@@ -27,7 +27,9 @@ import (
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	challengescontroller "github.com/jetstack/cert-manager/pkg/controller/acmechallenges"
 	orderscontroller "github.com/jetstack/cert-manager/pkg/controller/acmeorders"
+	revocationcontroller "github.com/jetstack/cert-manager/pkg/controller/acmerevocation"
 	cracmecontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/acme"
+	crapprovercontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/approver"
 	crcacontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/ca"
 	crselfsignedcontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/selfsigned"
 	crvaultcontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/vault"
@@ -80,6 +82,10 @@ type ControllerOptions struct {
 
 	MaxConcurrentChallenges int
 
+	// CRLServerAddress is the address to serve the CRLs maintained for CA
+	// issuers on. If empty, CRLs are not served.
+	CRLServerAddress string
+
 	// Namespace is the namespace the webhook CA and serving secret will be
 	// created in.
 	// If not specified, it will default to the same namespace as cert-manager.
@@ -123,6 +129,8 @@ const (
 
 	defaultMaxConcurrentChallenges = 60
 
+	defaultCRLServerAddress = ""
+
 	defaultWebhookNamespace         = "cert-manager"
 	defaultWebhookCASecretName      = "cert-manager-webhook-ca"
 	defaultWebhookServingSecretName = "cert-manager-webhook-tls"
@@ -154,7 +162,9 @@ var (
 		ingressshimcontroller.ControllerName,
 		orderscontroller.ControllerName,
 		challengescontroller.ControllerName,
+		revocationcontroller.ControllerName,
 		webhookbootstrap.ControllerName,
+		crapprovercontroller.ControllerName,
 		cracmecontroller.CRControllerName,
 		crcacontroller.CRControllerName,
 		crselfsignedcontroller.CRControllerName,
@@ -185,6 +195,7 @@ func NewControllerOptions() *ControllerOptions {
 		DNS01RecursiveNameservers:         []string{},
 		DNS01RecursiveNameserversOnly:     defaultDNS01RecursiveNameserversOnly,
 		EnableCertificateOwnerRef:         defaultEnableCertificateOwnerRef,
+		CRLServerAddress:                  defaultCRLServerAddress,
 	}
 }
 
@@ -280,6 +291,10 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"When this flag is enabled, the secret will be automatically removed when the certificate resource is deleted.")
 	fs.IntVar(&s.MaxConcurrentChallenges, "max-concurrent-challenges", defaultMaxConcurrentChallenges, ""+
 		"The maximum number of challenges that can be scheduled as 'processing' at once.")
+	fs.StringVar(&s.CRLServerAddress, "crl-server-address", defaultCRLServerAddress, ""+
+		"The address to serve the certificate revocation lists of CA issuers on, for example "+
+		"0.0.0.0:9403. CRLs are served at /issuers/<namespace>/<name>.crl and "+
+		"/clusterissuers/<name>.crl by the leader elected instance. If empty, CRLs are not served.")
 
 	fs.StringVar(&s.WebhookNamespace, "webhook-namespace", defaultWebhookNamespace, "The namespace the webhook component is running in, "+
 		"used for provisioning TLS certificates for the conversion webhook.")
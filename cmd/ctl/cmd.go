@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/check"
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/convert"
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/create"
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/renew"
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/status"
+	"github.com/jetstack/cert-manager/pkg/util"
+)
+
+// kubectlPluginPrefix is the prefix of the binary name when cmctl is
+// installed as a kubectl plugin, e.g. 'kubectl-cert_manager'.
+const kubectlPluginPrefix = "kubectl-"
+
+// NewCertManagerCtlCommand returns the root cobra command of cmctl. The
+// command name is derived from the binary name, so that help output matches
+// the way it is invoked when installed as a kubectl plugin.
+func NewCertManagerCtlCommand(binaryName string, ioStreams factory.IOStreams) *cobra.Command {
+	use := "cmctl"
+	if name := filepath.Base(binaryName); strings.HasPrefix(name, kubectlPluginPrefix) {
+		// kubectl maps underscores in plugin binary names to dashes
+		use = "kubectl " + strings.Replace(strings.TrimPrefix(name, kubectlPluginPrefix), "_", "-", -1)
+	}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: fmt.Sprintf("cert-manager CLI tool to manage and configure cert-manager resources (%s) (%s)", util.AppVersion, util.AppGitCommit),
+		Long: `
+cmctl is a CLI tool to help you manage cert-manager and its resources
+inside your Kubernetes cluster. It can be used as a standalone binary, or
+as a kubectl plugin by installing it as 'kubectl-cert_manager'.`,
+		SilenceUsage: true,
+	}
+
+	f := factory.New(cmd.PersistentFlags())
+
+	cmd.AddCommand(status.NewCmdStatus(ioStreams, f))
+	cmd.AddCommand(renew.NewCmdRenew(ioStreams, f))
+	cmd.AddCommand(create.NewCmdCreate(ioStreams, f))
+	cmd.AddCommand(convert.NewCmdConvert(ioStreams))
+	cmd.AddCommand(check.NewCmdCheck(ioStreams, f))
+
+	return cmd
+}
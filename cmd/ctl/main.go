@@ -0,0 +1,37 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/factory"
+)
+
+// cmctl is a command line tool for inspecting and operating cert-manager
+// resources. It can also be installed as a kubectl plugin.
+
+func main() {
+	ioStreams := factory.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
+	cmd := NewCertManagerCtlCommand(os.Args[0], ioStreams)
+
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
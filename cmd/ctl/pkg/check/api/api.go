@@ -0,0 +1,193 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+)
+
+const (
+	long = `Check if the cert-manager API is ready.
+
+This check creates a Certificate resource in dry-run mode, which exercises
+the cert-manager CustomResourceDefinitions and the mutating and validating
+webhooks without persisting any resources.`
+
+	example = `
+# Check if the cert-manager API is ready.
+cmctl check api
+
+# Wait for up to 2 minutes for the cert-manager API to become ready.
+cmctl check api --wait 2m`
+
+	checkName = "cmctl-check-api"
+)
+
+// Options are the options of the check api command.
+type Options struct {
+	// Wait is the time to wait for the API to become ready. If zero, the
+	// API is only checked once.
+	Wait time.Duration
+	// Interval is the time between checks when waiting for the API
+	Interval time.Duration
+
+	Namespace string
+	CMClient  cmclient.Interface
+
+	factory.IOStreams
+}
+
+// NewOptions returns initialised Options.
+func NewOptions(ioStreams factory.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdCheckAPI returns a cobra command for checking whether the
+// cert-manager API is ready.
+func NewCmdCheckAPI(ioStreams factory.IOStreams, f *factory.Factory) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "api",
+		Short:   "Check if the cert-manager API is ready",
+		Long:    long,
+		Example: example,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.Complete(f); err != nil {
+				return err
+			}
+			return o.Run()
+		},
+	}
+
+	cmd.Flags().DurationVar(&o.Wait, "wait", 0, "Wait until the cert-manager API is ready, for at most the given duration.")
+	cmd.Flags().DurationVar(&o.Interval, "interval", 5*time.Second, "Time between checks when waiting for the cert-manager API to become ready.")
+
+	return cmd
+}
+
+// Validate checks that the given flags are valid.
+func (o *Options) Validate() error {
+	if o.Wait < 0 {
+		return errors.New("--wait must not be negative")
+	}
+	if o.Interval <= 0 {
+		return errors.New("--interval must be greater than zero")
+	}
+	return nil
+}
+
+// Complete builds the API clients used by the command.
+func (o *Options) Complete(f *factory.Factory) error {
+	var err error
+
+	o.Namespace, _, err = f.Namespace()
+	if err != nil {
+		return err
+	}
+
+	o.CMClient, err = f.CMClient()
+	return err
+}
+
+// Run checks the cert-manager API, waiting for it to become ready if the
+// --wait flag is set.
+func (o *Options) Run() error {
+	if o.Wait == 0 {
+		if err := o.check(); err != nil {
+			return err
+		}
+		fmt.Fprintln(o.Out, "The cert-manager API is ready")
+		return nil
+	}
+
+	var checkErr error
+	err := wait.PollImmediate(o.Interval, o.Wait, func() (bool, error) {
+		checkErr = o.check()
+		if checkErr != nil {
+			fmt.Fprintf(o.ErrOut, "Not ready: %v\n", checkErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("timed out waiting for the cert-manager API to become ready: %v", checkErr)
+	}
+
+	fmt.Fprintln(o.Out, "The cert-manager API is ready")
+	return nil
+}
+
+// check creates a Certificate in dry-run mode, and translates any errors to
+// a description of the component that is not ready.
+func (o *Options) check() error {
+	crt := &cmapi.Certificate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: cmapi.SchemeGroupVersion.String(),
+			Kind:       cmapi.CertificateKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: checkName + "-",
+			Namespace:    o.Namespace,
+		},
+		Spec: cmapi.CertificateSpec{
+			SecretName: checkName,
+			DNSNames:   []string{checkName + ".example.com"},
+			IssuerRef: cmmeta.ObjectReference{
+				Name: checkName,
+			},
+		},
+	}
+
+	err := o.CMClient.CertmanagerV1alpha2().RESTClient().Post().
+		Namespace(o.Namespace).
+		Resource("certificates").
+		VersionedParams(&metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}}, metav1.ParameterCodec).
+		Body(crt).
+		Do().
+		Error()
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case k8sErrors.IsNotFound(err) && strings.Contains(err.Error(), "the server could not find the requested resource"):
+		return fmt.Errorf("the cert-manager CRDs are not yet installed on the Kubernetes API server: %v", err)
+	case strings.Contains(err.Error(), "failed calling webhook"):
+		return fmt.Errorf("the cert-manager webhook deployment is not ready yet: %v", err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/factory"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+)
+
+const webhookFailure = `{"kind":"Status","apiVersion":"v1","status":"Failure",` +
+	`"message":"Internal error occurred: failed calling webhook \"webhook.cert-manager.io\": connection refused",` +
+	`"reason":"InternalError","code":500}`
+
+// response is a response of the fake API server to a dry-run request.
+type response struct {
+	code int
+	body string
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		wait        time.Duration
+		interval    time.Duration
+		expectedErr bool
+	}{
+		"no wait given": {
+			interval: time.Second,
+		},
+		"wait given": {
+			wait:     time.Minute,
+			interval: time.Second,
+		},
+		"negative wait given": {
+			wait:        -time.Minute,
+			interval:    time.Second,
+			expectedErr: true,
+		},
+		"zero interval given": {
+			wait:        time.Minute,
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(factory.IOStreams{})
+			o.Wait = test.wait
+			o.Interval = test.interval
+
+			err := o.Validate()
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := map[string]struct {
+		responses []response
+		wait      time.Duration
+
+		expectedErr      string
+		expectedRequests int
+	}{
+		"the API should be ready if the dry-run request succeeds": {
+			responses:        []response{{code: http.StatusCreated, body: "{}"}},
+			expectedRequests: 1,
+		},
+		"missing CRDs should be reported": {
+			responses:        []response{{code: http.StatusNotFound}},
+			expectedErr:      "the cert-manager CRDs are not yet installed",
+			expectedRequests: 1,
+		},
+		"a webhook that is not ready should be reported": {
+			responses:        []response{{code: http.StatusInternalServerError, body: webhookFailure}},
+			expectedErr:      "the cert-manager webhook deployment is not ready yet",
+			expectedRequests: 1,
+		},
+		"the API should be checked again while waiting": {
+			responses: []response{
+				{code: http.StatusNotFound},
+				{code: http.StatusInternalServerError, body: webhookFailure},
+				{code: http.StatusCreated, body: "{}"},
+			},
+			wait:             time.Minute,
+			expectedRequests: 3,
+		},
+		"the last error should be reported if the API is not ready in time": {
+			responses:   []response{{code: http.StatusInternalServerError, body: webhookFailure}},
+			wait:        50 * time.Millisecond,
+			expectedErr: "timed out waiting for the cert-manager API to become ready: the cert-manager webhook deployment is not ready yet",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Query().Get("dryRun") != "All" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+				// the last response is repeated for any further requests
+				resp := test.responses[len(test.responses)-1]
+				if requests < len(test.responses) {
+					resp = test.responses[requests]
+				}
+				requests++
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(resp.code)
+				w.Write([]byte(resp.body))
+			}))
+			defer srv.Close()
+
+			cmClient, err := cmclient.NewForConfig(&rest.Config{Host: srv.URL})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			o := NewOptions(factory.IOStreams{Out: new(bytes.Buffer), ErrOut: new(bytes.Buffer)})
+			o.Namespace = "default"
+			o.CMClient = cmClient
+			o.Wait = test.wait
+			o.Interval = 10 * time.Millisecond
+
+			err = o.Run()
+			switch {
+			case err != nil && test.expectedErr == "":
+				t.Errorf("expected to not get an error, but got: %v", err)
+			case err == nil && test.expectedErr != "":
+				t.Errorf("expected to get an error but did not get one")
+			case err != nil && !strings.HasPrefix(err.Error(), test.expectedErr):
+				t.Errorf("expected error to start with %q, but got: %v", test.expectedErr, err)
+			}
+
+			if test.expectedRequests > 0 && requests != test.expectedRequests {
+				t.Errorf("expected %d requests, but got %d", test.expectedRequests, requests)
+			}
+		})
+	}
+}
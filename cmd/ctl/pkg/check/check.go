@@ -0,0 +1,37 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/check/api"
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/factory"
+)
+
+// NewCmdCheck returns a cobra command for checking cert-manager components.
+func NewCmdCheck(ioStreams factory.IOStreams, f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check cert-manager components",
+		Long:  `Check cert-manager components, e.g. whether the cert-manager API is ready`,
+	}
+
+	cmd.AddCommand(api.NewCmdCheckAPI(ioStreams, f))
+
+	return cmd
+}
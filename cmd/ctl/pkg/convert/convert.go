@@ -0,0 +1,296 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/factory"
+	acmeapi "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha3"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha3"
+	"github.com/jetstack/cert-manager/pkg/ctl"
+)
+
+const (
+	long = `Convert cert-manager config files between different API versions.
+
+The command takes a filename, directory, or stdin as input, and converts the
+cert-manager resources it contains to the version given by the
+--output-version flag. If no version is given, resources are converted to
+the latest API version.
+
+The conversion is performed locally using the same conversion functions as
+the cert-manager webhook, so no connection to a cluster is required.
+Resources that are not part of the cert-manager API groups are written to
+the output unchanged.`
+
+	example = `
+# Convert 'cert.yaml' to the latest API version and print to stdout.
+cmctl convert -f cert.yaml
+
+# Convert all files in a directory to v1alpha2 and print to stdout.
+cmctl convert -f ./manifests --output-version v1alpha2
+
+# Convert the resources read from stdin.
+cat cert.yaml | cmctl convert -f -`
+)
+
+// scheme has all internal and external versions of the cert-manager API
+// types registered, along with the conversion functions between them.
+var scheme = ctl.Scheme
+
+// Options are the options of the convert command.
+type Options struct {
+	// Filenames are the files, directories or '-' for stdin to read
+	// resources from
+	Filenames []string
+	// OutputVersion is the API version that resources are converted to
+	OutputVersion string
+
+	factory.IOStreams
+}
+
+// NewOptions returns initialised Options.
+func NewOptions(ioStreams factory.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdConvert returns a cobra command for converting cert-manager
+// resources between API versions.
+func NewCmdConvert(ioStreams factory.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "convert",
+		Short:   "Convert cert-manager config files between different API versions",
+		Long:    long,
+		Example: example,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run()
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&o.Filenames, "filename", "f", nil, "Filename, directory, or '-' for stdin, of the resources to convert.")
+	cmd.Flags().StringVar(&o.OutputVersion, "output-version", cmapi.SchemeGroupVersion.Version, "Output the formatted object with the given API version, one of v1alpha2 or v1alpha3.")
+
+	return cmd
+}
+
+// Validate checks that the given flags are valid.
+func (o *Options) Validate() error {
+	if len(o.Filenames) == 0 {
+		return errors.New("must specify at least one filename with --filename")
+	}
+
+	for _, gv := range o.targetVersions() {
+		if !scheme.IsVersionRegistered(gv) {
+			return fmt.Errorf("unsupported output version %q", o.OutputVersion)
+		}
+	}
+
+	return nil
+}
+
+// targetVersions returns the API versions of each cert-manager API group
+// that resources are converted to.
+func (o *Options) targetVersions() schema.GroupVersions {
+	return schema.GroupVersions{
+		{Group: cmapi.SchemeGroupVersion.Group, Version: o.OutputVersion},
+		{Group: acmeapi.SchemeGroupVersion.Group, Version: o.OutputVersion},
+	}
+}
+
+// Run converts all resources read from the given files and writes them to
+// the output stream.
+func (o *Options) Run() error {
+	var docs []document
+	for _, filename := range o.Filenames {
+		fileDocs, err := o.readDocuments(filename)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, fileDocs...)
+	}
+
+	for i, doc := range docs {
+		data := doc.raw
+		if data == nil {
+			converted, err := Convert(doc.obj, o.targetVersions())
+			if err != nil {
+				return err
+			}
+
+			data, err = yaml.Marshal(converted)
+			if err != nil {
+				return err
+			}
+		}
+
+		if i > 0 {
+			fmt.Fprintln(o.Out, "---")
+		}
+		if _, err := o.Out.Write(data); err != nil {
+			return err
+		}
+		if !bytes.HasSuffix(data, []byte("\n")) {
+			fmt.Fprintln(o.Out)
+		}
+	}
+
+	return nil
+}
+
+// Convert converts the given object to the target API versions, using the
+// internal API version as the hub.
+func Convert(obj runtime.Object, target runtime.GroupVersioner) (runtime.Object, error) {
+	internal, err := scheme.ConvertToVersion(obj, runtime.InternalGroupVersioner)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheme.ConvertToVersion(internal, target)
+}
+
+// document is a single document read from the input.
+type document struct {
+	// obj is the decoded cert-manager resource contained in the document.
+	obj runtime.Object
+	// raw is set to the original bytes of the document if it does not
+	// contain a cert-manager resource, so that it can be written to the
+	// output unchanged, including its comments and the order of its keys.
+	raw []byte
+}
+
+// readDocuments reads and decodes all documents from the given filename,
+// directory or stdin if filename is '-'.
+func (o *Options) readDocuments(filename string) ([]document, error) {
+	if filename == "-" {
+		return decodeDocuments(o.In, "stdin")
+	}
+
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	if info.IsDir() {
+		dir, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		names, err := dir.Readdirnames(-1)
+		dir.Close()
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			switch filepath.Ext(name) {
+			case ".yaml", ".yml", ".json":
+				paths = append(paths, filepath.Join(filename, name))
+			}
+		}
+	} else {
+		paths = []string{filename}
+	}
+
+	var docs []document
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		fileDocs, err := decodeDocuments(f, path)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, fileDocs...)
+	}
+
+	return docs, nil
+}
+
+// decodeDocuments decodes all documents of a YAML or JSON stream. Documents
+// containing resources that are not registered with the cert-manager scheme
+// are only checked to be valid resources, and are kept as they were read so
+// they can be passed through as-is.
+func decodeDocuments(r io.Reader, source string) ([]document, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+
+	var docs []document
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %v", source, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if runtime.IsNotRegisteredError(err) {
+			err = validateUnstructured(doc)
+			if err == nil {
+				docs = append(docs, document{raw: doc})
+				continue
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error decoding resource in %s: %v", source, err)
+		}
+		docs = append(docs, document{obj: obj})
+	}
+
+	return docs, nil
+}
+
+// validateUnstructured checks that doc contains a valid Kubernetes resource.
+func validateUnstructured(doc []byte) error {
+	data, err := utilyaml.ToJSON(doc)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = unstructured.UnstructuredJSONScheme.Decode(data, nil, nil)
+	return err
+}
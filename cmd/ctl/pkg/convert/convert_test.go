@@ -0,0 +1,147 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/factory"
+)
+
+func TestConvert(t *testing.T) {
+	tests := map[string]struct {
+		input          string
+		stdin          string
+		outputVersion  string
+		expectedOutput string
+		expectedErr    bool
+	}{
+		"convert a v1alpha2 Certificate to v1alpha3": {
+			input:          "testdata/certificate_v1alpha2.yaml",
+			outputVersion:  "v1alpha3",
+			expectedOutput: "testdata/certificate_v1alpha3.yaml",
+		},
+		"convert a v1alpha3 Certificate to v1alpha2": {
+			input:          "testdata/certificate_v1alpha3.yaml",
+			outputVersion:  "v1alpha2",
+			expectedOutput: "testdata/certificate_v1alpha2_converted.yaml",
+		},
+		"convert resources of multiple API groups read from stdin": {
+			input:          "-",
+			stdin:          "testdata/issuer_v1alpha2.yaml",
+			outputVersion:  "v1alpha3",
+			expectedOutput: "testdata/issuer_v1alpha3.yaml",
+		},
+		"pass through resources that are not cert-manager resources": {
+			input:          "testdata/mixed_v1alpha2.yaml",
+			outputVersion:  "v1alpha3",
+			expectedOutput: "testdata/mixed_v1alpha3.yaml",
+		},
+		"convert all files in a directory": {
+			input:          "testdata/dir",
+			outputVersion:  "v1alpha3",
+			expectedOutput: "testdata/dir_v1alpha3.yaml",
+		},
+		"fail to convert a file that does not exist": {
+			input:         "testdata/nonexistent.yaml",
+			outputVersion: "v1alpha3",
+			expectedErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			streams := factory.IOStreams{In: strings.NewReader(""), Out: out, ErrOut: new(bytes.Buffer)}
+			if test.stdin != "" {
+				stdin, err := ioutil.ReadFile(test.stdin)
+				if err != nil {
+					t.Fatal(err)
+				}
+				streams.In = bytes.NewReader(stdin)
+			}
+
+			o := NewOptions(streams)
+			o.Filenames = []string{test.input}
+			o.OutputVersion = test.outputVersion
+
+			if err := o.Validate(); err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+
+			err := o.Run()
+			if err != nil && !test.expectedErr {
+				t.Fatalf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Fatalf("expected to get an error but did not get one")
+			}
+			if test.expectedErr {
+				return
+			}
+
+			expected, err := ioutil.ReadFile(test.expectedOutput)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != string(expected) {
+				t.Errorf("unexpected output, exp=\n%s\ngot=\n%s", expected, out.String())
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		filenames     []string
+		outputVersion string
+		expectedErr   bool
+	}{
+		"valid options": {
+			filenames:     []string{"cert.yaml"},
+			outputVersion: "v1alpha2",
+		},
+		"no filenames": {
+			outputVersion: "v1alpha2",
+			expectedErr:   true,
+		},
+		"unsupported output version": {
+			filenames:     []string{"cert.yaml"},
+			outputVersion: "v1",
+			expectedErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(factory.IOStreams{})
+			o.Filenames = test.filenames
+			o.OutputVersion = test.outputVersion
+
+			err := o.Validate()
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequest
+
+import (
+	"crypto"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/factory"
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	"github.com/jetstack/cert-manager/pkg/ctl"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	long = `Create a cert-manager CertificateRequest resource for one-time Certificate issuing without auto renewal.
+
+A new private key is generated and written to the file given by
+--output-key-file. The CertificateRequest is built from the options of the
+Certificate manifest given by --from-certificate-file, and signed using the
+generated private key.`
+
+	example = `
+# Create a CertificateRequest named 'my-cr' from the Certificate in 'my-certificate.yaml'.
+cmctl create certificaterequest my-cr --from-certificate-file my-certificate.yaml
+
+# Create a CertificateRequest, wait for it to be signed for up to 5 minutes, and write the signed certificate to 'my-cr.crt'.
+cmctl create certificaterequest my-cr --from-certificate-file my-certificate.yaml --fetch-certificate --timeout 5m`
+
+	// defaultPollInterval is the interval at which the CertificateRequest is
+	// checked when waiting for the certificate to be signed.
+	defaultPollInterval = 2 * time.Second
+)
+
+// Options are the options of the create certificaterequest command.
+type Options struct {
+	// InputFilename is the path to the Certificate manifest
+	InputFilename string
+	// KeyFilename is the path the generated private key is written to
+	KeyFilename string
+	// CertFilename is the path the signed certificate is written to
+	CertFilename string
+	// FetchCert waits for the certificate to be signed and writes it to
+	// CertFilename if true
+	FetchCert bool
+	// Timeout is the maximum time to wait for the certificate to be signed
+	Timeout time.Duration
+
+	Namespace        string
+	EnforceNamespace bool
+	CMClient         cmclient.Interface
+
+	// pollInterval is the interval at which the CertificateRequest is
+	// checked when waiting for it to be signed
+	pollInterval time.Duration
+
+	factory.IOStreams
+}
+
+// NewOptions returns initialised Options.
+func NewOptions(ioStreams factory.IOStreams) *Options {
+	return &Options{
+		IOStreams:    ioStreams,
+		pollInterval: defaultPollInterval,
+	}
+}
+
+// NewCmdCreateCertificateRequest returns a cobra command for creating a
+// CertificateRequest from a Certificate manifest.
+func NewCmdCreateCertificateRequest(ioStreams factory.IOStreams, f *factory.Factory) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "certificaterequest NAME",
+		Aliases: []string{"cr"},
+		Short:   "Create a cert-manager CertificateRequest resource, using a Certificate resource as a template",
+		Long:    long,
+		Example: example,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			if err := o.Complete(f); err != nil {
+				return err
+			}
+			return o.Run(args)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.InputFilename, "from-certificate-file", "", "Path to a file containing a Certificate resource used as a template when generating the CertificateRequest resource.")
+	cmd.Flags().StringVar(&o.KeyFilename, "output-key-file", "", "Name of the file the generated private key is written to. Defaults to '<name>.key'.")
+	cmd.Flags().StringVar(&o.CertFilename, "output-certificate-file", "", "Name of the file the signed certificate is written to. Defaults to '<name>.crt'.")
+	cmd.Flags().BoolVar(&o.FetchCert, "fetch-certificate", false, "If set to true, wait for the CertificateRequest to be signed and write the certificate to --output-certificate-file.")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 5*time.Minute, "Time before timing out when waiting for the certificate to be signed. Only used with --fetch-certificate.")
+
+	return cmd
+}
+
+// Validate checks that the given arguments and flags are consistent.
+func (o *Options) Validate(args []string) error {
+	if len(args) != 1 {
+		return errors.New("the name of the CertificateRequest to be created must be specified as the only argument")
+	}
+
+	if o.InputFilename == "" {
+		return errors.New("the path to a Certificate manifest must be specified with --from-certificate-file")
+	}
+
+	if o.Timeout <= 0 {
+		return errors.New("--timeout must be greater than zero")
+	}
+
+	return nil
+}
+
+// Complete builds the API clients used by the command.
+func (o *Options) Complete(f *factory.Factory) error {
+	var err error
+
+	o.Namespace, o.EnforceNamespace, err = f.Namespace()
+	if err != nil {
+		return err
+	}
+
+	o.CMClient, err = f.CMClient()
+	return err
+}
+
+// Run generates a private key, creates the CertificateRequest and optionally
+// waits for it to be signed.
+func (o *Options) Run(args []string) error {
+	name := args[0]
+	keyFilename := defaultString(o.KeyFilename, name+".key")
+	certFilename := defaultString(o.CertFilename, name+".crt")
+
+	crt, err := o.readCertificate()
+	if err != nil {
+		return err
+	}
+
+	namespace := o.Namespace
+	if crt.Namespace != "" && !o.EnforceNamespace {
+		namespace = crt.Namespace
+	}
+
+	if _, err := os.Stat(keyFilename); err == nil {
+		return fmt.Errorf("private key file %q already exists", keyFilename)
+	}
+
+	signer, err := pki.GeneratePrivateKeyForCertificate(crt)
+	if err != nil {
+		return fmt.Errorf("error generating private key: %v", err)
+	}
+	keyData, err := pki.EncodePrivateKey(signer, crt.Spec.KeyEncoding)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(keyFilename, keyData, 0600); err != nil {
+		return fmt.Errorf("error writing private key to %q: %v", keyFilename, err)
+	}
+	fmt.Fprintf(o.Out, "Private key written to file %s\n", keyFilename)
+
+	cr, err := buildCertificateRequest(crt, name, namespace, signer)
+	if err != nil {
+		return err
+	}
+
+	cr, err = o.CMClient.CertmanagerV1alpha2().CertificateRequests(namespace).Create(cr)
+	if err != nil {
+		return fmt.Errorf("error creating CertificateRequest: %v", err)
+	}
+	fmt.Fprintf(o.Out, "CertificateRequest %s/%s has been created\n", cr.Namespace, cr.Name)
+
+	if !o.FetchCert {
+		return nil
+	}
+
+	fmt.Fprintf(o.Out, "Waiting for CertificateRequest %s/%s to be signed\n", cr.Namespace, cr.Name)
+	err = wait.PollImmediate(o.pollInterval, o.Timeout, func() (bool, error) {
+		cr, err = o.CMClient.CertmanagerV1alpha2().CertificateRequests(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		switch apiutil.CertificateRequestReadyReason(cr) {
+		case cmapi.CertificateRequestReasonIssued:
+			return len(cr.Status.Certificate) > 0, nil
+		case cmapi.CertificateRequestReasonFailed:
+			return false, fmt.Errorf("CertificateRequest %s/%s has failed", cr.Namespace, cr.Name)
+		}
+
+		if apiutil.CertificateRequestIsDenied(cr) {
+			return false, fmt.Errorf("CertificateRequest %s/%s has been denied", cr.Namespace, cr.Name)
+		}
+
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when waiting for CertificateRequest to be signed: %v", err)
+	}
+
+	if err := ioutil.WriteFile(certFilename, cr.Status.Certificate, 0600); err != nil {
+		return fmt.Errorf("error writing certificate to %q: %v", certFilename, err)
+	}
+	fmt.Fprintf(o.Out, "Certificate written to file %s\n", certFilename)
+
+	return nil
+}
+
+// readCertificate reads and decodes the Certificate manifest, converting it
+// to the v1alpha2 API version if required.
+func (o *Options) readCertificate() (*cmapi.Certificate, error) {
+	data, err := ioutil.ReadFile(o.InputFilename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %q: %v", o.InputFilename, err)
+	}
+
+	obj, _, err := serializer.NewCodecFactory(ctl.Scheme).UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %q: %v", o.InputFilename, err)
+	}
+
+	internal, err := ctl.Scheme.ConvertToVersion(obj, runtime.InternalGroupVersioner)
+	if err != nil {
+		return nil, err
+	}
+	obj, err = ctl.Scheme.ConvertToVersion(internal, cmapi.SchemeGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	crt, ok := obj.(*cmapi.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("%q does not contain a Certificate resource", o.InputFilename)
+	}
+
+	return crt, nil
+}
+
+// buildCertificateRequest builds a CertificateRequest with the options of
+// the given Certificate, signed by the given private key.
+func buildCertificateRequest(crt *cmapi.Certificate, name, namespace string, signer crypto.Signer) (*cmapi.CertificateRequest, error) {
+	template, err := pki.GenerateCSR(crt)
+	if err != nil {
+		return nil, fmt.Errorf("error generating CSR: %v", err)
+	}
+
+	csrDER, err := pki.EncodeCSR(template, signer)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding CSR: %v", err)
+	}
+
+	return &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      crt.Labels,
+			Annotations: crt.Annotations,
+		},
+		Spec: cmapi.CertificateRequestSpec{
+			CSRPEM: pem.EncodeToMemory(&pem.Block{
+				Type: "CERTIFICATE REQUEST", Bytes: csrDER,
+			}),
+			Duration: crt.Spec.Duration,
+			IssuerRef: cmmeta.ObjectReference{
+				Name:  crt.Spec.IssuerRef.Name,
+				Kind:  crt.Spec.IssuerRef.Kind,
+				Group: crt.Spec.IssuerRef.Group,
+			},
+			IsCA:   crt.Spec.IsCA,
+			Usages: crt.Spec.Usages,
+		},
+	}, nil
+}
+
+func defaultString(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		args          []string
+		inputFilename string
+		timeout       time.Duration
+		expectedErr   bool
+	}{
+		"name and filename given": {
+			args:          []string{"abc"},
+			inputFilename: "crt.yaml",
+			timeout:       time.Minute,
+		},
+		"no name given": {
+			inputFilename: "crt.yaml",
+			timeout:       time.Minute,
+			expectedErr:   true,
+		},
+		"more than one name given": {
+			args:          []string{"abc", "def"},
+			inputFilename: "crt.yaml",
+			timeout:       time.Minute,
+			expectedErr:   true,
+		},
+		"no filename given": {
+			args:        []string{"abc"},
+			timeout:     time.Minute,
+			expectedErr: true,
+		},
+		"zero timeout given": {
+			args:          []string{"abc"},
+			inputFilename: "crt.yaml",
+			expectedErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(factory.IOStreams{})
+			o.InputFilename = test.inputFilename
+			o.Timeout = test.timeout
+
+			err := o.Validate(test.args)
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+		})
+	}
+}
+
+func TestBuildCertificateRequest(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("default"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateCommonName("example.com"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "ClusterIssuer"}),
+		gen.SetCertificateDuration(time.Hour),
+		gen.SetCertificateKeyUsages(cmapi.UsageServerAuth),
+	)
+	crt.Labels = map[string]string{"foo": "bar"}
+
+	signer, err := pki.GeneratePrivateKeyForCertificate(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cr, err := buildCertificateRequest(crt, "test-cr", "other", signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cr.Name != "test-cr" || cr.Namespace != "other" {
+		t.Errorf("unexpected name %s/%s", cr.Namespace, cr.Name)
+	}
+	if !reflect.DeepEqual(cr.Labels, crt.Labels) {
+		t.Errorf("expected labels %v, got %v", crt.Labels, cr.Labels)
+	}
+	if !reflect.DeepEqual(cr.Spec.IssuerRef, crt.Spec.IssuerRef) {
+		t.Errorf("expected issuerRef %v, got %v", crt.Spec.IssuerRef, cr.Spec.IssuerRef)
+	}
+	if !reflect.DeepEqual(cr.Spec.Duration, &metav1.Duration{Duration: time.Hour}) {
+		t.Errorf("expected duration of 1h, got %v", cr.Spec.Duration)
+	}
+	if !reflect.DeepEqual(cr.Spec.Usages, crt.Spec.Usages) {
+		t.Errorf("expected usages %v, got %v", crt.Spec.Usages, cr.Spec.Usages)
+	}
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.CSRPEM)
+	if err != nil {
+		t.Fatalf("failed to decode CSR: %v", err)
+	}
+	if !reflect.DeepEqual(csr.DNSNames, []string{"example.com"}) {
+		t.Errorf("expected CSR DNS names [example.com], got %v", csr.DNSNames)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		t.Errorf("CSR signature is invalid: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package factory provides the kubeconfig flags and API clients shared by
+// all cmctl commands.
+package factory
+
+import (
+	"io"
+
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+)
+
+// IOStreams holds the standard streams used by commands, so that they can
+// be replaced in tests.
+type IOStreams struct {
+	// In is the stream commands read input from
+	In io.Reader
+	// Out is the stream commands write output to
+	Out io.Writer
+	// ErrOut is the stream commands write errors and warnings to
+	ErrOut io.Writer
+}
+
+// Factory builds API clients from the kubeconfig flags given on the command
+// line.
+type Factory struct {
+	loadingRules *clientcmd.ClientConfigLoadingRules
+	overrides    *clientcmd.ConfigOverrides
+
+	clientConfig clientcmd.ClientConfig
+}
+
+// New returns a Factory whose kubeconfig flags are registered on the given
+// FlagSet.
+func New(fs *pflag.FlagSet) *Factory {
+	f := &Factory{
+		loadingRules: clientcmd.NewDefaultClientConfigLoadingRules(),
+		overrides:    &clientcmd.ConfigOverrides{},
+	}
+
+	fs.StringVar(&f.loadingRules.ExplicitPath, clientcmd.RecommendedConfigPathFlag, "", "Path to the kubeconfig file to use for CLI requests.")
+	clientcmd.BindOverrideFlags(f.overrides, fs, clientcmd.RecommendedConfigOverrideFlags(""))
+
+	return f
+}
+
+func (f *Factory) config() clientcmd.ClientConfig {
+	if f.clientConfig == nil {
+		f.clientConfig = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(f.loadingRules, f.overrides)
+	}
+	return f.clientConfig
+}
+
+// Namespace returns the namespace given on the command line or in the
+// current kubeconfig context, and whether it was explicitly given with the
+// --namespace flag.
+func (f *Factory) Namespace() (string, bool, error) {
+	return f.config().Namespace()
+}
+
+// RESTConfig returns the client configuration for the current context.
+func (f *Factory) RESTConfig() (*rest.Config, error) {
+	return f.config().ClientConfig()
+}
+
+// CMClient returns a clientset for cert-manager API resources.
+func (f *Factory) CMClient() (cmclient.Interface, error) {
+	restConfig, err := f.RESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	return cmclient.NewForConfig(restConfig)
+}
+
+// KubeClient returns a clientset for Kubernetes API resources.
+func (f *Factory) KubeClient() (kubernetes.Interface, error) {
+	restConfig, err := f.RESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(restConfig)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renew
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/factory"
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+)
+
+const (
+	// ManuallyTriggeredReason is the reason set on the Issuing condition of
+	// Certificates renewed by this command.
+	ManuallyTriggeredReason = "ManuallyTriggered"
+
+	long = `Mark cert-manager Certificate resources for manual renewal.
+
+The Certificates will be re-issued by cert-manager, even if the current
+certificate is still valid, by setting the 'Issuing' condition.`
+
+	example = `
+# Renew the Certificates named 'my-app' and 'my-other-app' in the current namespace.
+cmctl renew my-app my-other-app
+
+# Renew all Certificates in the 'my-namespace' namespace.
+cmctl renew --namespace my-namespace --all
+
+# Renew all Certificates in all namespaces.
+cmctl renew --all --all-namespaces`
+)
+
+// Options are the options of the renew command.
+type Options struct {
+	// All renews all Certificates in the namespace
+	All bool
+	// AllNamespaces renews Certificates in all namespaces
+	AllNamespaces bool
+
+	Namespace string
+	CMClient  cmclient.Interface
+
+	factory.IOStreams
+}
+
+// NewOptions returns initialised Options.
+func NewOptions(ioStreams factory.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdRenew returns a cobra command for renewing Certificates.
+func NewCmdRenew(ioStreams factory.IOStreams, f *factory.Factory) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "renew [NAME...]",
+		Short:   "Mark Certificates for manual renewal",
+		Long:    long,
+		Example: example,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			if err := o.Complete(f); err != nil {
+				return err
+			}
+			return o.Run(args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.All, "all", false, "Renew all Certificates in the given namespace, or all namespaces with --all-namespaces enabled.")
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", false, "Look for Certificates across all namespaces.")
+
+	return cmd
+}
+
+// Validate checks that the given arguments and flags are consistent.
+func (o *Options) Validate(args []string) error {
+	if len(args) == 0 && !o.All {
+		return errors.New("please supply one or more Certificate resource names or use the --all flag to renew all Certificate resources")
+	}
+
+	if len(args) > 0 && o.All {
+		return errors.New("cannot specify Certificate names in conjunction with the --all flag")
+	}
+
+	if o.AllNamespaces && !o.All {
+		return errors.New("the --all-namespaces flag can only be used in conjunction with the --all flag")
+	}
+
+	return nil
+}
+
+// Complete builds the API clients used by the command.
+func (o *Options) Complete(f *factory.Factory) error {
+	var err error
+
+	o.Namespace, _, err = f.Namespace()
+	if err != nil {
+		return err
+	}
+
+	o.CMClient, err = f.CMClient()
+	return err
+}
+
+// Run marks the named Certificates, or all Certificates if the --all flag
+// is given, for renewal.
+func (o *Options) Run(args []string) error {
+	var crts []cmapi.Certificate
+
+	if o.All {
+		namespace := o.Namespace
+		if o.AllNamespaces {
+			namespace = metav1.NamespaceAll
+		}
+
+		list, err := o.CMClient.CertmanagerV1alpha2().Certificates(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		crts = list.Items
+	} else {
+		for _, name := range args {
+			crt, err := o.CMClient.CertmanagerV1alpha2().Certificates(o.Namespace).Get(name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			crts = append(crts, *crt)
+		}
+	}
+
+	if len(crts) == 0 {
+		if o.AllNamespaces {
+			fmt.Fprintln(o.ErrOut, "No Certificates found")
+		} else {
+			fmt.Fprintf(o.ErrOut, "No Certificates found in %s namespace.\n", o.Namespace)
+		}
+		return nil
+	}
+
+	for i := range crts {
+		if err := o.renewCertificate(&crts[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *Options) renewCertificate(crt *cmapi.Certificate) error {
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue,
+		ManuallyTriggeredReason, "Certificate re-issuance manually triggered")
+
+	if _, err := o.CMClient.CertmanagerV1alpha2().Certificates(crt.Namespace).UpdateStatus(crt); err != nil {
+		return fmt.Errorf("failed to trigger renewal of Certificate %s/%s: %v", crt.Namespace, crt.Name, err)
+	}
+
+	fmt.Fprintf(o.Out, "Manually triggered renewal of Certificate %s/%s\n", crt.Namespace, crt.Name)
+
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renew
+
+import (
+	"bytes"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/factory"
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		args          []string
+		all           bool
+		allNamespaces bool
+		expectedErr   bool
+	}{
+		"names given": {
+			args: []string{"abc"},
+		},
+		"all given": {
+			all: true,
+		},
+		"all namespaces given": {
+			all:           true,
+			allNamespaces: true,
+		},
+		"nothing given": {
+			expectedErr: true,
+		},
+		"names and all given": {
+			args:        []string{"abc"},
+			all:         true,
+			expectedErr: true,
+		},
+		"all namespaces given without all": {
+			args:          []string{"abc"},
+			allNamespaces: true,
+			expectedErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(factory.IOStreams{})
+			o.All = test.all
+			o.AllNamespaces = test.allNamespaces
+
+			err := o.Validate(test.args)
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	crt := func(name, namespace string) *cmapi.Certificate {
+		return gen.Certificate(name, gen.SetCertificateNamespace(namespace))
+	}
+
+	tests := map[string]struct {
+		objects       []runtime.Object
+		namespace     string
+		args          []string
+		all           bool
+		allNamespaces bool
+		expectRenewed []string
+		expectedErr   bool
+	}{
+		"renew named Certificates": {
+			objects:       []runtime.Object{crt("a", "default"), crt("b", "default"), crt("c", "default")},
+			namespace:     "default",
+			args:          []string{"a", "b"},
+			expectRenewed: []string{"default/a", "default/b"},
+		},
+		"renew all Certificates in the namespace": {
+			objects:       []runtime.Object{crt("a", "default"), crt("b", "other")},
+			namespace:     "default",
+			all:           true,
+			expectRenewed: []string{"default/a"},
+		},
+		"renew all Certificates in all namespaces": {
+			objects:       []runtime.Object{crt("a", "default"), crt("b", "other")},
+			namespace:     "default",
+			all:           true,
+			allNamespaces: true,
+			expectRenewed: []string{"default/a", "other/b"},
+		},
+		"fail if a named Certificate does not exist": {
+			objects:     []runtime.Object{crt("a", "default")},
+			namespace:   "default",
+			args:        []string{"b"},
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmClient := cmfake.NewSimpleClientset(test.objects...)
+
+			o := NewOptions(factory.IOStreams{Out: new(bytes.Buffer), ErrOut: new(bytes.Buffer)})
+			o.All = test.all
+			o.AllNamespaces = test.allNamespaces
+			o.Namespace = test.namespace
+			o.CMClient = cmClient
+
+			err := o.Run(test.args)
+			if err != nil && !test.expectedErr {
+				t.Fatalf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Fatalf("expected to get an error but did not get one")
+			}
+
+			crts, err := cmClient.CertmanagerV1alpha2().Certificates(metav1.NamespaceAll).List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			renewed := make(map[string]bool)
+			for _, name := range test.expectRenewed {
+				renewed[name] = true
+			}
+			for i := range crts.Items {
+				c := &crts.Items[i]
+				key := c.Namespace + "/" + c.Name
+				isRenewed := apiutil.CertificateHasCondition(c, cmapi.CertificateCondition{
+					Type:   cmapi.CertificateConditionIssuing,
+					Status: cmmeta.ConditionTrue,
+				})
+				if isRenewed != renewed[key] {
+					t.Errorf("expected Certificate %s renewed=%t, but got %t", key, renewed[key], isRenewed)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/factory"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	long = `Get details about the current status of a cert-manager Certificate resource,
+including information on related resources like CertificateRequest, Order,
+Challenges and the Secret containing the signed certificate.`
+
+	example = `
+# Query status of the Certificate named 'my-crt' in the current namespace.
+cmctl status certificate my-crt
+
+# Query status of the Certificate named 'my-crt' in the 'my-namespace' namespace.
+cmctl status certificate my-crt --namespace my-namespace`
+)
+
+// Options are the options of the status certificate command.
+type Options struct {
+	Namespace  string
+	CMClient   cmclient.Interface
+	KubeClient kubernetes.Interface
+
+	factory.IOStreams
+}
+
+// NewOptions returns initialised Options.
+func NewOptions(ioStreams factory.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdStatusCertificate returns a cobra command for printing the status of
+// a Certificate and its related resources.
+func NewCmdStatusCertificate(ioStreams factory.IOStreams, f *factory.Factory) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "certificate NAME",
+		Aliases: []string{"cert"},
+		Short:   "Get details about the current status of a cert-manager Certificate resource",
+		Long:    long,
+		Example: example,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			if err := o.Complete(f); err != nil {
+				return err
+			}
+			return o.Run(args)
+		},
+	}
+
+	return cmd
+}
+
+// Validate checks that a single Certificate name is given.
+func (o *Options) Validate(args []string) error {
+	if len(args) != 1 {
+		return errors.New("the name of the Certificate must be specified as the only argument")
+	}
+	return nil
+}
+
+// Complete builds the API clients used by the command.
+func (o *Options) Complete(f *factory.Factory) error {
+	var err error
+
+	o.Namespace, _, err = f.Namespace()
+	if err != nil {
+		return err
+	}
+
+	o.CMClient, err = f.CMClient()
+	if err != nil {
+		return err
+	}
+
+	o.KubeClient, err = f.KubeClient()
+	return err
+}
+
+// Run prints the status of the Certificate and each of the resources
+// related to it.
+func (o *Options) Run(args []string) error {
+	crt, err := o.CMClient.CertmanagerV1alpha2().Certificates(o.Namespace).Get(args[0], metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error when getting Certificate resource: %v", err)
+	}
+
+	w := o.Out
+	fmt.Fprintf(w, "Name: %s\n", crt.Name)
+	fmt.Fprintf(w, "Namespace: %s\n", crt.Namespace)
+	fmt.Fprintf(w, "Created at: %s\n", formatTime(&crt.CreationTimestamp))
+	printCertificateConditions(w, crt.Status.Conditions)
+	if len(crt.Spec.DNSNames) > 0 {
+		fmt.Fprintln(w, "DNS Names:")
+		for _, name := range crt.Spec.DNSNames {
+			fmt.Fprintf(w, "- %s\n", name)
+		}
+	}
+
+	o.printIssuer(w, crt)
+	o.printSecret(w, crt)
+
+	if crt.Status.NotAfter != nil {
+		fmt.Fprintf(w, "Not After: %s\n", formatTime(crt.Status.NotAfter))
+	}
+
+	req, err := o.findCertificateRequest(crt)
+	if err != nil {
+		fmt.Fprintf(w, "error when finding CertificateRequest: %v\n", err)
+		return nil
+	}
+	if req == nil {
+		fmt.Fprintln(w, "No CertificateRequest found for this Certificate")
+		return nil
+	}
+	printCertificateRequest(w, req)
+
+	order, err := o.findOrder(req)
+	if err != nil {
+		fmt.Fprintf(w, "error when finding Order: %v\n", err)
+		return nil
+	}
+	if order == nil {
+		return nil
+	}
+	printOrder(w, order)
+
+	challenges, err := o.findChallenges(order)
+	if err != nil {
+		fmt.Fprintf(w, "error when finding Challenges: %v\n", err)
+		return nil
+	}
+	printChallenges(w, challenges)
+
+	return nil
+}
+
+func (o *Options) printIssuer(w io.Writer, crt *cmapi.Certificate) {
+	var (
+		issuer cmapi.GenericIssuer
+		err    error
+	)
+
+	kind := crt.Spec.IssuerRef.Kind
+	switch kind {
+	case "", cmapi.IssuerKind:
+		kind = cmapi.IssuerKind
+		issuer, err = o.CMClient.CertmanagerV1alpha2().Issuers(crt.Namespace).Get(crt.Spec.IssuerRef.Name, metav1.GetOptions{})
+	case cmapi.ClusterIssuerKind:
+		issuer, err = o.CMClient.CertmanagerV1alpha2().ClusterIssuers().Get(crt.Spec.IssuerRef.Name, metav1.GetOptions{})
+	default:
+		fmt.Fprintf(w, "Issuer:\n  Name: %s\n  Kind: %s\n", crt.Spec.IssuerRef.Name, kind)
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(w, "error when getting %s: %v\n", kind, err)
+		return
+	}
+
+	fmt.Fprintln(w, "Issuer:")
+	fmt.Fprintf(w, "  Name: %s\n", issuer.GetName())
+	fmt.Fprintf(w, "  Kind: %s\n", kind)
+	fmt.Fprintln(w, "  Conditions:")
+	for _, c := range issuer.GetStatus().Conditions {
+		fmt.Fprintf(w, "    %s: %s, Reason: %s, Message: %s\n", c.Type, c.Status, c.Reason, c.Message)
+	}
+}
+
+func (o *Options) printSecret(w io.Writer, crt *cmapi.Certificate) {
+	secret, err := o.KubeClient.CoreV1().Secrets(crt.Namespace).Get(crt.Spec.SecretName, metav1.GetOptions{})
+	if err != nil {
+		fmt.Fprintf(w, "error when getting Secret: %v\n", err)
+		return
+	}
+
+	fmt.Fprintln(w, "Secret:")
+	fmt.Fprintf(w, "  Name: %s\n", secret.Name)
+
+	certData := secret.Data[corev1.TLSCertKey]
+	if len(certData) == 0 {
+		fmt.Fprintln(w, "  No certificate found in Secret")
+		return
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(certData)
+	if err != nil {
+		fmt.Fprintf(w, "  error when decoding certificate: %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(w, "  Issuer Country: %s\n", joinOrNone(cert.Issuer.Country))
+	fmt.Fprintf(w, "  Issuer Organisation: %s\n", joinOrNone(cert.Issuer.Organization))
+	fmt.Fprintf(w, "  Issuer Common Name: %s\n", cert.Issuer.CommonName)
+	fmt.Fprintf(w, "  Subject Common Name: %s\n", cert.Subject.CommonName)
+	fmt.Fprintf(w, "  DNS Names: %s\n", joinOrNone(cert.DNSNames))
+	fmt.Fprintf(w, "  IP Addresses: %s\n", joinOrNone(pki.IPAddressesToString(cert.IPAddresses)))
+	fmt.Fprintf(w, "  URIs: %s\n", joinOrNone(pki.URLsToString(cert.URIs)))
+	fmt.Fprintf(w, "  Key Usage: %s\n", joinOrNone(keyUsageNames(cert.KeyUsage)))
+	fmt.Fprintf(w, "  Extended Key Usages: %s\n", joinOrNone(extKeyUsageNamesFor(cert.ExtKeyUsage)))
+	fmt.Fprintf(w, "  Public Key Algorithm: %s\n", cert.PublicKeyAlgorithm)
+	fmt.Fprintf(w, "  Signature Algorithm: %s\n", cert.SignatureAlgorithm)
+	fmt.Fprintf(w, "  Subject Key ID: %x\n", cert.SubjectKeyId)
+	fmt.Fprintf(w, "  Authority Key ID: %x\n", cert.AuthorityKeyId)
+	fmt.Fprintf(w, "  Serial Number: %x\n", cert.SerialNumber)
+	fmt.Fprintf(w, "  Not Before: %s\n", cert.NotBefore.Format(time.RFC3339))
+	fmt.Fprintf(w, "  Not After: %s\n", cert.NotAfter.Format(time.RFC3339))
+}
+
+// findCertificateRequest returns the most recently created
+// CertificateRequest owned by the Certificate, or nil if none exists.
+func (o *Options) findCertificateRequest(crt *cmapi.Certificate) (*cmapi.CertificateRequest, error) {
+	reqs, err := o.CMClient.CertmanagerV1alpha2().CertificateRequests(crt.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var found *cmapi.CertificateRequest
+	for i, req := range reqs.Items {
+		if !ownedBy(req.OwnerReferences, crt.UID) {
+			continue
+		}
+		if found == nil || found.CreationTimestamp.Before(&req.CreationTimestamp) {
+			found = &reqs.Items[i]
+		}
+	}
+
+	return found, nil
+}
+
+// findOrder returns the most recently created Order owned by the
+// CertificateRequest, or nil if none exists.
+func (o *Options) findOrder(req *cmapi.CertificateRequest) (*cmacme.Order, error) {
+	orders, err := o.CMClient.AcmeV1alpha2().Orders(req.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var found *cmacme.Order
+	for i, order := range orders.Items {
+		if !ownedBy(order.OwnerReferences, req.UID) {
+			continue
+		}
+		if found == nil || found.CreationTimestamp.Before(&order.CreationTimestamp) {
+			found = &orders.Items[i]
+		}
+	}
+
+	return found, nil
+}
+
+// findChallenges returns all Challenges owned by the Order.
+func (o *Options) findChallenges(order *cmacme.Order) ([]cmacme.Challenge, error) {
+	challenges, err := o.CMClient.AcmeV1alpha2().Challenges(order.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var found []cmacme.Challenge
+	for _, ch := range challenges.Items {
+		if ownedBy(ch.OwnerReferences, order.UID) {
+			found = append(found, ch)
+		}
+	}
+
+	return found, nil
+}
+
+func printCertificateConditions(w io.Writer, conditions []cmapi.CertificateCondition) {
+	fmt.Fprintln(w, "Conditions:")
+	for _, c := range conditions {
+		fmt.Fprintf(w, "  %s: %s, Reason: %s, Message: %s\n", c.Type, c.Status, c.Reason, c.Message)
+	}
+}
+
+func printCertificateRequest(w io.Writer, req *cmapi.CertificateRequest) {
+	fmt.Fprintln(w, "CertificateRequest:")
+	fmt.Fprintf(w, "  Name: %s\n", req.Name)
+	fmt.Fprintf(w, "  Namespace: %s\n", req.Namespace)
+	fmt.Fprintln(w, "  Conditions:")
+	for _, c := range req.Status.Conditions {
+		fmt.Fprintf(w, "    %s: %s, Reason: %s, Message: %s\n", c.Type, c.Status, c.Reason, c.Message)
+	}
+}
+
+func printOrder(w io.Writer, order *cmacme.Order) {
+	fmt.Fprintln(w, "Order:")
+	fmt.Fprintf(w, "  Name: %s\n", order.Name)
+	fmt.Fprintf(w, "  State: %s, Reason: %s\n", order.Status.State, order.Status.Reason)
+	if len(order.Status.Authorizations) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "  Authorizations:")
+	for _, authz := range order.Status.Authorizations {
+		wildcard := authz.Wildcard != nil && *authz.Wildcard
+		fmt.Fprintf(w, "    URL: %s, Identifier: %s, Wildcard: %t\n", authz.URL, authz.Identifier, wildcard)
+	}
+}
+
+func printChallenges(w io.Writer, challenges []cmacme.Challenge) {
+	if len(challenges) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Challenges:")
+	for _, ch := range challenges {
+		fmt.Fprintf(w, "- Name: %s, Type: %s, Token: %s, Key: %s, State: %s, Reason: %s, Processing: %t, Presented: %t\n",
+			ch.Name, ch.Spec.Type, ch.Spec.Token, ch.Spec.Key, ch.Status.State, ch.Status.Reason, ch.Status.Processing, ch.Status.Presented)
+	}
+}
+
+func ownedBy(refs []metav1.OwnerReference, uid types.UID) bool {
+	for _, ref := range refs {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+func formatTime(t *metav1.Time) string {
+	return t.Time.Format(time.RFC3339)
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmfake "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		expectedErr bool
+	}{
+		"a single name given": {
+			args: []string{"abc"},
+		},
+		"no name given": {
+			expectedErr: true,
+		},
+		"multiple names given": {
+			args:        []string{"abc", "def"},
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := NewOptions(factory.IOStreams{}).Validate(test.args)
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+		})
+	}
+}
+
+func TestFindCertificateRequest(t *testing.T) {
+	const crtUID = types.UID("crt-uid")
+	crt := gen.Certificate("crt", gen.SetCertificateNamespace("default"))
+	crt.UID = crtUID
+
+	now := time.Now()
+	req := func(name, namespace string, owner types.UID, created time.Time) *cmapi.CertificateRequest {
+		r := gen.CertificateRequest(name, gen.SetCertificateRequestNamespace(namespace))
+		r.CreationTimestamp = metav1.NewTime(created)
+		if owner != "" {
+			r.OwnerReferences = []metav1.OwnerReference{{UID: owner}}
+		}
+		return r
+	}
+
+	tests := map[string]struct {
+		objects      []runtime.Object
+		expectedName string
+	}{
+		"no CertificateRequests": {},
+		"a CertificateRequest owned by the Certificate should be found": {
+			objects:      []runtime.Object{req("a", "default", crtUID, now)},
+			expectedName: "a",
+		},
+		"CertificateRequests not owned by the Certificate should be ignored": {
+			objects: []runtime.Object{
+				req("a", "default", "other-uid", now),
+				req("b", "default", "", now),
+			},
+		},
+		"CertificateRequests in other namespaces should be ignored": {
+			objects: []runtime.Object{req("a", "other", crtUID, now)},
+		},
+		"the most recently created CertificateRequest should be found": {
+			objects: []runtime.Object{
+				req("a", "default", crtUID, now.Add(-2*time.Hour)),
+				req("b", "default", crtUID, now),
+				req("c", "default", crtUID, now.Add(-time.Hour)),
+			},
+			expectedName: "b",
+		},
+		"a newer CertificateRequest not owned by the Certificate should be ignored": {
+			objects: []runtime.Object{
+				req("a", "default", crtUID, now.Add(-time.Hour)),
+				req("b", "default", "other-uid", now),
+			},
+			expectedName: "a",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(factory.IOStreams{})
+			o.CMClient = cmfake.NewSimpleClientset(test.objects...)
+
+			found, err := o.findCertificateRequest(crt)
+			if err != nil {
+				t.Fatalf("expected to not get an error, but got: %v", err)
+			}
+
+			var foundName string
+			if found != nil {
+				foundName = found.Name
+			}
+			if foundName != test.expectedName {
+				t.Errorf("expected to find CertificateRequest %q, but got %q", test.expectedName, foundName)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	crt := gen.Certificate("crt",
+		gen.SetCertificateNamespace("default"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateSecretName("crt-tls"),
+	)
+	crt.UID = "crt-uid"
+	req := gen.CertificateRequest("crt-1", gen.SetCertificateRequestNamespace("default"))
+	req.OwnerReferences = []metav1.OwnerReference{{UID: crt.UID}}
+
+	tests := map[string]struct {
+		objects        []runtime.Object
+		expectedOutput []string
+		expectedErr    bool
+	}{
+		"print the Certificate and its CertificateRequest": {
+			objects: []runtime.Object{crt, req},
+			expectedOutput: []string{
+				"Name: crt\n",
+				"- example.com\n",
+				"CertificateRequest:\n  Name: crt-1\n",
+			},
+		},
+		"report a Certificate without a CertificateRequest": {
+			objects: []runtime.Object{crt},
+			expectedOutput: []string{
+				"Name: crt\n",
+				"No CertificateRequest found for this Certificate\n",
+			},
+		},
+		"fail if the Certificate does not exist": {
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			o := NewOptions(factory.IOStreams{Out: out, ErrOut: new(bytes.Buffer)})
+			o.Namespace = "default"
+			o.CMClient = cmfake.NewSimpleClientset(test.objects...)
+			o.KubeClient = kubefake.NewSimpleClientset()
+
+			err := o.Run([]string{"crt"})
+			if err != nil && !test.expectedErr {
+				t.Fatalf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Fatalf("expected to get an error but did not get one")
+			}
+
+			for _, s := range test.expectedOutput {
+				if !strings.Contains(out.String(), s) {
+					t.Errorf("expected output to contain %q, but got:\n%s", s, out.String())
+				}
+			}
+		})
+	}
+}
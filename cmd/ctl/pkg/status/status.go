@@ -0,0 +1,38 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/status/certificate"
+)
+
+// NewCmdStatus returns a cobra command for getting the status of
+// cert-manager resources.
+func NewCmdStatus(ioStreams factory.IOStreams, f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Get details on current status of cert-manager resources",
+		Long:  `Get details on current status of cert-manager resources, e.g. Certificate`,
+	}
+
+	cmd.AddCommand(certificate.NewCmdStatusCertificate(ioStreams, f))
+
+	return cmd
+}
@@ -1377,6 +1377,14 @@ def go_repositories():
         sum = "h1:HRMgzkcYKYpi3C8ajMPV8OFXaaRUnok+kx1WdO15EQc=",
         version = "v2.1.0+incompatible",
     )
+    go_repository(
+        name = "com_github_pavel_v_chernykh_keystore_go",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/pavel-v-chernykh/keystore-go",
+        sum = "h1:Jd6xfriVlJ6hWPvYOE0Ni0QWcNTLRehfGPFxr3eSL80=",
+        version = "v2.1.0+incompatible",
+    )
     go_repository(
         name = "com_github_pborman_uuid",
         build_file_generation = "on",
@@ -1679,8 +1687,8 @@ def go_repositories():
         build_file_generation = "on",
         build_file_proto_mode = "disable",
         importpath = "software.sslmate.com/src/go-pkcs12",
-        sum = "h1:iAEkCBPbRaflBgZ7o9gjVUuWuvWeV4sytFWg9o+Pj2k=",
-        version = "v0.0.0-20180114231543-2291e8f0f237",
+        sum = "h1:AVd6O+azYjVQYW1l55IqkbL8/JxjrLtO6q4FCmV8N5c=",
+        version = "v0.0.0-20200830195227-52f69702a001",
     )
     go_repository(
         name = "in_gopkg_alecthomas_kingpin_v2",
@@ -18,8 +18,9 @@ package acme
 
 import (
 	"context"
-	"crypto/rsa"
+	"crypto"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
 	"net/http"
@@ -41,13 +42,16 @@ import (
 // the ClientWithKey function below.
 
 // ClientWithKey will construct a new ACME client for the provided Issuer, using
-// the given RSA private key.
-func ClientWithKey(iss cmapi.GenericIssuer, pk *rsa.PrivateKey) (acme.Interface, error) {
+// the given RSA or ECDSA private key.
+func ClientWithKey(iss cmapi.GenericIssuer, pk crypto.Signer) (acme.Interface, error) {
 	acmeSpec := iss.GetSpec().ACME
 	if acmeSpec == nil {
 		return nil, fmt.Errorf("issuer %q is not an ACME issuer. Ensure the 'acme' stanza is correctly specified on your Issuer resource", iss.GetObjectMeta().Name)
 	}
-	acmeCl := lookupClient(acmeSpec, pk)
+	acmeCl, err := lookupClient(acmeSpec, pk)
+	if err != nil {
+		return nil, err
+	}
 
 	return acmemw.NewLogger(acmeCl), nil
 }
@@ -57,7 +61,7 @@ func ClientWithKey(iss cmapi.GenericIssuer, pk *rsa.PrivateKey) (acme.Interface,
 // that the cert-manager controllers can concurrently access
 // the anti-replay nonces and directory information.
 var (
-	clientRepo   map[repoKey]*acmecl.Client
+	clientRepo   map[repoKey]*acme.Client
 	clientRepoMu sync.Mutex
 )
 
@@ -65,36 +69,38 @@ type repoKey struct {
 	skiptls   bool
 	server    string
 	publickey string
-	exponent  int
 }
 
-func lookupClient(spec *cmacme.ACMEIssuer, pk *rsa.PrivateKey) *acmecl.Client {
+func lookupClient(spec *cmacme.ACMEIssuer, pk crypto.Signer) (*acme.Client, error) {
+	// the DER encoding of the public key identifies both RSA and ECDSA keys
+	pkbytes, err := x509.MarshalPKIXPublicKey(pk.Public())
+	if err != nil {
+		return nil, fmt.Errorf("error encoding ACME account public key: %v", err)
+	}
+
 	clientRepoMu.Lock()
 	defer clientRepoMu.Unlock()
 	if clientRepo == nil {
-		clientRepo = make(map[repoKey]*acmecl.Client)
+		clientRepo = make(map[repoKey]*acme.Client)
 	}
 	repokey := repoKey{
-		skiptls: spec.SkipTLSVerify,
-		server:  spec.Server,
+		skiptls:   spec.SkipTLSVerify,
+		server:    spec.Server,
+		publickey: string(pkbytes),
 	}
-	// Encoding a big.Int cannot fail
-	pkbytes, _ := pk.PublicKey.N.GobEncode()
-	repokey.publickey = string(pkbytes)
-	repokey.exponent = pk.PublicKey.E
 
 	client := clientRepo[repokey]
 	if client != nil {
-		return client
+		return client, nil
 	}
-	acmeCl := &acmecl.Client{
+	acmeCl := acme.NewClient(&acmecl.Client{
 		HTTPClient:   buildHTTPClient(spec.SkipTLSVerify),
 		Key:          pk,
 		DirectoryURL: spec.Server,
 		UserAgent:    util.CertManagerUserAgent,
-	}
+	})
 	clientRepo[repokey] = acmeCl
-	return acmeCl
+	return acmeCl, nil
 }
 
 func ClearClientCache() {
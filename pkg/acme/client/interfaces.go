@@ -18,6 +18,8 @@ package client
 
 import (
 	"context"
+	"crypto"
+	"crypto/x509"
 
 	"golang.org/x/crypto/acme"
 )
@@ -38,6 +40,8 @@ type Interface interface {
 	DNS01ChallengeRecord(token string) (string, error)
 	Discover(ctx context.Context) (acme.Directory, error)
 	UpdateReg(ctx context.Context, a *acme.Account) (*acme.Account, error)
+	DeactivateReg(ctx context.Context) error
+	RevokeCert(ctx context.Context, key crypto.Signer, cert []byte, reason acme.CRLReasonCode) error
+	GetRenewalInfo(ctx context.Context, cert *x509.Certificate) (*RenewalInfo, error)
+	AccountKeyRollover(ctx context.Context, accountURL string, newKey crypto.Signer) error
 }
-
-var _ Interface = &acme.Client{}
@@ -0,0 +1,158 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256" // needed for RS256 and ES256
+	_ "crypto/sha512" // needed for ES384 and ES512
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/acme"
+)
+
+// The JWS helpers in this file follow those in golang.org/x/crypto/acme,
+// which are not exported. They are needed to sign requests the ACME client
+// does not support, such as account key rollover.
+
+// jwsEncodeJSON signs payload with the given key. If kid is empty, the JWK of
+// the key is included in the protected header instead of the key ID. If nonce
+// is empty, no nonce is included, as is required for the inner JWS of a key
+// change request.
+func jwsEncodeJSON(payload interface{}, key crypto.Signer, kid, nonce, url string) ([]byte, error) {
+	alg, hash := jwsHasher(key.Public())
+	if alg == "" || !hash.Available() {
+		return nil, acme.ErrUnsupportedKey
+	}
+
+	header := map[string]interface{}{
+		"alg": alg,
+		"url": url,
+	}
+	if kid == "" {
+		jwk, err := jwkEncode(key.Public())
+		if err != nil {
+			return nil, err
+		}
+		header["jwk"] = json.RawMessage(jwk)
+	} else {
+		header["kid"] = kid
+	}
+	if nonce != "" {
+		header["nonce"] = nonce
+	}
+
+	protectedJSON, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	protected := base64.RawURLEncoding.EncodeToString(protectedJSON)
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payloadJSON)
+
+	h := hash.New()
+	h.Write([]byte(protected + "." + encodedPayload))
+	sig, err := jwsSign(key, hash, h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct {
+		Protected string `json:"protected"`
+		Payload   string `json:"payload"`
+		Signature string `json:"signature"`
+	}{
+		Protected: protected,
+		Payload:   encodedPayload,
+		Signature: base64.RawURLEncoding.EncodeToString(sig),
+	})
+}
+
+// jwkEncode encodes the public part of an RSA or ECDSA key as a JWK, with
+// its fields in the order required for computing a JWK thumbprint.
+func jwkEncode(pub crypto.PublicKey) (string, error) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`,
+			base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		), nil
+	case *ecdsa.PublicKey:
+		p := pub.Curve.Params()
+		size := (p.BitSize + 7) / 8
+		return fmt.Sprintf(`{"crv":"%s","kty":"EC","x":"%s","y":"%s"}`,
+			p.Name,
+			base64.RawURLEncoding.EncodeToString(padBytes(pub.X.Bytes(), size)),
+			base64.RawURLEncoding.EncodeToString(padBytes(pub.Y.Bytes(), size)),
+		), nil
+	}
+
+	return "", acme.ErrUnsupportedKey
+}
+
+// jwsSign signs the digest with the given key. ECDSA signatures are encoded
+// as the concatenation of R and S as required by RFC 7518, rather than the
+// ASN.1 encoding returned by ecdsa.PrivateKey's Sign method.
+func jwsSign(key crypto.Signer, hash crypto.Hash, digest []byte) ([]byte, error) {
+	if key, ok := key.(*ecdsa.PrivateKey); ok {
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+		if err != nil {
+			return nil, err
+		}
+		size := (key.Params().BitSize + 7) / 8
+		return append(padBytes(r.Bytes(), size), padBytes(s.Bytes(), size)...), nil
+	}
+
+	return key.Sign(rand.Reader, digest, hash)
+}
+
+// jwsHasher returns the JWS algorithm name and hash function to use when
+// signing with the given key, or an empty name if the key is not supported.
+func jwsHasher(pub crypto.PublicKey) (string, crypto.Hash) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return "RS256", crypto.SHA256
+	case *ecdsa.PublicKey:
+		switch pub.Params().Name {
+		case "P-256":
+			return "ES256", crypto.SHA256
+		case "P-384":
+			return "ES384", crypto.SHA384
+		case "P-521":
+			return "ES512", crypto.SHA512
+		}
+	}
+
+	return "", 0
+}
+
+func padBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	return append(make([]byte, size-len(b)), b...)
+}
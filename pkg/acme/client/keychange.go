@@ -0,0 +1,143 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"golang.org/x/crypto/acme"
+)
+
+// ErrKeyChangeNotSupported is returned by AccountKeyRollover if the ACME
+// server's directory does not advertise a keyChange endpoint.
+var ErrKeyChangeNotSupported = errors.New("acme: server does not support account key rollover")
+
+// AccountKeyRollover replaces the key of the ACME account with the given URL
+// by newKey, as described in RFC 8555 section 7.3.5. The client must be
+// configured with the account's current key. Once the rollover has
+// succeeded, the client can no longer be used to access the account.
+func (c *Client) AccountKeyRollover(ctx context.Context, accountURL string, newKey crypto.Signer) error {
+	dir, err := c.Discover(ctx)
+	if err != nil {
+		return err
+	}
+	if dir.KeyChangeURL == "" || dir.NonceURL == "" {
+		return ErrKeyChangeNotSupported
+	}
+
+	oldKey, err := jwkEncode(c.Key.Public())
+	if err != nil {
+		return err
+	}
+
+	// the inner JWS is signed by the new key, and proves the holder of the
+	// new key agrees to the change
+	inner, err := jwsEncodeJSON(struct {
+		Account string          `json:"account"`
+		OldKey  json.RawMessage `json:"oldKey"`
+	}{
+		Account: accountURL,
+		OldKey:  json.RawMessage(oldKey),
+	}, newKey, "", "", dir.KeyChangeURL)
+	if err != nil {
+		return err
+	}
+
+	nonce, err := c.fetchNonce(ctx, dir.NonceURL)
+	if err != nil {
+		return err
+	}
+
+	// the outer JWS is signed by the current key of the account
+	body, err := jwsEncodeJSON(json.RawMessage(inner), c.Key, accountURL, nonce, dir.KeyChangeURL)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, dir.KeyChangeURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/jose+json")
+
+	res, err := c.do(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return responseError(res)
+	}
+
+	return nil
+}
+
+// fetchNonce fetches a new anti-replay nonce from the ACME server's newNonce
+// endpoint.
+func (c *Client) fetchNonce(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequest(http.MethodHead, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := c.do(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	nonce := res.Header.Get("Replay-Nonce")
+	if nonce == "" {
+		if res.StatusCode >= http.StatusBadRequest {
+			return "", responseError(res)
+		}
+		return "", errors.New("acme: nonce not found")
+	}
+
+	return nonce, nil
+}
+
+// responseError builds an *acme.Error from an error response returned by
+// the ACME server.
+func responseError(res *http.Response) error {
+	var problem struct {
+		Type   string `json:"type"`
+		Detail string `json:"detail"`
+	}
+
+	b, _ := ioutil.ReadAll(res.Body)
+	if err := json.Unmarshal(b, &problem); err != nil {
+		problem.Detail = string(b)
+		if problem.Detail == "" {
+			problem.Detail = res.Status
+		}
+	}
+
+	return &acme.Error{
+		StatusCode:  res.StatusCode,
+		ProblemType: problem.Type,
+		Detail:      problem.Detail,
+		Header:      res.Header,
+	}
+}
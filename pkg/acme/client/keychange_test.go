@@ -0,0 +1,183 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/crypto/acme"
+)
+
+type testJWS struct {
+	Protected string `json:"protected"`
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+// decodeJWS verifies the signature of a JWS with the given public key, and
+// returns its decoded protected header and payload.
+func decodeJWS(t *testing.T, b []byte, pub crypto.PublicKey) (map[string]interface{}, []byte) {
+	var jws testJWS
+	if err := json.Unmarshal(b, &jws); err != nil {
+		t.Fatalf("invalid JWS: %v", err)
+	}
+
+	sig, _ := base64.RawURLEncoding.DecodeString(jws.Signature)
+	digest := sha256.Sum256([]byte(jws.Protected + "." + jws.Payload))
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+			t.Errorf("invalid RSA signature: %v", err)
+		}
+	case *ecdsa.PublicKey:
+		r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+		if !ecdsa.Verify(pub, digest[:], r, s) {
+			t.Errorf("invalid ECDSA signature")
+		}
+	}
+
+	protectedJSON, _ := base64.RawURLEncoding.DecodeString(jws.Protected)
+	var protected map[string]interface{}
+	if err := json.Unmarshal(protectedJSON, &protected); err != nil {
+		t.Fatalf("invalid JWS protected header: %v", err)
+	}
+	payload, _ := base64.RawURLEncoding.DecodeString(jws.Payload)
+
+	return protected, payload
+}
+
+func TestAccountKeyRollover(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldJWK, _ := jwkEncode(oldKey.Public())
+	newJWK, _ := jwkEncode(newKey.Public())
+
+	tests := map[string]struct {
+		directory string
+		status    int
+		body      string
+
+		expectedErr    error
+		expectedStatus int
+	}{
+		"the account key should be changed using the directory's keyChange endpoint": {
+			directory: `{"newOrder": "%[1]s/new-order", "newNonce": "%[1]s/new-nonce", "keyChange": "%[1]s/key-change"}`,
+			status:    http.StatusOK,
+		},
+		"a directory without a keyChange endpoint should not be supported": {
+			directory:   `{"newOrder": "%[1]s/new-order", "newNonce": "%[1]s/new-nonce"}`,
+			expectedErr: ErrKeyChangeNotSupported,
+		},
+		"an error response should be returned as an ACME error": {
+			directory:      `{"newOrder": "%[1]s/new-order", "newNonce": "%[1]s/new-nonce", "keyChange": "%[1]s/key-change"}`,
+			status:         http.StatusConflict,
+			body:           `{"type": "urn:ietf:params:acme:error:malformed", "detail": "key is already in use"}`,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var keyChanged bool
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/directory":
+					w.Header().Set("Replay-Nonce", "directory-nonce")
+					fmt.Fprintf(w, test.directory, server.URL)
+				case "/new-nonce":
+					w.Header().Set("Replay-Nonce", "fresh-nonce")
+				case "/key-change":
+					keyChanged = true
+					body, _ := ioutil.ReadAll(r.Body)
+
+					outer, inner := decodeJWS(t, body, oldKey.Public())
+					if outer["kid"] != server.URL+"/account/1" || outer["url"] != server.URL+"/key-change" || outer["alg"] != "RS256" || outer["nonce"] == nil {
+						t.Errorf("unexpected outer JWS protected header: %v", outer)
+					}
+
+					header, payload := decodeJWS(t, inner, newKey.Public())
+					if header["url"] != server.URL+"/key-change" || header["alg"] != "ES256" || header["nonce"] != nil {
+						t.Errorf("unexpected inner JWS protected header: %v", header)
+					}
+					if jwk, _ := json.Marshal(header["jwk"]); string(jwk) != newJWK {
+						t.Errorf("unexpected inner JWS key, exp=%s got=%s", newJWK, jwk)
+					}
+
+					var keyChange struct {
+						Account string          `json:"account"`
+						OldKey  json.RawMessage `json:"oldKey"`
+					}
+					if err := json.Unmarshal(payload, &keyChange); err != nil {
+						t.Errorf("invalid key change payload: %v", err)
+					}
+					if keyChange.Account != server.URL+"/account/1" || string(keyChange.OldKey) != oldJWK {
+						t.Errorf("unexpected key change payload: %s", payload)
+					}
+
+					w.WriteHeader(test.status)
+					fmt.Fprint(w, test.body)
+				default:
+					t.Errorf("unexpected request to %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			cl := NewClient(&acme.Client{Key: oldKey, DirectoryURL: server.URL + "/directory"})
+			err := cl.AccountKeyRollover(context.Background(), server.URL+"/account/1", newKey)
+
+			switch {
+			case test.expectedErr != nil:
+				if err != test.expectedErr {
+					t.Errorf("unexpected error, exp=%v got=%v", test.expectedErr, err)
+				}
+			case test.expectedStatus != 0:
+				acmeErr, ok := err.(*acme.Error)
+				if !ok || acmeErr.StatusCode != test.expectedStatus {
+					t.Errorf("expected an ACME error with status %d, got %v", test.expectedStatus, err)
+				}
+			case err != nil:
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if keyChanged != (test.expectedErr == nil) {
+				t.Errorf("unexpected key change request, exp=%t got=%t", test.expectedErr == nil, keyChanged)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package middleware
 
 import (
 	"context"
+	"crypto"
+	"crypto/x509"
 
 	"golang.org/x/crypto/acme"
 	"k8s.io/klog"
@@ -110,3 +112,23 @@ func (l *Logger) UpdateReg(ctx context.Context, a *acme.Account) (*acme.Account,
 	klog.Infof("Calling UpdateAccount")
 	return l.baseCl.UpdateReg(ctx, a)
 }
+
+func (l *Logger) DeactivateReg(ctx context.Context) error {
+	klog.Infof("Calling DeactivateReg")
+	return l.baseCl.DeactivateReg(ctx)
+}
+
+func (l *Logger) RevokeCert(ctx context.Context, key crypto.Signer, cert []byte, reason acme.CRLReasonCode) error {
+	klog.Infof("Calling RevokeCert")
+	return l.baseCl.RevokeCert(ctx, key, cert, reason)
+}
+
+func (l *Logger) GetRenewalInfo(ctx context.Context, cert *x509.Certificate) (*client.RenewalInfo, error) {
+	klog.Infof("Calling GetRenewalInfo")
+	return l.baseCl.GetRenewalInfo(ctx, cert)
+}
+
+func (l *Logger) AccountKeyRollover(ctx context.Context, accountURL string, newKey crypto.Signer) error {
+	klog.Infof("Calling AccountKeyRollover")
+	return l.baseCl.AccountKeyRollover(ctx, accountURL, newKey)
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+// DefaultRenewalInfoRetryAfter is how long to wait before fetching updated
+// renewal information if the ACME server does not return a Retry-After
+// header.
+const DefaultRenewalInfoRetryAfter = 6 * time.Hour
+
+// ErrRenewalInfoNotSupported is returned by GetRenewalInfo if the ACME
+// server's directory does not advertise a renewalInfo endpoint.
+var ErrRenewalInfoNotSupported = errors.New("acme: server does not support renewal information")
+
+// RenewalInfo is the renewal information suggested by an ACME server for a
+// certificate, as described by the ACME Renewal Information (ARI) extension.
+type RenewalInfo struct {
+	SuggestedWindow RenewalWindow `json:"suggestedWindow"`
+	ExplanationURL  string        `json:"explanationURL,omitempty"`
+
+	// RetryAfter is how long to wait before fetching updated renewal
+	// information for the certificate.
+	RetryAfter time.Duration `json:"-"`
+}
+
+// RenewalWindow is the window in which an ACME server suggests a certificate
+// is renewed.
+type RenewalWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Client is an ACME client that supports the ACME extensions cert-manager
+// uses which are not implemented by golang.org/x/crypto/acme.
+type Client struct {
+	*acme.Client
+
+	// lock protects renewalInfoURL
+	lock sync.Mutex
+	// renewalInfoURL is the renewalInfo endpoint read from the directory, or
+	// nil if the directory has not been fetched yet
+	renewalInfoURL *string
+}
+
+var _ Interface = &Client{}
+
+// NewClient returns a Client wrapping the given ACME client.
+func NewClient(cl *acme.Client) *Client {
+	return &Client{Client: cl}
+}
+
+// GetRenewalInfo fetches the renewal information suggested by the ACME
+// server for the given certificate. The certificate must have an authority
+// key identifier.
+func (c *Client) GetRenewalInfo(ctx context.Context, cert *x509.Certificate) (*RenewalInfo, error) {
+	certID, err := renewalInfoCertID(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := c.renewalInfoEndpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == "" {
+		return nil, ErrRenewalInfoNotSupported
+	}
+
+	res, err := c.get(ctx, strings.TrimSuffix(endpoint, "/")+"/"+certID)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("acme: unexpected status code %d fetching renewal information", res.StatusCode)
+	}
+
+	info := &RenewalInfo{}
+	if err := json.NewDecoder(res.Body).Decode(info); err != nil {
+		return nil, fmt.Errorf("acme: invalid renewal information: %v", err)
+	}
+	if info.SuggestedWindow.Start.IsZero() || !info.SuggestedWindow.End.After(info.SuggestedWindow.Start) {
+		return nil, fmt.Errorf("acme: invalid suggested renewal window %s - %s", info.SuggestedWindow.Start, info.SuggestedWindow.End)
+	}
+
+	info.RetryAfter = retryAfter(res.Header.Get("Retry-After"), time.Now())
+
+	return info, nil
+}
+
+// renewalInfoEndpoint returns the renewalInfo URL advertised in the ACME
+// server's directory, or an empty string if there is none. The
+// golang.org/x/crypto/acme Directory type does not expose the endpoint, so
+// the directory is fetched and cached here.
+func (c *Client) renewalInfoEndpoint(ctx context.Context) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.renewalInfoURL != nil {
+		return *c.renewalInfoURL, nil
+	}
+
+	res, err := c.get(ctx, c.DirectoryURL)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("acme: unexpected status code %d fetching directory", res.StatusCode)
+	}
+
+	var dir struct {
+		RenewalInfo string `json:"renewalInfo"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&dir); err != nil {
+		return "", fmt.Errorf("acme: invalid directory: %v", err)
+	}
+
+	c.renewalInfoURL = &dir.RenewalInfo
+
+	return dir.RenewalInfo, nil
+}
+
+func (c *Client) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(ctx, req)
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	cl := c.HTTPClient
+	if cl == nil {
+		cl = http.DefaultClient
+	}
+
+	return cl.Do(req.WithContext(ctx))
+}
+
+// renewalInfoCertID returns the identifier of a certificate used in ARI
+// requests: the base64url encoded key identifier of its authority key
+// identifier and its DER encoded serial number, joined by a period.
+func renewalInfoCertID(cert *x509.Certificate) (string, error) {
+	if len(cert.AuthorityKeyId) == 0 {
+		return "", errors.New("acme: certificate does not have an authority key identifier")
+	}
+
+	serial := cert.SerialNumber.Bytes()
+	// the serial number is a positive DER INTEGER, so must be prefixed with a
+	// zero byte if its most significant bit is set
+	if len(serial) == 0 || serial[0]&0x80 != 0 {
+		serial = append([]byte{0}, serial...)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(cert.AuthorityKeyId) + "." + base64.RawURLEncoding.EncodeToString(serial), nil
+}
+
+// retryAfter parses the value of a Retry-After header, which may be either a
+// number of seconds or an HTTP date.
+func retryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return DefaultRenewalInfoRetryAfter
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+
+	return DefaultRenewalInfoRetryAfter
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+func TestRenewalInfoCertID(t *testing.T) {
+	// example taken from the ACME Renewal Information specification
+	aki, _ := hex.DecodeString("69885B6B87464041E1B37B847BA0AE2CDE01C8D4")
+	serial, _ := new(big.Int).SetString("87654321", 16)
+
+	id, err := renewalInfoCertID(&x509.Certificate{AuthorityKeyId: aki, SerialNumber: serial})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp := "aYhba4dGQEHhs3uEe6CuLN4ByNQ.AIdlQyE"; id != exp {
+		t.Errorf("unexpected cert ID, exp=%s got=%s", exp, id)
+	}
+
+	if _, err := renewalInfoCertID(&x509.Certificate{SerialNumber: serial}); err == nil {
+		t.Errorf("expected an error for a certificate without an authority key identifier")
+	}
+}
+
+func TestGetRenewalInfo(t *testing.T) {
+	cert := &x509.Certificate{AuthorityKeyId: []byte{1, 2, 3}, SerialNumber: big.NewInt(0x1a2b)}
+
+	tests := map[string]struct {
+		directory   string
+		status      int
+		body        string
+		retryAfter  string
+		expectedErr error
+
+		expectedInfo *RenewalInfo
+	}{
+		"renewal information should be fetched from the directory's renewalInfo endpoint": {
+			directory:  `{"renewalInfo": "%s/renewal-info/"}`,
+			status:     http.StatusOK,
+			body:       `{"suggestedWindow": {"start": "2020-06-01T00:00:00Z", "end": "2020-06-03T00:00:00Z"}, "explanationURL": "https://example.com/incident"}`,
+			retryAfter: "3600",
+			expectedInfo: &RenewalInfo{
+				SuggestedWindow: RenewalWindow{
+					Start: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+					End:   time.Date(2020, 6, 3, 0, 0, 0, 0, time.UTC),
+				},
+				ExplanationURL: "https://example.com/incident",
+				RetryAfter:     time.Hour,
+			},
+		},
+		"the default retry after should be used if none is returned": {
+			directory: `{"renewalInfo": "%s/renewal-info"}`,
+			status:    http.StatusOK,
+			body:      `{"suggestedWindow": {"start": "2020-06-01T00:00:00Z", "end": "2020-06-03T00:00:00Z"}}`,
+			expectedInfo: &RenewalInfo{
+				SuggestedWindow: RenewalWindow{
+					Start: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+					End:   time.Date(2020, 6, 3, 0, 0, 0, 0, time.UTC),
+				},
+				RetryAfter: DefaultRenewalInfoRetryAfter,
+			},
+		},
+		"a directory without a renewalInfo endpoint should not be supported": {
+			directory:   `{"newOrder": "%s/new-order"}`,
+			expectedErr: ErrRenewalInfoNotSupported,
+		},
+		"an invalid suggested window should be an error": {
+			directory: `{"renewalInfo": "%s/renewal-info"}`,
+			status:    http.StatusOK,
+			body:      `{"suggestedWindow": {"start": "2020-06-03T00:00:00Z", "end": "2020-06-01T00:00:00Z"}}`,
+		},
+		"an error response should be an error": {
+			directory: `{"renewalInfo": "%s/renewal-info"}`,
+			status:    http.StatusNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var directoryRequests int
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/directory":
+					directoryRequests++
+					fmt.Fprintf(w, test.directory, server.URL)
+				case "/renewal-info/AQID.Gis":
+					if test.retryAfter != "" {
+						w.Header().Set("Retry-After", test.retryAfter)
+					}
+					w.WriteHeader(test.status)
+					fmt.Fprint(w, test.body)
+				default:
+					t.Errorf("unexpected request to %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			cl := NewClient(&acme.Client{DirectoryURL: server.URL + "/directory"})
+
+			for i := 0; i < 2; i++ {
+				info, err := cl.GetRenewalInfo(context.Background(), cert)
+				if test.expectedInfo == nil && test.expectedErr == nil {
+					if err == nil {
+						t.Errorf("expected an error but got none")
+					}
+					continue
+				}
+				if err != test.expectedErr {
+					t.Fatalf("unexpected error, exp=%v got=%v", test.expectedErr, err)
+				}
+				if test.expectedInfo == nil {
+					continue
+				}
+				if !info.SuggestedWindow.Start.Equal(test.expectedInfo.SuggestedWindow.Start) ||
+					!info.SuggestedWindow.End.Equal(test.expectedInfo.SuggestedWindow.End) ||
+					info.ExplanationURL != test.expectedInfo.ExplanationURL ||
+					info.RetryAfter != test.expectedInfo.RetryAfter {
+					t.Errorf("unexpected renewal info, exp=%+v got=%+v", test.expectedInfo, info)
+				}
+			}
+
+			if directoryRequests != 1 {
+				t.Errorf("expected the directory to be fetched once, got %d requests", directoryRequests)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]time.Duration{
+		"":                              DefaultRenewalInfoRetryAfter,
+		"120":                           2 * time.Minute,
+		"0":                             DefaultRenewalInfoRetryAfter,
+		"Mon, 01 Jun 2020 02:00:00 GMT": 2 * time.Hour,
+		"Sun, 31 May 2020 00:00:00 GMT": DefaultRenewalInfoRetryAfter,
+		"invalid":                       DefaultRenewalInfoRetryAfter,
+	}
+
+	for v, exp := range tests {
+		if got := retryAfter(v, now); got != exp {
+			t.Errorf("retryAfter(%q): exp=%s got=%s", v, exp, got)
+		}
+	}
+}
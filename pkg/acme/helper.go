@@ -17,8 +17,12 @@ limitations under the License.
 package acme
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rsa"
 	"fmt"
+
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	acme "github.com/jetstack/cert-manager/pkg/acme/client"
@@ -31,7 +35,7 @@ import (
 
 type Helper interface {
 	ClientForIssuer(iss cmapi.GenericIssuer) (acme.Interface, error)
-	ReadPrivateKey(sel cmmeta.SecretKeySelector, ns string) (*rsa.PrivateKey, error)
+	ReadPrivateKey(sel cmmeta.SecretKeySelector, ns string) (crypto.Signer, error)
 }
 
 // Helper is a structure that provides 'glue' between cert-managers API types and
@@ -58,9 +62,9 @@ func NewHelper(lister corelisters.SecretLister, ns string) Helper {
 // ReadPrivateKey will attempt to read and parse an ACME private key from a secret.
 // If the referenced secret or key within that secret does not exist, an error will
 // be returned.
-// A *rsa.PrivateKey will be returned here, as ACME private keys can currently
-// only be RSA.
-func (h *helperImpl) ReadPrivateKey(sel cmmeta.SecretKeySelector, ns string) (*rsa.PrivateKey, error) {
+// ACME private keys may be RSA keys, or ECDSA keys using the P-256, P-384 or
+// P-521 curves.
+func (h *helperImpl) ReadPrivateKey(sel cmmeta.SecretKeySelector, ns string) (crypto.Signer, error) {
 	sel = PrivateKeySelector(sel)
 
 	s, err := h.SecretLister.Secrets(ns).Get(sel.Name)
@@ -79,12 +83,28 @@ func (h *helperImpl) ReadPrivateKey(sel cmmeta.SecretKeySelector, ns string) (*r
 		return nil, err
 	}
 
-	rsaKey, ok := pk.(*rsa.PrivateKey)
-	if !ok {
-		return nil, cmerrors.NewInvalidData("ACME private key in %q is not of type RSA", sel.Name)
+	if err := ValidatePrivateKey(pk); err != nil {
+		return nil, cmerrors.NewInvalidData("ACME private key in %q is not valid: %v", sel.Name, err)
+	}
+
+	return pk, nil
+}
+
+// ValidatePrivateKey returns an error if the given key cannot be used as an
+// ACME account private key.
+func ValidatePrivateKey(pk crypto.Signer) error {
+	switch k := pk.(type) {
+	case *rsa.PrivateKey:
+		return nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256(), elliptic.P384(), elliptic.P521():
+			return nil
+		}
+		return fmt.Errorf("unsupported ECDSA curve %q, must be one of P-256, P-384 or P-521", k.Curve.Params().Name)
 	}
 
-	return rsaKey, nil
+	return fmt.Errorf("unsupported key type %T, must be either RSA or ECDSA", pk)
 }
 
 // ClientForIssuer will return a properly configure ACME client for the given
@@ -23,6 +23,10 @@ import (
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 )
 
+// previousPrivateKeySuffix is appended to the key of the ACME account private
+// key in its secret to find the previous key during an account key rollover.
+const previousPrivateKeySuffix = ".previous"
+
 // IsFinalState will return true if the given ACME State is a 'final' state.
 // This is either one of 'ready', 'invalid' or 'expired'.
 // The 'valid' state is a special case, as it is a final state for Challenges but
@@ -51,3 +55,12 @@ func PrivateKeySelector(sel cmmeta.SecretKeySelector) cmmeta.SecretKeySelector {
 	}
 	return sel
 }
+
+// PreviousPrivateKeySelector returns the SecretKeySelector for the previous
+// ACME account private key, which is stored alongside the current key during
+// an account key rollover.
+func PreviousPrivateKeySelector(sel cmmeta.SecretKeySelector) cmmeta.SecretKeySelector {
+	sel = PrivateKeySelector(sel)
+	sel.Key += previousPrivateKeySuffix
+	return sel
+}
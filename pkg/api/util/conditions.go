@@ -110,6 +110,32 @@ func CertificateHasCondition(crt *cmapi.Certificate, c cmapi.CertificateConditio
 	return false
 }
 
+// GetCertificateCondition returns the condition of the given type on the
+// Certificate, or nil if no such condition exists.
+func GetCertificateCondition(crt *cmapi.Certificate, conditionType cmapi.CertificateConditionType) *cmapi.CertificateCondition {
+	if crt == nil {
+		return nil
+	}
+	for i, cond := range crt.Status.Conditions {
+		if cond.Type == conditionType {
+			return &crt.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// RemoveCertificateCondition will remove any condition of the given type from
+// the Certificate.
+func RemoveCertificateCondition(crt *cmapi.Certificate, conditionType cmapi.CertificateConditionType) {
+	var conditions []cmapi.CertificateCondition
+	for _, cond := range crt.Status.Conditions {
+		if cond.Type != conditionType {
+			conditions = append(conditions, cond)
+		}
+	}
+	crt.Status.Conditions = conditions
+}
+
 // SetCertificateCondition will set a 'condition' on the given Certificate.
 // - If no condition of the same type already exists, the condition will be
 //   inserted with the LastTransitionTime set to the current time.
@@ -273,3 +299,23 @@ func CertificateRequestHasInvalidRequest(cr *cmapi.CertificateRequest) bool {
 
 	return false
 }
+
+// CertificateRequestIsApproved returns true if the CertificateRequest has been
+// approved via an Approved condition with status 'True', and returns false
+// otherwise.
+func CertificateRequestIsApproved(cr *cmapi.CertificateRequest) bool {
+	return CertificateRequestHasCondition(cr, cmapi.CertificateRequestCondition{
+		Type:   cmapi.CertificateRequestConditionApproved,
+		Status: cmmeta.ConditionTrue,
+	})
+}
+
+// CertificateRequestIsDenied returns true if the CertificateRequest has been
+// denied via a Denied condition with status 'True', and returns false
+// otherwise.
+func CertificateRequestIsDenied(cr *cmapi.CertificateRequest) bool {
+	return CertificateRequestHasCondition(cr, cmapi.CertificateRequestCondition{
+		Type:   cmapi.CertificateRequestConditionDenied,
+		Status: cmmeta.ConditionTrue,
+	})
+}
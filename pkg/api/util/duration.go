@@ -32,3 +32,14 @@ func DefaultCertDuration(d *metav1.Duration) time.Duration {
 
 	return certDuration
 }
+
+// DefaultCRLDuration returns the validity period of CRLs signed by CA
+// issuers, defaulting to v1alpha2.DefaultCRLDuration if d is nil.
+func DefaultCRLDuration(d *metav1.Duration) time.Duration {
+	crlDuration := v1alpha2.DefaultCRLDuration
+	if d != nil {
+		crlDuration = d.Duration
+	}
+
+	return crlDuration
+}
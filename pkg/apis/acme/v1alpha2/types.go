@@ -35,6 +35,14 @@ const (
 	// IngressEditInPlaceAnnotation is used to toggle the use of ingressClass instead
 	// of ingress on the created Certificate resource
 	IngressEditInPlaceAnnotationKey = "acme.cert-manager.io/http01-edit-in-place"
+
+	// If this annotation is specified on a Certificate issued by an ACME
+	// issuer, the certificate currently stored in its secret will be revoked
+	// with the reason given here, e.g. "keyCompromise". The annotation is
+	// removed once the certificate has been revoked, and a new certificate is
+	// then issued. If the reason is "keyCompromise", the new certificate also
+	// uses a new private key.
+	RevokeReasonAnnotationKey = "acme.cert-manager.io/revoke-reason"
 )
 
 const (
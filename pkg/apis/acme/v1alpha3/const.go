@@ -18,4 +18,14 @@ package v1alpha3
 
 const (
 	ACMEFinalizer = "finalizer.acme.cert-manager.io"
+
+	// ACMERevocationFinalizer is added to Certificates issued by ACME issuers
+	// with the OnDelete revocation policy, so their certificate can be
+	// revoked before the resource is deleted.
+	ACMERevocationFinalizer = "revocation.acme.cert-manager.io"
+
+	// ACMEAccountDeactivationFinalizer is added to ACME issuers with the
+	// OnDelete account deactivation policy, so their account can be
+	// deactivated before the resource is deleted.
+	ACMEAccountDeactivationFinalizer = "account-deactivation.acme.cert-manager.io"
 )
@@ -29,9 +29,23 @@ type ACMEIssuer struct {
 	// +optional
 	Email string `json:"email,omitempty"`
 
+	// AdditionalEmails is a list of further email addresses that are
+	// registered as contacts of this account, after Email.
+	// +optional
+	AdditionalEmails []string `json:"additionalEmails,omitempty"`
+
 	// Server is the ACME server URL
 	Server string `json:"server"`
 
+	// AgreeTermsOfService is the URL of the terms of service of the ACME server
+	// that the issuer's account agrees to. Accounts agree to the terms of
+	// service published when they are registered. If the ACME server later
+	// publishes new terms of service, they are only recorded as agreed to,
+	// and the TermsOfServiceChanged condition cleared, once this field is set
+	// to their URL.
+	// +optional
+	AgreeTermsOfService string `json:"agreeTermsOfService,omitempty"`
+
 	// If true, skip verifying the ACME server TLS certificate
 	// +optional
 	SkipTLSVerify bool `json:"skipTLSVerify,omitempty"`
@@ -42,13 +56,34 @@ type ACMEIssuer struct {
 	ExternalAccountBinding *ACMEExternalAccountBinding `json:"externalAccountBinding,omitempty"`
 
 	// PrivateKey is the name of a secret containing the private key for this
-	// user account.
+	// user account. The key may be an RSA key, or an ECDSA key using the
+	// P-256, P-384 or P-521 curve. If the secret does not exist, an RSA key
+	// will be generated.
+	// To roll over the account key, store the new key in the secret and the
+	// current key under the same data key with a '.previous' suffix, e.g.
+	// 'tls.key.previous'. The key of the existing account will be changed to
+	// the new key, and the previous key removed from the secret.
 	PrivateKey cmmeta.SecretKeySelector `json:"privateKeySecretRef"`
 
 	// Solvers is a list of challenge solvers that will be used to solve
 	// ACME challenges for the matching domains.
 	// +optional
 	Solvers []ACMEChallengeSolver `json:"solvers,omitempty"`
+
+	// RevocationPolicy controls whether certificates obtained from this
+	// issuer are revoked with the ACME server when the Certificate resource
+	// that requested them is deleted. Valid values are "Never" and
+	// "OnDelete". Defaults to "Never".
+	// +optional
+	RevocationPolicy ACMERevocationPolicy `json:"revocationPolicy,omitempty"`
+
+	// AccountDeactivationPolicy controls whether the ACME account of this
+	// issuer is deactivated with the ACME server when the issuer resource is
+	// deleted. Valid values are "Never" and "OnDelete". Defaults to "Never".
+	// A deactivated account can no longer be used, even by another issuer
+	// using the same private key.
+	// +optional
+	AccountDeactivationPolicy ACMEAccountDeactivationPolicy `json:"accountDeactivationPolicy,omitempty"`
 }
 
 // ACMEExternalAcccountBinding is a reference to a CA external account of the ACME
@@ -81,6 +116,36 @@ const (
 	HS512 HMACKeyAlgorithm = "HS512"
 )
 
+// ACMERevocationPolicy controls when certificates obtained from an ACME
+// issuer are revoked.
+// +kubebuilder:validation:Enum=Never;OnDelete
+type ACMERevocationPolicy string
+
+const (
+	// ACMERevocationPolicyNever means certificates are only ever revoked
+	// when requested with the revoke-reason annotation.
+	ACMERevocationPolicyNever ACMERevocationPolicy = "Never"
+
+	// ACMERevocationPolicyOnDelete means the current certificate of a
+	// Certificate resource is revoked when the resource is deleted.
+	ACMERevocationPolicyOnDelete ACMERevocationPolicy = "OnDelete"
+)
+
+// ACMEAccountDeactivationPolicy controls when the ACME account of an issuer
+// is deactivated.
+// +kubebuilder:validation:Enum=Never;OnDelete
+type ACMEAccountDeactivationPolicy string
+
+const (
+	// ACMEAccountDeactivationPolicyNever means the ACME account is never
+	// deactivated by cert-manager.
+	ACMEAccountDeactivationPolicyNever ACMEAccountDeactivationPolicy = "Never"
+
+	// ACMEAccountDeactivationPolicyOnDelete means the ACME account is
+	// deactivated when the issuer resource is deleted.
+	ACMEAccountDeactivationPolicyOnDelete ACMEAccountDeactivationPolicy = "OnDelete"
+)
+
 type ACMEChallengeSolver struct {
 	// Selector selects a set of DNSNames on the Certificate resource that
 	// should be solved using this challenge solver.
@@ -91,6 +156,9 @@ type ACMEChallengeSolver struct {
 
 	// +optional
 	DNS01 *ACMEChallengeSolverDNS01 `json:"dns01,omitempty"`
+
+	// +optional
+	TLSALPN01 *ACMEChallengeSolverTLSALPN01 `json:"tlsalpn01,omitempty"`
 }
 
 // CertificateDomainSelector selects certificates using a label selector, and
@@ -137,7 +205,16 @@ type ACMEChallengeSolverHTTP01 struct {
 	// '/.well-known/acme-challenge/XYZ' to 'challenge solver' pods that are
 	// provisioned by cert-manager for each Challenge to be completed.
 	// +optional
-	Ingress *ACMEChallengeSolverHTTP01Ingress `json:"ingress"`
+	Ingress *ACMEChallengeSolverHTTP01Ingress `json:"ingress,omitempty"`
+
+	// The Gateway API HTTPRoute based HTTP01 challenge solver will solve
+	// challenges by creating temporary HTTPRoute resources, attached to the
+	// given parent Gateways, in order to route requests for
+	// '/.well-known/acme-challenge/XYZ' to 'challenge solver' pods that are
+	// provisioned by cert-manager for each Challenge to be completed.
+	// Only one of 'ingress' or 'gatewayHTTPRoute' may be specified.
+	// +optional
+	GatewayHTTPRoute *ACMEChallengeSolverHTTP01GatewayHTTPRoute `json:"gatewayHTTPRoute,omitempty"`
 }
 
 type ACMEChallengeSolverHTTP01Ingress struct {
@@ -165,6 +242,58 @@ type ACMEChallengeSolverHTTP01Ingress struct {
 	PodTemplate *ACMEChallengeSolverHTTP01IngressPodTemplate `json:"podTemplate,omitempty"`
 }
 
+type ACMEChallengeSolverHTTP01GatewayHTTPRoute struct {
+	// Optional service type for Kubernetes solver service
+	// +optional
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// Custom labels that will be applied to HTTPRoutes created by cert-manager
+	// while solving HTTP01 challenges.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// The Gateways (or other parent resources) that HTTPRoutes created by
+	// cert-manager should be attached to.
+	// +optional
+	ParentRefs []ACMEChallengeSolverHTTP01GatewayParentRef `json:"parentRefs,omitempty"`
+
+	// Optional pod template used to configure the ACME challenge solver pods
+	// used for HTTP01 challenges
+	// +optional
+	PodTemplate *ACMEChallengeSolverHTTP01IngressPodTemplate `json:"podTemplate,omitempty"`
+}
+
+// ACMEChallengeSolverHTTP01GatewayParentRef identifies a parent resource,
+// usually a Gateway, that a HTTPRoute should be attached to.
+type ACMEChallengeSolverHTTP01GatewayParentRef struct {
+	// Group of the parent resource.
+	// Defaults to 'gateway.networking.k8s.io'.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Kind of the parent resource.
+	// Defaults to 'Gateway'.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Namespace of the parent resource.
+	// Defaults to the namespace of the Challenge.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the parent resource.
+	Name string `json:"name"`
+
+	// Name of a section within the parent resource, such as a Gateway
+	// listener, that the HTTPRoute should be attached to.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+
+	// Port of the parent resource that the HTTPRoute should be attached to.
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+}
+
 type ACMEChallengeSolverHTTP01IngressPodTemplate struct {
 	// ObjectMeta overrides for the pod used to solve HTTP01 challenges.
 	// Only the 'labels' and 'annotations' fields may be set.
@@ -204,6 +333,24 @@ type ACMEChallengeSolverHTTP01IngressPodSpec struct {
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 }
 
+// ACMEChallengeSolverTLSALPN01 contains configuration detailing how to solve
+// TLS-ALPN-01 challenges within a Kubernetes cluster.
+// Challenges are solved by provisioning 'solver pods' that present a
+// self-signed certificate containing the acmeIdentifier extension when
+// connected to using the 'acme-tls/1' ALPN protocol, along with a Service
+// that exposes them. Traffic for port 443 of each domain being validated
+// must be routed to this Service.
+type ACMEChallengeSolverTLSALPN01 struct {
+	// Optional service type for Kubernetes solver service
+	// +optional
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// Optional pod template used to configure the ACME challenge solver pods
+	// used for TLS-ALPN-01 challenges
+	// +optional
+	PodTemplate *ACMEChallengeSolverHTTP01IngressPodTemplate `json:"podTemplate,omitempty"`
+}
+
 type ACMEChallengeSolverDNS01 struct {
 	// CNAMEStrategy configures how the DNS01 provider should handle CNAME
 	// records when found in DNS zones.
@@ -414,4 +561,23 @@ type ACMEIssuerStatus struct {
 	// associated with the  Issuer
 	// +optional
 	LastRegisteredEmail string `json:"lastRegisteredEmail,omitempty"`
+
+	// LastRegisteredAdditionalEmails are the additional emails associated
+	// with the latest registered ACME account, in order to track changes made
+	// to the registered account associated with the Issuer
+	// +optional
+	LastRegisteredAdditionalEmails []string `json:"lastRegisteredAdditionalEmails,omitempty"`
+
+	// AgreedTermsOfService is the URL of the terms of service of the ACME
+	// server that the account has agreed to. If the ACME server publishes
+	// different terms of service, the TermsOfServiceChanged condition is set
+	// on the Issuer until they are agreed to using spec.acme.agreeTermsOfService.
+	// +optional
+	AgreedTermsOfService string `json:"agreedTermsOfService,omitempty"`
+
+	// AccountKeyThumbprint is the JWK thumbprint of the private key of the
+	// ACME account, in order to detect the private key being replaced
+	// without the account key being rolled over.
+	// +optional
+	AccountKeyThumbprint string `json:"accountKeyThumbprint,omitempty"`
 }
@@ -166,7 +166,8 @@ type ACMEChallenge struct {
 	// This is used to compute the 'key' that must also be presented.
 	Token string `json:"token"`
 
-	// Type is the type of challenge being offered, e.g. http-01, dns-01
+	// Type is the type of challenge being offered, e.g. http-01, dns-01,
+	// tls-alpn-01
 	Type ACMEChallengeType `json:"type"`
 }
 
@@ -179,6 +180,9 @@ const (
 
 	// ACMEChallengeTypeDNS01 denotes a Challenge is of type dns-01
 	ACMEChallengeTypeDNS01 ACMEChallengeType = "dns-01"
+
+	// ACMEChallengeTypeTLSALPN01 denotes a Challenge is of type tls-alpn-01
+	ACMEChallengeTypeTLSALPN01 ACMEChallengeType = "tls-alpn-01"
 )
 
 // State represents the state of an ACME resource, such as an Order.
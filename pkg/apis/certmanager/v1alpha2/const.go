@@ -30,6 +30,13 @@ const (
 
 	// Default duration before certificate expiration if  Issuer.spec.renewBefore is not set
 	DefaultRenewBefore = time.Hour * 24 * 30
+
+	// minimum permitted validity period of CRLs signed by CA issuers
+	MinimumCRLDuration = time.Hour
+
+	// default validity period of CRLs signed by CA issuers if
+	// Issuer.spec.ca.crl.duration is not set
+	DefaultCRLDuration = time.Hour * 24
 )
 
 const (
@@ -40,4 +47,9 @@ const (
 	// (/v1/auth/kubernetes). The endpoint will then be called at `/login`, so
 	// left as the default, `/v1/auth/kubernetes/login` will be called.
 	DefaultVaultKubernetesAuthMountPath = "/v1/auth/kubernetes"
+
+	// Default mount path location for TLS certificate authentication
+	// (/v1/auth/cert). The endpoint will then be called at `/login`, so
+	// left as the default, `/v1/auth/cert/login` will be called.
+	DefaultVaultClientCertificateAuthMountPath = "/v1/auth/cert"
 )
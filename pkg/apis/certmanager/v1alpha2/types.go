@@ -28,6 +28,66 @@ const (
 	CertificateNameKey       = "cert-manager.io/certificate-name"
 )
 
+// Data keys for additional keystore formats stored in Secrets
+const (
+	// PKCS12SecretKey is the name of the data entry in Secret resources
+	// used to store PKCS#12 encoded keystores.
+	// This data entry is only set if the Certificate has PKCS#12 keystores
+	// enabled.
+	PKCS12SecretKey = "keystore.p12"
+
+	// PKCS12TruststoreKey is the name of the data entry in Secret resources
+	// used to store PKCS#12 encoded truststores containing the CA certificate.
+	// This data entry is only set if the Certificate has PKCS#12 keystores
+	// enabled and a CA certificate is available.
+	PKCS12TruststoreKey = "truststore.p12"
+
+	// JKSSecretKey is the name of the data entry in Secret resources used to
+	// store JKS encoded keystores.
+	// This data entry is only set if the Certificate has JKS keystores
+	// enabled.
+	JKSSecretKey = "keystore.jks"
+
+	// JKSTruststoreKey is the name of the data entry in Secret resources used
+	// to store JKS encoded truststores containing the CA certificate.
+	// This data entry is only set if the Certificate has JKS keystores
+	// enabled and a CA certificate is available.
+	JKSTruststoreKey = "truststore.jks"
+)
+
+// Data keys for additional output formats stored in Secrets
+const (
+	// CertificateOutputFormatDERKey is the name of the data entry in Secret
+	// resources used to store the DER encoded private key.
+	// This data entry is only set if the Certificate has the DER additional
+	// output format enabled.
+	CertificateOutputFormatDERKey = "key.der"
+
+	// CertificateOutputFormatCombinedPEMKey is the name of the data entry in
+	// Secret resources used to store the PEM encoded private key followed by
+	// the PEM encoded certificate chain.
+	// This data entry is only set if the Certificate has the CombinedPEM
+	// additional output format enabled.
+	CertificateOutputFormatCombinedPEMKey = "tls-combined.pem"
+)
+
+// Data keys for Secrets containing CRLs signed by CA issuers
+const (
+	// CRLSecretKey is the name of the data entry in Secret resources used to
+	// store the DER encoded certificate revocation list of a CA issuer.
+	CRLSecretKey = "ca.crl"
+)
+
+// Annotation names for CertificateRequests signed by Vault issuers
+const (
+	// VaultParameterAnnotationPrefix is the prefix of annotations whose
+	// values are passed as additional parameters to the Vault PKI signing
+	// request, e.g. 'vault.cert-manager.io/param.other_sans'. Annotations set
+	// on a Certificate are copied onto its CertificateRequests. Only
+	// parameters listed in the issuer's allowedParameters may be set.
+	VaultParameterAnnotationPrefix = "vault.cert-manager.io/param."
+)
+
 // Deprecated annotation names for Secrets
 const (
 	DeprecatedIssuerNameAnnotationKey = "certmanager.k8s.io/issuer-name"
@@ -55,6 +115,15 @@ const (
 // Annotation names for CertificateRequests
 const (
 	CRPrivateKeyAnnotationKey = "cert-manager.io/private-key-secret-name"
+
+	// CertificateRequestRevisionAnnotationKey is the revision of the owning
+	// Certificate that a CertificateRequest will issue.
+	CertificateRequestRevisionAnnotationKey = "cert-manager.io/certificate-revision"
+
+	// CertificateRequestIssuingTimeAnnotationKey is set on CertificateRequests
+	// created for a manually requested re-issuance, and records the
+	// lastTransitionTime of the owning Certificate's Issuing condition.
+	CertificateRequestIssuingTimeAnnotationKey = "cert-manager.io/issuing-time"
 )
 
 const (
@@ -33,6 +33,8 @@ const (
 
 // CertificateRequest is a type to represent a Certificate Signing Request
 // +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="Approved",type="string",JSONPath=".status.conditions[?(@.type==\"Approved\")].status",description=""
+// +kubebuilder:printcolumn:name="Denied",type="string",JSONPath=".status.conditions[?(@.type==\"Denied\")].status",description=""
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
 // +kubebuilder:printcolumn:name="Issuer",type="string",JSONPath=".spec.issuerRef.name",description="",priority=1
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",priority=1
@@ -84,6 +86,24 @@ type CertificateRequestSpec struct {
 	// Defaults are ('digital signature', 'key encipherment') if empty
 	// +optional
 	Usages []KeyUsage `json:"usages,omitempty"`
+
+	// Username contains the name of the user that created the
+	// CertificateRequest. This field is set by the cert-manager webhook on
+	// creation and cannot be modified.
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// UID contains the uid of the user that created the CertificateRequest.
+	// This field is set by the cert-manager webhook on creation and cannot be
+	// modified.
+	// +optional
+	UID string `json:"uid,omitempty"`
+
+	// Groups contains group membership of the user that created the
+	// CertificateRequest. This field is set by the cert-manager webhook on
+	// creation and cannot be modified.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
 }
 
 // CertificateStatus defines the observed state of CertificateRequest and
@@ -110,7 +130,8 @@ type CertificateRequestStatus struct {
 
 // CertificateRequestCondition contains condition information for a CertificateRequest.
 type CertificateRequestCondition struct {
-	// Type of the condition, currently ('Ready', 'InvalidRequest').
+	// Type of the condition, currently ('Ready', 'InvalidRequest', 'Approved',
+	// 'Denied').
 	Type CertificateRequestConditionType `json:"type"`
 
 	// Status of the condition, one of ('True', 'False', 'Unknown').
@@ -146,4 +167,16 @@ const (
 	// parameters being invalid. Additional information about why the request
 	// was rejected can be found in the `reason` and `message` fields.
 	CertificateRequestConditionInvalidRequest CertificateRequestConditionType = "InvalidRequest"
+
+	// CertificateRequestConditionApproved indicates that a certificate request
+	// has been approved by an approval controller. Issuers will not sign a
+	// CertificateRequest until it has an Approved condition set to 'True'.
+	// Once set, this condition cannot be removed or modified.
+	CertificateRequestConditionApproved CertificateRequestConditionType = "Approved"
+
+	// CertificateRequestConditionDenied indicates that a certificate request
+	// has been denied by an approval controller, and must never be signed.
+	// A denied CertificateRequest will be marked as Failed.
+	// Once set, this condition cannot be removed or modified.
+	CertificateRequestConditionDenied CertificateRequestConditionType = "Denied"
 )
@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CertificateRequestPolicy binds a set of namespaces and ServiceAccounts to
+// an issuer, and constrains the CertificateRequests they may have signed by
+// it. Once any CertificateRequestPolicy references an issuer, only
+// CertificateRequests matching at least one of the policies referencing that
+// issuer will be approved.
+// +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="Issuer",type="string",JSONPath=".spec.issuerRef.name",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. It is not guaranteed to be set in happens-before order across separate operations. Clients may not set this value. It is represented in RFC3339 form and is in UTC."
+// +kubebuilder:resource:path=certificaterequestpolicies,scope=Cluster,shortName=crp;crps
+type CertificateRequestPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CertificateRequestPolicySpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CertificateRequestPolicyList is a list of CertificateRequestPolicies
+type CertificateRequestPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []CertificateRequestPolicy `json:"items"`
+}
+
+// CertificateRequestPolicySpec defines the subjects and constraints of a
+// CertificateRequestPolicy.
+type CertificateRequestPolicySpec struct {
+	// IssuerRef is a reference to the issuer that this policy applies to.
+	// If the 'kind' field is not set, or set to 'Issuer', the policy applies
+	// to Issuer resources with the given name in any namespace. If the 'kind'
+	// field is set to 'ClusterIssuer', the policy applies to the ClusterIssuer
+	// with the given name. The group field defaults to 'cert-manager.io' if
+	// empty.
+	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
+
+	// Subjects is the set of namespaces and ServiceAccounts that this policy
+	// binds to the issuer.
+	Subjects CertificateRequestPolicySubjects `json:"subjects"`
+
+	// Constraints restrict the CertificateRequests that may be approved by
+	// this policy. Constraints that are not set are not enforced.
+	// +optional
+	Constraints *CertificateRequestPolicyConstraints `json:"constraints,omitempty"`
+}
+
+// CertificateRequestPolicySubjects is a set of namespaces and ServiceAccounts.
+// A CertificateRequest matches if it is in one of the namespaces, or was
+// created by one of the ServiceAccounts.
+type CertificateRequestPolicySubjects struct {
+	// Namespaces is a list of namespaces whose CertificateRequests this
+	// policy applies to.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// ServiceAccounts is a list of ServiceAccounts whose CertificateRequests
+	// this policy applies to, identified by the user that created the
+	// CertificateRequest.
+	// +optional
+	ServiceAccounts []CertificateRequestPolicyServiceAccount `json:"serviceAccounts,omitempty"`
+}
+
+// CertificateRequestPolicyServiceAccount is a reference to a ServiceAccount.
+type CertificateRequestPolicyServiceAccount struct {
+	// Name of the ServiceAccount.
+	Name string `json:"name"`
+
+	// Namespace of the ServiceAccount.
+	Namespace string `json:"namespace"`
+}
+
+// CertificateRequestPolicyConstraints restrict the contents of the
+// certificate signing request and options of a CertificateRequest.
+type CertificateRequestPolicyConstraints struct {
+	// AllowedDNSNames is a list of glob patterns that the DNS names and common
+	// name of the request must match, e.g. '*.example.com'. A '*' matches
+	// any sequence of characters.
+	// +optional
+	AllowedDNSNames []string `json:"allowedDNSNames,omitempty"`
+
+	// AllowedURISANs is a list of glob patterns that the URI SANs of the
+	// request must match, e.g. 'spiffe://cluster.local/ns/*'.
+	// +optional
+	AllowedURISANs []string `json:"allowedURISANs,omitempty"`
+
+	// AllowedIPRanges is a list of CIDR ranges that the IP addresses of the
+	// request must be contained in.
+	// +optional
+	AllowedIPRanges []string `json:"allowedIPRanges,omitempty"`
+
+	// MaxDuration is the maximum duration that may be requested.
+	// +optional
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+
+	// AllowIsCA determines whether the request may set 'isCA'.
+	// +optional
+	AllowIsCA *bool `json:"allowIsCA,omitempty"`
+
+	// AllowedUsages is the set of x509 usages that may be requested.
+	// +optional
+	AllowedUsages []KeyUsage `json:"allowedUsages,omitempty"`
+
+	// AllowedKeyAlgorithms is the set of private key algorithms that may be
+	// used by the request.
+	// +optional
+	AllowedKeyAlgorithms []KeyAlgorithm `json:"allowedKeyAlgorithms,omitempty"`
+
+	// MinRSAKeySize is the minimum size in bits of RSA keys used by the
+	// request.
+	// +optional
+	MinRSAKeySize int `json:"minRSAKeySize,omitempty"`
+
+	// MinECDSAKeySize is the minimum curve size in bits of ECDSA keys used by
+	// the request.
+	// +optional
+	MinECDSAKeySize int `json:"minECDSAKeySize,omitempty"`
+}
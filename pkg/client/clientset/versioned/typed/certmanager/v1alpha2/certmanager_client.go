@@ -28,6 +28,7 @@ type CertmanagerV1alpha2Interface interface {
 	RESTClient() rest.Interface
 	CertificatesGetter
 	CertificateRequestsGetter
+	CertificateRequestPoliciesGetter
 	ClusterIssuersGetter
 	IssuersGetter
 }
@@ -45,6 +46,10 @@ func (c *CertmanagerV1alpha2Client) CertificateRequests(namespace string) Certif
 	return newCertificateRequests(c, namespace)
 }
 
+func (c *CertmanagerV1alpha2Client) CertificateRequestPolicies() CertificateRequestPolicyInterface {
+	return newCertificateRequestPolicies(c)
+}
+
 func (c *CertmanagerV1alpha2Client) ClusterIssuers() ClusterIssuerInterface {
 	return newClusterIssuers(c)
 }
@@ -36,6 +36,10 @@ func (c *FakeCertmanagerV1alpha2) CertificateRequests(namespace string) v1alpha2
 	return &FakeCertificateRequests{c, namespace}
 }
 
+func (c *FakeCertmanagerV1alpha2) CertificateRequestPolicies() v1alpha2.CertificateRequestPolicyInterface {
+	return &FakeCertificateRequestPolicies{c}
+}
+
 func (c *FakeCertmanagerV1alpha2) ClusterIssuers() v1alpha2.ClusterIssuerInterface {
 	return &FakeClusterIssuers{c}
 }
@@ -0,0 +1,120 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha3 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha3"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeCertificateRequestPolicies implements CertificateRequestPolicyInterface
+type FakeCertificateRequestPolicies struct {
+	Fake *FakeCertmanagerV1alpha3
+}
+
+var certificaterequestpoliciesResource = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1alpha3", Resource: "certificaterequestpolicies"}
+
+var certificaterequestpoliciesKind = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1alpha3", Kind: "CertificateRequestPolicy"}
+
+// Get takes name of the certificateRequestPolicy, and returns the corresponding certificateRequestPolicy object, and an error if there is any.
+func (c *FakeCertificateRequestPolicies) Get(name string, options v1.GetOptions) (result *v1alpha3.CertificateRequestPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(certificaterequestpoliciesResource, name), &v1alpha3.CertificateRequestPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha3.CertificateRequestPolicy), err
+}
+
+// List takes label and field selectors, and returns the list of CertificateRequestPolicies that match those selectors.
+func (c *FakeCertificateRequestPolicies) List(opts v1.ListOptions) (result *v1alpha3.CertificateRequestPolicyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(certificaterequestpoliciesResource, certificaterequestpoliciesKind, opts), &v1alpha3.CertificateRequestPolicyList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha3.CertificateRequestPolicyList{ListMeta: obj.(*v1alpha3.CertificateRequestPolicyList).ListMeta}
+	for _, item := range obj.(*v1alpha3.CertificateRequestPolicyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested certificateRequestPolicies.
+func (c *FakeCertificateRequestPolicies) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(certificaterequestpoliciesResource, opts))
+}
+
+// Create takes the representation of a certificateRequestPolicy and creates it.  Returns the server's representation of the certificateRequestPolicy, and an error, if there is any.
+func (c *FakeCertificateRequestPolicies) Create(certificateRequestPolicy *v1alpha3.CertificateRequestPolicy) (result *v1alpha3.CertificateRequestPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(certificaterequestpoliciesResource, certificateRequestPolicy), &v1alpha3.CertificateRequestPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha3.CertificateRequestPolicy), err
+}
+
+// Update takes the representation of a certificateRequestPolicy and updates it. Returns the server's representation of the certificateRequestPolicy, and an error, if there is any.
+func (c *FakeCertificateRequestPolicies) Update(certificateRequestPolicy *v1alpha3.CertificateRequestPolicy) (result *v1alpha3.CertificateRequestPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(certificaterequestpoliciesResource, certificateRequestPolicy), &v1alpha3.CertificateRequestPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha3.CertificateRequestPolicy), err
+}
+
+// Delete takes name of the certificateRequestPolicy and deletes it. Returns an error if one occurs.
+func (c *FakeCertificateRequestPolicies) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(certificaterequestpoliciesResource, name), &v1alpha3.CertificateRequestPolicy{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeCertificateRequestPolicies) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(certificaterequestpoliciesResource, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha3.CertificateRequestPolicyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched certificateRequestPolicy.
+func (c *FakeCertificateRequestPolicies) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha3.CertificateRequestPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(certificaterequestpoliciesResource, name, pt, data, subresources...), &v1alpha3.CertificateRequestPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha3.CertificateRequestPolicy), err
+}
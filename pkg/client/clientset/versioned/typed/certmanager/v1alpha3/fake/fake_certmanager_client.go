@@ -36,6 +36,10 @@ func (c *FakeCertmanagerV1alpha3) CertificateRequests(namespace string) v1alpha3
 	return &FakeCertificateRequests{c, namespace}
 }
 
+func (c *FakeCertmanagerV1alpha3) CertificateRequestPolicies() v1alpha3.CertificateRequestPolicyInterface {
+	return &FakeCertificateRequestPolicies{c}
+}
+
 func (c *FakeCertmanagerV1alpha3) ClusterIssuers() v1alpha3.ClusterIssuerInterface {
 	return &FakeClusterIssuers{c}
 }
@@ -28,6 +28,8 @@ type Interface interface {
 	Certificates() CertificateInformer
 	// CertificateRequests returns a CertificateRequestInformer.
 	CertificateRequests() CertificateRequestInformer
+	// CertificateRequestPolicies returns a CertificateRequestPolicyInformer.
+	CertificateRequestPolicies() CertificateRequestPolicyInformer
 	// ClusterIssuers returns a ClusterIssuerInformer.
 	ClusterIssuers() ClusterIssuerInformer
 	// Issuers returns a IssuerInformer.
@@ -55,6 +57,11 @@ func (v *version) CertificateRequests() CertificateRequestInformer {
 	return &certificateRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CertificateRequestPolicies returns a CertificateRequestPolicyInformer.
+func (v *version) CertificateRequestPolicies() CertificateRequestPolicyInformer {
+	return &certificateRequestPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterIssuers returns a ClusterIssuerInformer.
 func (v *version) ClusterIssuers() ClusterIssuerInformer {
 	return &clusterIssuerInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
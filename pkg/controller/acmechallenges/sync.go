@@ -71,13 +71,14 @@ func (c *controller) Sync(ctx context.Context, ch *cmacme.Challenge) (err error)
 
 	defer func() {
 		// TODO: replace with more efficient comparison
-		if reflect.DeepEqual(oldChal.Status, ch.Status) && len(oldChal.Finalizers) == len(ch.Finalizers) {
-			return
-		}
-		_, updateErr := c.cmClient.AcmeV1alpha2().Challenges(ch.Namespace).UpdateStatus(ch)
-		if updateErr != nil {
-			err = utilerrors.NewAggregate([]error{err, updateErr})
+		if !reflect.DeepEqual(oldChal.Status, ch.Status) || len(oldChal.Finalizers) != len(ch.Finalizers) {
+			_, updateErr := c.cmClient.AcmeV1alpha2().Challenges(ch.Namespace).UpdateStatus(ch)
+			if updateErr != nil {
+				err = utilerrors.NewAggregate([]error{err, updateErr})
+				return
+			}
 		}
+		metrics.Default.UpdateChallenge(oldChal, ch)
 	}()
 
 	// bail out early on if processing=false, as this challenge has not been
@@ -392,6 +393,8 @@ func (c *controller) solverFor(challengeType cmacme.ACMEChallengeType) (solver,
 		return c.httpSolver, nil
 	case "dns-01":
 		return c.dnsSolver, nil
+	case "tls-alpn-01":
+		return c.tlsalpnSolver, nil
 	}
 	return nil, fmt.Errorf("no solver for %q implemented", challengeType)
 }
@@ -125,6 +125,8 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 				return &ch
 			case ch.Type == "dns-01" && solver.DNS01 != nil:
 				return &ch
+			case ch.Type == "tls-alpn-01" && solver.TLSALPN01 != nil:
+				return &ch
 			}
 		}
 		return nil
@@ -326,6 +328,11 @@ func keyForChallenge(cl acmecl.Interface, challenge *cmacme.ACMEChallenge) (stri
 		return cl.HTTP01ChallengeResponse(challenge.Token)
 	case cmacme.ACMEChallengeTypeDNS01:
 		return cl.DNS01ChallengeRecord(challenge.Token)
+	case cmacme.ACMEChallengeTypeTLSALPN01:
+		// the key authorization is presented by the solver in the
+		// acmeIdentifier extension of its certificate, which is computed
+		// the same way as the HTTP01 challenge response
+		return cl.HTTP01ChallengeResponse(challenge.Token)
 	default:
 		err = fmt.Errorf("unsupported challenge type %s", challenge.Type)
 	}
@@ -88,6 +88,10 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 		Type:  "dns-01",
 		Token: "dns-01-token",
 	}
+	acmeChallengeTLSALPN01 := &cmacme.ACMEChallenge{
+		Type:  "tls-alpn-01",
+		Token: "tls-alpn-01-token",
+	}
 
 	tests := map[string]struct {
 		acmeClient acmecl.Interface
@@ -98,6 +102,38 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 		expectedChallengeSpec *cmacme.ChallengeSpec
 		expectedError         bool
 	}{
+		"should select the tls-alpn-01 challenge for a TLSALPN01 solver": {
+			acmeClient: basicACMEClient,
+			issuer: &v1alpha2.Issuer{
+				Spec: v1alpha2.IssuerSpec{
+					IssuerConfig: v1alpha2.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{
+								{TLSALPN01: &cmacme.ACMEChallengeSolverTLSALPN01{}},
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeHTTP01, *acmeChallengeTLSALPN01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    "tls-alpn-01",
+				DNSName: "example.com",
+				Token:   acmeChallengeTLSALPN01.Token,
+				Key:     "http01",
+				Solver: &cmacme.ACMEChallengeSolver{
+					TLSALPN01: &cmacme.ACMEChallengeSolverTLSALPN01{},
+				},
+			},
+		},
 		"should override the ingress name to edit if override annotation is specified": {
 			acmeClient: basicACMEClient,
 			issuer: &v1alpha2.Issuer{
@@ -0,0 +1,281 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmerevocation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	reasonRevoked           = "Revoked"
+	reasonRevocationFailed  = "RevocationFailed"
+	reasonRevocationSkipped = "RevocationSkipped"
+
+	// deletionRevocationReason is the reason certificates are revoked with
+	// when their Certificate resource is deleted.
+	deletionRevocationReason = "cessationOfOperation"
+)
+
+// revocationReasons maps the reasons that may be given in the revoke-reason
+// annotation to their RFC 5280 CRL reason codes.
+var revocationReasons = map[string]acmeapi.CRLReasonCode{
+	"unspecified":          acmeapi.CRLReasonUnspecified,
+	"keyCompromise":        acmeapi.CRLReasonKeyCompromise,
+	"caCompromise":         acmeapi.CRLReasonCACompromise,
+	"affiliationChanged":   acmeapi.CRLReasonAffiliationChanged,
+	"superseded":           acmeapi.CRLReasonSuperseded,
+	"cessationOfOperation": acmeapi.CRLReasonCessationOfOperation,
+	"certificateHold":      acmeapi.CRLReasonCertificateHold,
+	"removeFromCRL":        acmeapi.CRLReasonRemoveFromCRL,
+	"privilegeWithdrawn":   acmeapi.CRLReasonPrivilegeWithdrawn,
+	"aaCompromise":         acmeapi.CRLReasonAACompromise,
+}
+
+// Sync revokes the certificate of the given Certificate when it is being
+// deleted and its issuer has the OnDelete revocation policy, or when the
+// revoke-reason annotation is set. It keeps the revocation finalizer in sync
+// with the issuer's revocation policy.
+func (c *controller) Sync(ctx context.Context, crt *cmapi.Certificate) error {
+	log := logf.FromContext(ctx)
+
+	iss, err := c.acmeIssuer(crt)
+	if err != nil {
+		return err
+	}
+
+	var policy cmacme.ACMERevocationPolicy
+	if iss != nil {
+		policy = iss.GetSpec().ACME.RevocationPolicy
+	}
+
+	if crt.DeletionTimestamp != nil {
+		if !hasFinalizer(crt) {
+			return nil
+		}
+
+		switch {
+		case policy == cmacme.ACMERevocationPolicyOnDelete:
+			crt, _, err = c.revoke(ctx, crt, iss, deletionRevocationReason)
+			if err != nil {
+				return err
+			}
+		case iss == nil:
+			c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationSkipped, "Issuer %q is not an ACME issuer or does not exist, not revoking certificate", crt.Spec.IssuerRef.Name)
+		}
+
+		log.V(logf.DebugLevel).Info("removing revocation finalizer")
+		updated := crt.DeepCopy()
+		setFinalizer(updated, false)
+		_, err = c.cmClient.CertmanagerV1alpha2().Certificates(updated.Namespace).Update(updated)
+		return err
+	}
+
+	updated := crt.DeepCopy()
+	if reason, ok := crt.Annotations[cmacme.RevokeReasonAnnotationKey]; ok {
+		if iss == nil {
+			c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationSkipped, "Issuer %q is not an ACME issuer or does not exist, cannot revoke certificate", crt.Spec.IssuerRef.Name)
+			return nil
+		}
+
+		if _, ok := revocationReasons[reason]; !ok {
+			c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationFailed, "Unknown revocation reason %q", reason)
+			return nil
+		}
+
+		revoked, done, err := c.revoke(ctx, crt, iss, reason)
+		if err != nil {
+			return err
+		}
+		updated = revoked.DeepCopy()
+		if done {
+			delete(updated.Annotations, cmacme.RevokeReasonAnnotationKey)
+		}
+	}
+
+	setFinalizer(updated, policy == cmacme.ACMERevocationPolicyOnDelete)
+	if hasFinalizer(updated) == hasFinalizer(crt) && len(updated.Annotations) == len(crt.Annotations) {
+		return nil
+	}
+
+	_, err = c.cmClient.CertmanagerV1alpha2().Certificates(updated.Namespace).Update(updated)
+	return err
+}
+
+// acmeIssuer returns the issuer referenced by the Certificate, or nil if it
+// does not exist or is not an ACME issuer.
+func (c *controller) acmeIssuer(crt *cmapi.Certificate) (cmapi.GenericIssuer, error) {
+	if !(crt.Spec.IssuerRef.Group == "" || crt.Spec.IssuerRef.Group == certmanager.GroupName) {
+		return nil, nil
+	}
+
+	iss, err := c.helper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	if k8sErrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if iss.GetSpec().ACME == nil {
+		return nil, nil
+	}
+
+	return iss, nil
+}
+
+// revoke revokes the certificate stored in the Certificate's secret with the
+// ACME account of the issuer, and records the revocation on the
+// Certificate's status. It returns the updated Certificate, and false if the
+// ACME server permanently refused to revoke the certificate.
+func (c *controller) revoke(ctx context.Context, crt *cmapi.Certificate, iss cmapi.GenericIssuer, reason string) (*cmapi.Certificate, bool, error) {
+	log := logf.FromContext(ctx)
+	log = logf.WithRelatedResourceName(log, crt.Spec.SecretName, crt.Namespace, "Secret")
+
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if k8sErrors.IsNotFound(err) {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationSkipped, "Secret %q does not exist, no certificate to revoke", crt.Spec.SecretName)
+		return crt, true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if name, ok := secret.Annotations[cmapi.IssuerNameAnnotationKey]; ok && name != iss.GetObjectMeta().Name {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationSkipped, "Certificate in secret %q was issued by %q, not revoking it", crt.Spec.SecretName, name)
+		return crt, true, nil
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationSkipped, "Failed to decode certificate in secret %q: %v", crt.Spec.SecretName, err)
+		return crt, true, nil
+	}
+
+	serial := fmt.Sprintf("%X", cert.SerialNumber)
+	log = log.WithValues("serial_number", serial)
+
+	if rev := crt.Status.Revocation; rev != nil && rev.SerialNumber == serial {
+		log.V(logf.DebugLevel).Info("certificate has already been revoked")
+		return crt, true, nil
+	}
+
+	now := c.clock.Now()
+	if !now.Before(cert.NotAfter) {
+		c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonRevocationSkipped, "Certificate with serial number %s has expired, not revoking it", serial)
+		return crt, true, nil
+	}
+
+	cl, err := c.acmeHelper.ClientForIssuer(iss)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// a nil key revokes the certificate with the issuer's account key
+	if err := cl.RevokeCert(ctx, nil, cert.Raw, revocationReasons[reason]); err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationFailed, "Failed to revoke certificate with serial number %s: %v", serial, err)
+		// the request will not succeed when retried if the ACME server
+		// rejected it, e.g. because the account does not own the certificate
+		if acmeErr, ok := err.(*acmeapi.Error); ok && isPermanentError(acmeErr) {
+			log.Error(err, "ACME server refused to revoke certificate")
+			return crt, false, nil
+		}
+		return nil, false, err
+	}
+
+	log.Info("revoked certificate", "reason", reason)
+	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonRevoked, "Revoked certificate with serial number %s (reason: %s)", serial, reason)
+
+	crt = crt.DeepCopy()
+	crt.Status.Revocation = &cmapi.CertificateRevocation{
+		SerialNumber:   serial,
+		Reason:         reason,
+		RevocationTime: &metav1.Time{Time: now},
+	}
+
+	// The revoked certificate can no longer be used, so a new certificate is
+	// requested unless the Certificate is being deleted. If the private key
+	// has been compromised, the new certificate must also use a new private
+	// key.
+	if crt.DeletionTimestamp == nil {
+		issuingReason := reasonRevoked
+		if reason == "keyCompromise" {
+			issuingReason = cmapi.CertificateIssuingReasonKeyCompromise
+		}
+		apiutil.SetCertificateCondition(crt, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, issuingReason,
+			fmt.Sprintf("Re-issuing certificate as the certificate with serial number %s has been revoked (reason: %s)", serial, reason))
+	}
+
+	crt, err = c.cmClient.CertmanagerV1alpha2().Certificates(crt.Namespace).UpdateStatus(crt)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return crt, true, nil
+}
+
+// isPermanentError returns true if the ACME server rejected a request in a
+// way that retrying it will not resolve.
+func isPermanentError(err *acmeapi.Error) bool {
+	if err.StatusCode == http.StatusTooManyRequests {
+		return false
+	}
+	return err.StatusCode >= http.StatusBadRequest && err.StatusCode < http.StatusInternalServerError
+}
+
+func hasFinalizer(crt *cmapi.Certificate) bool {
+	for _, f := range crt.Finalizers {
+		if f == cmacme.ACMERevocationFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// setFinalizer adds or removes the revocation finalizer on the Certificate.
+func setFinalizer(crt *cmapi.Certificate, present bool) {
+	if hasFinalizer(crt) == present {
+		return
+	}
+
+	if present {
+		crt.Finalizers = append(crt.Finalizers, cmacme.ACMERevocationFinalizer)
+		return
+	}
+
+	var finalizers []string
+	for _, f := range crt.Finalizers {
+		if f != cmacme.ACMERevocationFinalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	crt.Finalizers = finalizers
+}
@@ -0,0 +1,357 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmerevocation
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	acmefake "github.com/jetstack/cert-manager/pkg/acme/fake"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func generateTestCertificate(t *testing.T, serial int64, notAfter time.Time) []byte {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    notAfter.Add(-time.Hour * 24 * 90),
+		NotAfter:     notAfter,
+	}
+
+	pem, _, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem
+}
+
+func TestSync(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	deletedAt := metav1.NewTime(now.Add(-time.Minute))
+
+	onDeleteIssuer := gen.Issuer("acme-issuer",
+		gen.SetIssuerACME(cmacme.ACMEIssuer{RevocationPolicy: cmacme.ACMERevocationPolicyOnDelete}),
+	)
+	neverIssuer := gen.Issuer("acme-issuer",
+		gen.SetIssuerACME(cmacme.ACMEIssuer{}),
+	)
+
+	baseCrt := gen.Certificate("test",
+		gen.SetCertificateSecretName("test-tls"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "acme-issuer"}),
+	)
+	withFinalizer := func(crt *cmapi.Certificate) *cmapi.Certificate {
+		crt = crt.DeepCopy()
+		crt.Finalizers = []string{cmacme.ACMERevocationFinalizer}
+		return crt
+	}
+	deleting := func(crt *cmapi.Certificate) *cmapi.Certificate {
+		crt = withFinalizer(crt)
+		crt.DeletionTimestamp = &deletedAt
+		return crt
+	}
+	withReason := func(crt *cmapi.Certificate, reason string) *cmapi.Certificate {
+		crt = crt.DeepCopy()
+		crt.Annotations = map[string]string{cmacme.RevokeReasonAnnotationKey: reason}
+		return crt
+	}
+	withRevocation := func(crt *cmapi.Certificate, serial, reason string) *cmapi.Certificate {
+		crt = crt.DeepCopy()
+		crt.Status.Revocation = &cmapi.CertificateRevocation{
+			SerialNumber:   serial,
+			Reason:         reason,
+			RevocationTime: &metav1.Time{Time: now},
+		}
+		return crt
+	}
+	withIssuing := func(crt *cmapi.Certificate, reason, message string) *cmapi.Certificate {
+		crt = crt.DeepCopy()
+		crt.Status.Conditions = append(crt.Status.Conditions, cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionIssuing,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: &metav1.Time{Time: now},
+		})
+		return crt
+	}
+	withoutFinalizers := func(crt *cmapi.Certificate) *cmapi.Certificate {
+		crt = crt.DeepCopy()
+		crt.Finalizers = nil
+		return crt
+	}
+	withoutAnnotation := func(crt *cmapi.Certificate) *cmapi.Certificate {
+		crt = crt.DeepCopy()
+		delete(crt.Annotations, cmacme.RevokeReasonAnnotationKey)
+		return crt
+	}
+
+	certPEM := generateTestCertificate(t, 0x1a2b, now.Add(time.Hour*24*30))
+	expiredCertPEM := generateTestCertificate(t, 0x3c4d, now.Add(-time.Hour))
+	secret := func(pem []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-tls",
+				Namespace:   gen.DefaultTestNamespace,
+				Annotations: map[string]string{cmapi.IssuerNameAnnotationKey: "acme-issuer"},
+			},
+			Data: map[string][]byte{corev1.TLSCertKey: pem},
+		}
+	}
+
+	certificates := cmapi.SchemeGroupVersion.WithResource("certificates")
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		issuer      *cmapi.Issuer
+		secret      *corev1.Secret
+		revokeErr   error
+
+		expectedRevokeReason *acmeapi.CRLReasonCode
+		expectedActions      []testpkg.Action
+		expectedEvents       []string
+		expectErr            bool
+	}{
+		"the finalizer should be added if the issuer revokes on delete": {
+			certificate: baseCrt,
+			issuer:      onDeleteIssuer,
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(certificates, gen.DefaultTestNamespace, withFinalizer(baseCrt))),
+			},
+		},
+		"the finalizer should be removed if the issuer never revokes": {
+			certificate: withFinalizer(baseCrt),
+			issuer:      neverIssuer,
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(certificates, gen.DefaultTestNamespace, withoutFinalizers(baseCrt))),
+			},
+		},
+		"nothing should be done if the finalizer is up to date": {
+			certificate: baseCrt,
+			issuer:      neverIssuer,
+		},
+		"the certificate should be revoked when the Certificate is deleted": {
+			certificate:          deleting(baseCrt),
+			issuer:               onDeleteIssuer,
+			secret:               secret(certPEM),
+			expectedRevokeReason: reasonCode(acmeapi.CRLReasonCessationOfOperation),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(certificates, "status", gen.DefaultTestNamespace,
+					withRevocation(deleting(baseCrt), "1A2B", "cessationOfOperation"))),
+				testpkg.NewAction(coretesting.NewUpdateAction(certificates, gen.DefaultTestNamespace,
+					withoutFinalizers(withRevocation(deleting(baseCrt), "1A2B", "cessationOfOperation")))),
+			},
+			expectedEvents: []string{"Normal Revoked Revoked certificate with serial number 1A2B (reason: cessationOfOperation)"},
+		},
+		"an expired certificate should not be revoked when the Certificate is deleted": {
+			certificate: deleting(baseCrt),
+			issuer:      onDeleteIssuer,
+			secret:      secret(expiredCertPEM),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(certificates, gen.DefaultTestNamespace, withoutFinalizers(deleting(baseCrt)))),
+			},
+			expectedEvents: []string{"Normal RevocationSkipped Certificate with serial number 3C4D has expired, not revoking it"},
+		},
+		"the finalizer should be removed if the ACME server refuses to revoke the certificate": {
+			certificate:          deleting(baseCrt),
+			issuer:               onDeleteIssuer,
+			secret:               secret(certPEM),
+			revokeErr:            &acmeapi.Error{StatusCode: http.StatusForbidden, ProblemType: "urn:ietf:params:acme:error:unauthorized", Detail: "not authorized"},
+			expectedRevokeReason: reasonCode(acmeapi.CRLReasonCessationOfOperation),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(certificates, gen.DefaultTestNamespace, withoutFinalizers(deleting(baseCrt)))),
+			},
+			expectedEvents: []string{"Warning RevocationFailed Failed to revoke certificate with serial number 1A2B: 403 urn:ietf:params:acme:error:unauthorized: not authorized"},
+		},
+		"the finalizer should be kept if revoking the certificate fails temporarily": {
+			certificate:          deleting(baseCrt),
+			issuer:               onDeleteIssuer,
+			secret:               secret(certPEM),
+			revokeErr:            &acmeapi.Error{StatusCode: http.StatusServiceUnavailable, Detail: "unavailable"},
+			expectedRevokeReason: reasonCode(acmeapi.CRLReasonCessationOfOperation),
+			expectedEvents:       []string{"Warning RevocationFailed Failed to revoke certificate with serial number 1A2B: 503 : unavailable"},
+			expectErr:            true,
+		},
+		"the certificate should be revoked with the reason given in the annotation and re-issued": {
+			certificate:          withReason(baseCrt, "superseded"),
+			issuer:               neverIssuer,
+			secret:               secret(certPEM),
+			expectedRevokeReason: reasonCode(acmeapi.CRLReasonSuperseded),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(certificates, "status", gen.DefaultTestNamespace,
+					withIssuing(withRevocation(withReason(baseCrt, "superseded"), "1A2B", "superseded"),
+						"Revoked", "Re-issuing certificate as the certificate with serial number 1A2B has been revoked (reason: superseded)"))),
+				testpkg.NewAction(coretesting.NewUpdateAction(certificates, gen.DefaultTestNamespace,
+					withoutAnnotation(withIssuing(withRevocation(withReason(baseCrt, "superseded"), "1A2B", "superseded"),
+						"Revoked", "Re-issuing certificate as the certificate with serial number 1A2B has been revoked (reason: superseded)")))),
+			},
+			expectedEvents: []string{"Normal Revoked Revoked certificate with serial number 1A2B (reason: superseded)"},
+		},
+		"a certificate revoked as its private key is compromised should be re-issued with a new private key": {
+			certificate:          withReason(baseCrt, "keyCompromise"),
+			issuer:               neverIssuer,
+			secret:               secret(certPEM),
+			expectedRevokeReason: reasonCode(acmeapi.CRLReasonKeyCompromise),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(certificates, "status", gen.DefaultTestNamespace,
+					withIssuing(withRevocation(withReason(baseCrt, "keyCompromise"), "1A2B", "keyCompromise"),
+						cmapi.CertificateIssuingReasonKeyCompromise, "Re-issuing certificate as the certificate with serial number 1A2B has been revoked (reason: keyCompromise)"))),
+				testpkg.NewAction(coretesting.NewUpdateAction(certificates, gen.DefaultTestNamespace,
+					withoutAnnotation(withIssuing(withRevocation(withReason(baseCrt, "keyCompromise"), "1A2B", "keyCompromise"),
+						cmapi.CertificateIssuingReasonKeyCompromise, "Re-issuing certificate as the certificate with serial number 1A2B has been revoked (reason: keyCompromise)")))),
+			},
+			expectedEvents: []string{"Normal Revoked Revoked certificate with serial number 1A2B (reason: keyCompromise)"},
+		},
+		"a certificate that has already been revoked should not be revoked again": {
+			certificate: withRevocation(withReason(baseCrt, "keyCompromise"), "1A2B", "superseded"),
+			issuer:      neverIssuer,
+			secret:      secret(certPEM),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(certificates, gen.DefaultTestNamespace,
+					withoutAnnotation(withRevocation(withReason(baseCrt, "keyCompromise"), "1A2B", "superseded")))),
+			},
+		},
+		"an unknown revocation reason should be reported": {
+			certificate:    withReason(baseCrt, "compromised"),
+			issuer:         neverIssuer,
+			secret:         secret(certPEM),
+			expectedEvents: []string{`Warning RevocationFailed Unknown revocation reason "compromised"`},
+		},
+		"a certificate issued by another issuer should not be revoked": {
+			certificate: withReason(baseCrt, "keyCompromise"),
+			issuer:      neverIssuer,
+			secret: func() *corev1.Secret {
+				s := secret(certPEM)
+				s.Annotations[cmapi.IssuerNameAnnotationKey] = "other-issuer"
+				return s
+			}(),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(certificates, gen.DefaultTestNamespace, withoutAnnotation(withReason(baseCrt, "keyCompromise")))),
+			},
+			expectedEvents: []string{`Warning RevocationSkipped Certificate in secret "test-tls" was issued by "other-issuer", not revoking it`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var kubeObjects []runtime.Object
+			if test.secret != nil {
+				kubeObjects = append(kubeObjects, test.secret)
+			}
+
+			b := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(now),
+				KubeObjects:        kubeObjects,
+				CertManagerObjects: []runtime.Object{test.certificate, test.issuer},
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			b.Init()
+			defer b.Stop()
+
+			var revokeReason *acmeapi.CRLReasonCode
+			client := &acmecl.FakeACME{
+				FakeRevokeCert: func(_ context.Context, key crypto.Signer, cert []byte, reason acmeapi.CRLReasonCode) error {
+					if key != nil {
+						t.Errorf("expected the certificate to be revoked with the account key")
+					}
+					if parsed, err := x509.ParseCertificate(cert); err != nil || parsed.SerialNumber.Int64() != 0x1a2b {
+						t.Errorf("unexpected certificate revoked: %v", err)
+					}
+					revokeReason = &reason
+					return test.revokeErr
+				},
+			}
+
+			c := &controller{}
+			c.Register(b.Context)
+			c.acmeHelper = &acmefake.Helper{
+				ClientForIssuerFunc: func(cmapi.GenericIssuer) (acmecl.Interface, error) {
+					return client, nil
+				},
+			}
+			b.Start()
+
+			err := c.Sync(context.Background(), test.certificate)
+			if err != nil && !test.expectErr {
+				t.Errorf("expected no error, but got: %v", err)
+			}
+			if err == nil && test.expectErr {
+				t.Errorf("expected an error, but got none")
+			}
+
+			if !equalReason(revokeReason, test.expectedRevokeReason) {
+				t.Errorf("unexpected revocation reason, exp=%v got=%v", test.expectedRevokeReason, revokeReason)
+			}
+
+			b.CheckAndFinish(err)
+		})
+	}
+}
+
+func TestSetFinalizer(t *testing.T) {
+	crt := gen.Certificate("test")
+	crt.Finalizers = []string{"other"}
+
+	setFinalizer(crt, true)
+	setFinalizer(crt, true)
+	if !hasFinalizer(crt) || len(crt.Finalizers) != 2 {
+		t.Errorf("expected the revocation finalizer to be added once, got %v", crt.Finalizers)
+	}
+
+	setFinalizer(crt, false)
+	if hasFinalizer(crt) || len(crt.Finalizers) != 1 || crt.Finalizers[0] != "other" {
+		t.Errorf("expected only the revocation finalizer to be removed, got %v", crt.Finalizers)
+	}
+}
+
+func reasonCode(r acmeapi.CRLReasonCode) *acmeapi.CRLReasonCode {
+	return &r
+}
+
+func equalReason(a, b *acmeapi.CRLReasonCode) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
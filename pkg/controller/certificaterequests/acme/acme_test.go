@@ -99,6 +99,10 @@ func TestSign(t *testing.T) {
 			Group: certmanager.GroupName,
 			Kind:  "Issuer",
 		}),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionApproved,
+			Status: cmmeta.ConditionTrue,
+		}),
 	)
 
 	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
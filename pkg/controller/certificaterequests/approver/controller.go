@@ -0,0 +1,167 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	ControllerName = "certificaterequests-approver"
+)
+
+// Evaluator decides whether a CertificateRequest should be approved or
+// denied. Policy engines can replace the default CertificateRequestPolicy
+// evaluator by disabling the 'certificaterequests-approver' controller and
+// registering their own controller constructed with New.
+type Evaluator interface {
+	// Evaluate returns the Decision for the given CertificateRequest. A nil
+	// Decision indicates that no decision can be made yet, and the
+	// CertificateRequest will be left unmodified.
+	Evaluate(context.Context, *cmapi.CertificateRequest) (*Decision, error)
+}
+
+// Decision is the result of evaluating a CertificateRequest.
+type Decision struct {
+	// Approved is true if the CertificateRequest should be approved, and false
+	// if it should be denied.
+	Approved bool
+
+	// Reason is a brief machine readable explanation for the decision, used
+	// as the reason of the Approved or Denied condition.
+	Reason string
+
+	// Message is a human readable description of the decision, used as the
+	// message of the Approved or Denied condition.
+	Message string
+}
+
+type Controller struct {
+	// clientset used to update cert-manager API resources
+	cmClient cmclient.Interface
+
+	certificateRequestLister cmlisters.CertificateRequestLister
+
+	queue workqueue.RateLimitingInterface
+
+	// logger to be used by this controller
+	log logr.Logger
+
+	// used to record Events about resources to the API
+	recorder record.EventRecorder
+
+	// evaluator decides whether CertificateRequests are approved or denied
+	evaluator Evaluator
+}
+
+// New will construct a new approver controller using the given Evaluator
+// implementation.
+func New(evaluator Evaluator) *Controller {
+	return &Controller{
+		evaluator: evaluator,
+	}
+}
+
+// Register registers and constructs the controller using the provided context.
+// It returns the workqueue to be used to enqueue items, a list of
+// InformerSynced functions that must be synced, or an error.
+func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, []controllerpkg.RunFunc, error) {
+	// construct a new named logger to be reused throughout the controller
+	c.log = logf.FromContext(ctx.RootContext, ControllerName)
+
+	// create a queue used to queue up items to be processed
+	c.queue = workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().CertificateRequests()
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateRequestInformer.Informer().HasSynced,
+	}
+
+	// set all the references to the listers for used by the Sync function
+	c.certificateRequestLister = certificateRequestInformer.Lister()
+
+	// allow the evaluator to obtain any informers it requires
+	if r, ok := c.evaluator.(registerable); ok {
+		mustSync = append(mustSync, r.Register(ctx)...)
+	}
+
+	// register handler functions
+	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+
+	c.cmClient = ctx.CMClient
+	c.recorder = ctx.Recorder
+
+	return c.queue, mustSync, nil, nil
+}
+
+func (c *Controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key")
+		return nil
+	}
+
+	cr, err := c.certificateRequestLister.CertificateRequests(namespace).Get(name)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Error(err, "certificate request in work queue no longer exists")
+			return nil
+		}
+
+		return err
+	}
+
+	ctx = logf.NewContext(ctx, logf.WithResource(log, cr))
+	return c.Sync(ctx, cr)
+}
+
+// autoApprover is an Evaluator which approves every CertificateRequest. It
+// is used for issuers that are not referenced by any CertificateRequestPolicy.
+type autoApprover struct{}
+
+func (autoApprover) Evaluate(context.Context, *cmapi.CertificateRequest) (*Decision, error) {
+	return &Decision{
+		Approved: true,
+		Reason:   "cert-manager.io",
+		Message:  "Certificate request has been approved by cert-manager.io",
+	}, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(New(&policyEvaluator{})).
+			Complete()
+	})
+}
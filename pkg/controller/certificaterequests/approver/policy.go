@@ -0,0 +1,341 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	// policyViolationReason is the reason used when a CertificateRequest is
+	// denied by the policy evaluator.
+	policyViolationReason = "PolicyViolation"
+)
+
+// registerable is implemented by Evaluators that need to obtain informers
+// from the controller context. The returned InformerSynced functions must
+// be synced before the controller begins processing items.
+type registerable interface {
+	Register(*controllerpkg.Context) []cache.InformerSynced
+}
+
+// policyEvaluator is an Evaluator that approves or denies CertificateRequests
+// based on the CertificateRequestPolicies that reference their issuer.
+// CertificateRequests for issuers that are not referenced by any policy are
+// approved.
+type policyEvaluator struct {
+	policyLister cmlisters.CertificateRequestPolicyLister
+}
+
+var _ Evaluator = &policyEvaluator{}
+var _ registerable = &policyEvaluator{}
+
+func (p *policyEvaluator) Register(ctx *controllerpkg.Context) []cache.InformerSynced {
+	policyInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().CertificateRequestPolicies()
+	p.policyLister = policyInformer.Lister()
+	return []cache.InformerSynced{policyInformer.Informer().HasSynced}
+}
+
+func (p *policyEvaluator) Evaluate(ctx context.Context, cr *cmapi.CertificateRequest) (*Decision, error) {
+	log := logf.FromContext(ctx, "policy")
+
+	policies, err := p.policyLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	// sort policies by name so that decision messages are deterministic
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+
+	var bound []*cmapi.CertificateRequestPolicy
+	for _, policy := range policies {
+		if issuerRefsEqual(policy.Spec.IssuerRef, cr.Spec.IssuerRef) {
+			bound = append(bound, policy)
+		}
+	}
+
+	if len(bound) == 0 {
+		log.V(logf.DebugLevel).Info("no policy references the issuer, approving request")
+		return autoApprover{}.Evaluate(ctx, cr)
+	}
+
+	var matching []*cmapi.CertificateRequestPolicy
+	for _, policy := range bound {
+		if subjectsMatch(policy.Spec.Subjects, cr) {
+			matching = append(matching, policy)
+		}
+	}
+
+	if len(matching) == 0 {
+		return &Decision{
+			Approved: false,
+			Reason:   policyViolationReason,
+			Message:  fmt.Sprintf("No CertificateRequestPolicy for issuer %q applies to the requester", cr.Spec.IssuerRef.Name),
+		}, nil
+	}
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.CSRPEM)
+	if err != nil {
+		return &Decision{
+			Approved: false,
+			Reason:   policyViolationReason,
+			Message:  fmt.Sprintf("Failed to decode CSR in spec: %v", err),
+		}, nil
+	}
+
+	var violations []string
+	for _, policy := range matching {
+		errs := evaluateConstraints(policy.Spec.Constraints, cr, csr)
+		if len(errs) == 0 {
+			return &Decision{
+				Approved: true,
+				Reason:   "cert-manager.io",
+				Message:  fmt.Sprintf("Certificate request has been approved by CertificateRequestPolicy %q", policy.Name),
+			}, nil
+		}
+
+		violations = append(violations, fmt.Sprintf("%s: %s", policy.Name, strings.Join(errs, ", ")))
+	}
+
+	return &Decision{
+		Approved: false,
+		Reason:   policyViolationReason,
+		Message:  fmt.Sprintf("Certificate request does not satisfy any CertificateRequestPolicy: %s", strings.Join(violations, "; ")),
+	}, nil
+}
+
+// issuerRefsEqual returns true if both references point to the same issuer,
+// after defaulting the kind to 'Issuer' and the group to 'cert-manager.io'.
+func issuerRefsEqual(a, b cmmeta.ObjectReference) bool {
+	return a.Name == b.Name &&
+		defaultString(a.Kind, cmapi.IssuerKind) == defaultString(b.Kind, cmapi.IssuerKind) &&
+		defaultString(a.Group, cmapi.SchemeGroupVersion.Group) == defaultString(b.Group, cmapi.SchemeGroupVersion.Group)
+}
+
+func defaultString(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// subjectsMatch returns true if the CertificateRequest is in one of the
+// subject namespaces, or was created by one of the subject ServiceAccounts.
+func subjectsMatch(subjects cmapi.CertificateRequestPolicySubjects, cr *cmapi.CertificateRequest) bool {
+	for _, ns := range subjects.Namespaces {
+		if ns == cr.Namespace {
+			return true
+		}
+	}
+
+	for _, sa := range subjects.ServiceAccounts {
+		if cr.Spec.Username == fmt.Sprintf("system:serviceaccount:%s:%s", sa.Namespace, sa.Name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// evaluateConstraints returns a list of the constraints that the
+// CertificateRequest violates. Constraints that are not set are not
+// enforced.
+func evaluateConstraints(constraints *cmapi.CertificateRequestPolicyConstraints, cr *cmapi.CertificateRequest, csr *x509.CertificateRequest) []string {
+	if constraints == nil {
+		return nil
+	}
+
+	var errs []string
+
+	if constraints.AllowedDNSNames != nil {
+		names := csr.DNSNames
+		if cn := csr.Subject.CommonName; len(cn) > 0 && !containsString(names, cn) {
+			names = append([]string{cn}, names...)
+		}
+		for _, name := range names {
+			if !matchesAnyGlob(constraints.AllowedDNSNames, name) {
+				errs = append(errs, fmt.Sprintf("DNS name %q is not allowed", name))
+			}
+		}
+	}
+
+	if constraints.AllowedURISANs != nil {
+		for _, uri := range csr.URIs {
+			if !matchesAnyGlob(constraints.AllowedURISANs, uri.String()) {
+				errs = append(errs, fmt.Sprintf("URI SAN %q is not allowed", uri.String()))
+			}
+		}
+	}
+
+	if constraints.AllowedIPRanges != nil {
+		for _, ip := range csr.IPAddresses {
+			if !containedInAnyRange(constraints.AllowedIPRanges, ip) {
+				errs = append(errs, fmt.Sprintf("IP address %q is not allowed", ip.String()))
+			}
+		}
+	}
+
+	if constraints.MaxDuration != nil {
+		duration := apiutil.DefaultCertDuration(cr.Spec.Duration)
+		if duration > constraints.MaxDuration.Duration {
+			errs = append(errs, fmt.Sprintf("duration %s exceeds maximum of %s", duration, constraints.MaxDuration.Duration))
+		}
+	}
+
+	if constraints.AllowIsCA != nil && !*constraints.AllowIsCA && cr.Spec.IsCA {
+		errs = append(errs, "isCA is not allowed")
+	}
+
+	if constraints.AllowedUsages != nil {
+		usages := cr.Spec.Usages
+		if len(usages) == 0 {
+			usages = cmapi.DefaultKeyUsages()
+		}
+		for _, u := range usages {
+			if !containsUsage(constraints.AllowedUsages, u) {
+				errs = append(errs, fmt.Sprintf("usage %q is not allowed", u))
+			}
+		}
+	}
+
+	errs = append(errs, evaluateKeyConstraints(constraints, csr)...)
+
+	return errs
+}
+
+func evaluateKeyConstraints(constraints *cmapi.CertificateRequestPolicyConstraints, csr *x509.CertificateRequest) []string {
+	var (
+		alg     cmapi.KeyAlgorithm
+		size    int
+		minSize int
+	)
+
+	switch pub := csr.PublicKey.(type) {
+	case *rsa.PublicKey:
+		alg, size, minSize = cmapi.RSAKeyAlgorithm, pub.N.BitLen(), constraints.MinRSAKeySize
+	case *ecdsa.PublicKey:
+		alg, size, minSize = cmapi.ECDSAKeyAlgorithm, pub.Curve.Params().BitSize, constraints.MinECDSAKeySize
+	case ed25519.PublicKey:
+		alg = cmapi.Ed25519KeyAlgorithm
+	default:
+		return []string{fmt.Sprintf("unsupported public key type %T", csr.PublicKey)}
+	}
+
+	var errs []string
+	if constraints.AllowedKeyAlgorithms != nil && !containsKeyAlgorithm(constraints.AllowedKeyAlgorithms, alg) {
+		errs = append(errs, fmt.Sprintf("key algorithm %q is not allowed", alg))
+	}
+	if size < minSize {
+		errs = append(errs, fmt.Sprintf("%s key size %d is smaller than minimum of %d", alg, size, minSize))
+	}
+
+	return errs
+}
+
+func matchesAnyGlob(patterns []string, s string) bool {
+	for _, pattern := range patterns {
+		if globMatch(pattern, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// globMatch reports whether s matches the pattern, where '*' matches any
+// sequence of characters, including an empty one.
+func globMatch(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+
+	return strings.HasSuffix(s, last)
+}
+
+func containedInAnyRange(cidrs []string, ip net.IP) bool {
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsUsage(usages []cmapi.KeyUsage, u cmapi.KeyUsage) bool {
+	for _, usage := range usages {
+		if usage == u {
+			return true
+		}
+	}
+	return false
+}
+
+func containsKeyAlgorithm(algs []cmapi.KeyAlgorithm, alg cmapi.KeyAlgorithm) bool {
+	for _, a := range algs {
+		if a == alg {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func mustGenerateCSR(t *testing.T, keyAlgorithm x509.PublicKeyAlgorithm, mods ...gen.CSRModifier) []byte {
+	csr, _, err := gen.CSR(keyAlgorithm, mods...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return csr
+}
+
+func TestPolicyEvaluator(t *testing.T) {
+	issuerRef := cmmeta.ObjectReference{
+		Name: "test-issuer",
+	}
+
+	csrPEM := mustGenerateCSR(t, x509.ECDSA,
+		gen.SetCSRDNSNames("app.team-a.example.com"),
+		gen.SetCSRIPAddresses(net.ParseIP("10.0.0.1")),
+		gen.SetCSRURIs(&url.URL{Scheme: "spiffe", Host: "cluster.local", Path: "/ns/team-a/sa/app"}),
+	)
+
+	baseCR := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestIssuer(issuerRef),
+		gen.SetCertificateRequestCSR(csrPEM),
+	)
+
+	policy := func(name string, subjects cmapi.CertificateRequestPolicySubjects, constraints *cmapi.CertificateRequestPolicyConstraints) *cmapi.CertificateRequestPolicy {
+		return &cmapi.CertificateRequestPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: cmapi.CertificateRequestPolicySpec{
+				IssuerRef:   issuerRef,
+				Subjects:    subjects,
+				Constraints: constraints,
+			},
+		}
+	}
+	namespaceSubjects := cmapi.CertificateRequestPolicySubjects{
+		Namespaces: []string{gen.DefaultTestNamespace},
+	}
+	falseVal := false
+
+	tests := map[string]struct {
+		policies         []runtime.Object
+		cr               *cmapi.CertificateRequest
+		expectedDecision *Decision
+	}{
+		"approve if no policy references the issuer": {
+			policies: []runtime.Object{
+				&cmapi.CertificateRequestPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "other"},
+					Spec: cmapi.CertificateRequestPolicySpec{
+						IssuerRef: cmmeta.ObjectReference{Name: "test-issuer", Kind: cmapi.ClusterIssuerKind},
+						Subjects:  namespaceSubjects,
+					},
+				},
+			},
+			cr: baseCR,
+			expectedDecision: &Decision{
+				Approved: true,
+				Reason:   "cert-manager.io",
+				Message:  "Certificate request has been approved by cert-manager.io",
+			},
+		},
+		"deny if no policy for the issuer applies to the requester": {
+			policies: []runtime.Object{
+				policy("other-namespace", cmapi.CertificateRequestPolicySubjects{Namespaces: []string{"other"}}, nil),
+			},
+			cr: baseCR,
+			expectedDecision: &Decision{
+				Approved: false,
+				Reason:   policyViolationReason,
+				Message:  `No CertificateRequestPolicy for issuer "test-issuer" applies to the requester`,
+			},
+		},
+		"approve if a policy binds the namespace with no constraints": {
+			policies: []runtime.Object{
+				policy("team-a", namespaceSubjects, nil),
+			},
+			cr: baseCR,
+			expectedDecision: &Decision{
+				Approved: true,
+				Reason:   "cert-manager.io",
+				Message:  `Certificate request has been approved by CertificateRequestPolicy "team-a"`,
+			},
+		},
+		"approve if a policy binds the requesting ServiceAccount": {
+			policies: []runtime.Object{
+				policy("team-a", cmapi.CertificateRequestPolicySubjects{
+					ServiceAccounts: []cmapi.CertificateRequestPolicyServiceAccount{
+						{Name: "app", Namespace: "team-a"},
+					},
+				}, nil),
+			},
+			cr: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestUsername("system:serviceaccount:team-a:app"),
+			),
+			expectedDecision: &Decision{
+				Approved: true,
+				Reason:   "cert-manager.io",
+				Message:  `Certificate request has been approved by CertificateRequestPolicy "team-a"`,
+			},
+		},
+		"approve if the request satisfies all constraints": {
+			policies: []runtime.Object{
+				policy("team-a", namespaceSubjects, &cmapi.CertificateRequestPolicyConstraints{
+					AllowedDNSNames:      []string{"*.team-a.example.com"},
+					AllowedURISANs:       []string{"spiffe://cluster.local/ns/team-a/*"},
+					AllowedIPRanges:      []string{"10.0.0.0/8"},
+					MaxDuration:          &metav1.Duration{Duration: cmapi.DefaultCertificateDuration},
+					AllowIsCA:            &falseVal,
+					AllowedUsages:        cmapi.DefaultKeyUsages(),
+					AllowedKeyAlgorithms: []cmapi.KeyAlgorithm{cmapi.ECDSAKeyAlgorithm},
+					MinECDSAKeySize:      256,
+				}),
+			},
+			cr: baseCR,
+			expectedDecision: &Decision{
+				Approved: true,
+				Reason:   "cert-manager.io",
+				Message:  `Certificate request has been approved by CertificateRequestPolicy "team-a"`,
+			},
+		},
+		"approve if any matching policy is satisfied": {
+			policies: []runtime.Object{
+				policy("a-strict", namespaceSubjects, &cmapi.CertificateRequestPolicyConstraints{
+					AllowedDNSNames: []string{"*.team-b.example.com"},
+				}),
+				policy("b-relaxed", namespaceSubjects, &cmapi.CertificateRequestPolicyConstraints{
+					AllowedDNSNames: []string{"*.example.com"},
+				}),
+			},
+			cr: baseCR,
+			expectedDecision: &Decision{
+				Approved: true,
+				Reason:   "cert-manager.io",
+				Message:  `Certificate request has been approved by CertificateRequestPolicy "b-relaxed"`,
+			},
+		},
+		"deny if the request violates the constraints": {
+			policies: []runtime.Object{
+				policy("team-a", namespaceSubjects, &cmapi.CertificateRequestPolicyConstraints{
+					AllowedDNSNames:      []string{"*.team-b.example.com"},
+					AllowedURISANs:       []string{"spiffe://cluster.local/ns/team-b/*"},
+					AllowedIPRanges:      []string{"192.168.0.0/16"},
+					MaxDuration:          &metav1.Duration{Duration: time.Hour},
+					AllowIsCA:            &falseVal,
+					AllowedUsages:        []cmapi.KeyUsage{cmapi.UsageServerAuth},
+					AllowedKeyAlgorithms: []cmapi.KeyAlgorithm{cmapi.RSAKeyAlgorithm},
+					MinECDSAKeySize:      384,
+				}),
+			},
+			cr: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestIsCA(true),
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageServerAuth, cmapi.UsageClientAuth),
+			),
+			expectedDecision: &Decision{
+				Approved: false,
+				Reason:   policyViolationReason,
+				Message: `Certificate request does not satisfy any CertificateRequestPolicy: team-a: ` +
+					`DNS name "app.team-a.example.com" is not allowed, ` +
+					`URI SAN "spiffe://cluster.local/ns/team-a/sa/app" is not allowed, ` +
+					`IP address "10.0.0.1" is not allowed, ` +
+					`duration 2160h0m0s exceeds maximum of 1h0m0s, ` +
+					`isCA is not allowed, ` +
+					`usage "client auth" is not allowed, ` +
+					`key algorithm "ecdsa" is not allowed, ` +
+					`ecdsa key size 256 is smaller than minimum of 384`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				CertManagerObjects: test.policies,
+			}
+			builder.Init()
+			defer builder.Stop()
+
+			p := &policyEvaluator{}
+			p.Register(builder.Context)
+			builder.Start()
+
+			decision, err := p.Evaluate(context.Background(), test.cr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(test.expectedDecision, decision) {
+				t.Errorf("unexpected decision, exp=%+v got=%+v", test.expectedDecision, decision)
+			}
+		})
+	}
+}
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		match      bool
+	}{
+		{"example.com", "example.com", true},
+		{"example.com", "www.example.com", false},
+		{"*.example.com", "www.example.com", true},
+		{"*.example.com", "a.b.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"spiffe://cluster.local/ns/*/sa/app", "spiffe://cluster.local/ns/team-a/sa/app", true},
+		{"spiffe://cluster.local/ns/*/sa/app", "spiffe://cluster.local/ns/team-a/sa/other", false},
+		{"a*a", "a", false},
+		{"*", "", true},
+	}
+
+	for _, test := range tests {
+		if got := globMatch(test.pattern, test.s); got != test.match {
+			t.Errorf("globMatch(%q, %q) = %t, expected %t", test.pattern, test.s, got, test.match)
+		}
+	}
+}
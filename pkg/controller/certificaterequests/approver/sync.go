@@ -0,0 +1,76 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) error {
+	log := logf.FromContext(ctx)
+	dbg := log.V(logf.DebugLevel)
+
+	// Once a decision has been made it cannot be changed.
+	if apiutil.CertificateRequestIsApproved(cr) || apiutil.CertificateRequestIsDenied(cr) {
+		dbg.Info("certificate request has already been approved or denied so skipping processing")
+		return nil
+	}
+
+	// CertificateRequests that completed before approval was required do not
+	// need a decision.
+	switch apiutil.CertificateRequestReadyReason(cr) {
+	case cmapi.CertificateRequestReasonFailed, cmapi.CertificateRequestReasonIssued:
+		dbg.Info("certificate request has already completed so skipping processing")
+		return nil
+	}
+
+	decision, err := c.evaluator.Evaluate(ctx, cr)
+	if err != nil {
+		log.Error(err, "failed to evaluate certificate request")
+		return err
+	}
+
+	if decision == nil {
+		dbg.Info("no decision has been made for certificate request yet")
+		return nil
+	}
+
+	crCopy := cr.DeepCopy()
+
+	conditionType, eventType := cmapi.CertificateRequestConditionApproved, corev1.EventTypeNormal
+	if !decision.Approved {
+		conditionType, eventType = cmapi.CertificateRequestConditionDenied, corev1.EventTypeWarning
+	}
+
+	apiutil.SetCertificateRequestCondition(crCopy, conditionType, cmmeta.ConditionTrue, decision.Reason, decision.Message)
+
+	if _, err := c.cmClient.CertmanagerV1alpha2().CertificateRequests(crCopy.Namespace).UpdateStatus(crCopy); err != nil {
+		return err
+	}
+
+	log.Info("recorded decision for certificate request", "condition", conditionType)
+	c.recorder.Event(crCopy, eventType, string(conditionType), decision.Message)
+
+	return nil
+}
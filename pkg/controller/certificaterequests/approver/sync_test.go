@@ -0,0 +1,218 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+var (
+	fixedClockStart = time.Now()
+	fixedClock      = fakeclock.NewFakeClock(fixedClockStart)
+)
+
+type fakeEvaluator func(context.Context, *cmapi.CertificateRequest) (*Decision, error)
+
+func (f fakeEvaluator) Evaluate(ctx context.Context, cr *cmapi.CertificateRequest) (*Decision, error) {
+	return f(ctx, cr)
+}
+
+func TestSync(t *testing.T) {
+	nowMetaTime := metav1.NewTime(fixedClockStart)
+
+	baseCR := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+			Kind: "Issuer",
+			Name: "test-issuer",
+		}),
+	)
+
+	approvedCondition := cmapi.CertificateRequestCondition{
+		Type:               cmapi.CertificateRequestConditionApproved,
+		Status:             cmmeta.ConditionTrue,
+		Reason:             "cert-manager.io",
+		Message:            "Certificate request has been approved by cert-manager.io",
+		LastTransitionTime: &nowMetaTime,
+	}
+
+	deniedCondition := cmapi.CertificateRequestCondition{
+		Type:               cmapi.CertificateRequestConditionDenied,
+		Status:             cmmeta.ConditionTrue,
+		Reason:             "PolicyViolation",
+		Message:            "Request does not match policy",
+		LastTransitionTime: &nowMetaTime,
+	}
+
+	denyEvaluator := fakeEvaluator(func(context.Context, *cmapi.CertificateRequest) (*Decision, error) {
+		return &Decision{Approved: false, Reason: "PolicyViolation", Message: "Request does not match policy"}, nil
+	})
+
+	tests := map[string]testT{
+		"approve a certificate request with no decision using the default evaluator": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal Approved Certificate request has been approved by cert-manager.io",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(approvedCondition),
+						),
+					)),
+				},
+			},
+		},
+		"deny a certificate request if the evaluator denies it": {
+			certificateRequest: baseCR.DeepCopy(),
+			evaluator:          denyEvaluator,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning Denied Request does not match policy",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(deniedCondition),
+						),
+					)),
+				},
+			},
+		},
+		"do nothing if the certificate request has already been approved": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestStatusCondition(approvedCondition),
+			),
+			evaluator: denyEvaluator,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy()},
+				ExpectedEvents:     []string{},
+				ExpectedActions:    []testpkg.Action{},
+			},
+		},
+		"do nothing if the certificate request has already been denied": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestStatusCondition(deniedCondition),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy()},
+				ExpectedEvents:     []string{},
+				ExpectedActions:    []testpkg.Action{},
+			},
+		},
+		"do nothing if the certificate request was issued before approval was required": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:   cmapi.CertificateRequestConditionReady,
+					Status: cmmeta.ConditionTrue,
+					Reason: cmapi.CertificateRequestReasonIssued,
+				}),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy()},
+				ExpectedEvents:     []string{},
+				ExpectedActions:    []testpkg.Action{},
+			},
+		},
+		"do nothing if the evaluator has not made a decision": {
+			certificateRequest: baseCR.DeepCopy(),
+			evaluator: fakeEvaluator(func(context.Context, *cmapi.CertificateRequest) (*Decision, error) {
+				return nil, nil
+			}),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy()},
+				ExpectedEvents:     []string{},
+				ExpectedActions:    []testpkg.Action{},
+			},
+		},
+		"return an error to retry if the evaluator fails": {
+			certificateRequest: baseCR.DeepCopy(),
+			evaluator: fakeEvaluator(func(context.Context, *cmapi.CertificateRequest) (*Decision, error) {
+				return nil, errors.New("evaluation failed")
+			}),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy()},
+				ExpectedEvents:     []string{},
+				ExpectedActions:    []testpkg.Action{},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+			apiutil.Clock = fixedClock
+			runTest(t, test)
+		})
+	}
+}
+
+type testT struct {
+	builder            *testpkg.Builder
+	evaluator          Evaluator
+	certificateRequest *cmapi.CertificateRequest
+	expectedErr        bool
+}
+
+func runTest(t *testing.T, test testT) {
+	test.builder.T = t
+	test.builder.Clock = fixedClock
+	test.builder.Init()
+
+	defer test.builder.Stop()
+
+	if test.evaluator == nil {
+		test.evaluator = &policyEvaluator{}
+	}
+
+	c := New(test.evaluator)
+	c.Register(test.builder.Context)
+
+	test.builder.Start()
+
+	err := c.Sync(context.Background(), test.certificateRequest)
+	if err != nil && !test.expectedErr {
+		t.Errorf("expected to not get an error, but got: %v", err)
+	}
+	if err == nil && test.expectedErr {
+		t.Errorf("expected to get an error but did not get one")
+	}
+	test.builder.CheckAndFinish(err)
+}
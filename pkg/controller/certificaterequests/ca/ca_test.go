@@ -123,6 +123,10 @@ func TestSign(t *testing.T) {
 			Kind:  "Issuer",
 		}),
 		gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 24 * 60}),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionApproved,
+			Status: cmmeta.ConditionTrue,
+		}),
 	)
 
 	// generate a self signed root ca valid for 60d
@@ -152,6 +156,22 @@ func TestSign(t *testing.T) {
 		t.FailNow()
 	}
 
+	revocationIssuer := gen.IssuerFrom(baseIssuer,
+		gen.SetIssuerCA(cmapi.CAIssuer{
+			SecretName:            "root-ca-secret",
+			CRLDistributionPoints: []string{"http://crl.example.com/ca.crl"},
+			OCSPServers:           []string{"http://ocsp.example.com"},
+		}),
+	)
+	revocationTemplate := *template
+	revocationTemplate.CRLDistributionPoints = revocationIssuer.Spec.CA.CRLDistributionPoints
+	revocationTemplate.OCSPServer = revocationIssuer.Spec.CA.OCSPServers
+	revocationCertPEM, _, err := pki.SignCSRTemplate([]*x509.Certificate{template}, skRSA, &revocationTemplate)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
 	metaFixedClockStart := metav1.NewTime(fixedClockStart)
 	tests := map[string]testT{
 		"a missing CA key pair should set the condition to pending and wait for a re-sync": {
@@ -341,6 +361,43 @@ func TestSign(t *testing.T) {
 				},
 			},
 		},
+		"a successful signing should set the CRL distribution points and OCSP servers of the issuer": {
+			certificateRequest: baseCR.DeepCopy(),
+			templateGenerator: func(cr *cmapi.CertificateRequest) (*x509.Certificate, error) {
+				_, err := pki.GenerateTemplateFromCertificateRequest(cr)
+				if err != nil {
+					return nil, err
+				}
+
+				tmpl := *template
+				return &tmpl, nil
+			},
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{rsaCASecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), revocationIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCA(rsaPEMCert),
+							gen.SetCertificateRequestCertificate(revocationCertPEM),
+						),
+					)),
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
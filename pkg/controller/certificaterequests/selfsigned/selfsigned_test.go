@@ -129,6 +129,27 @@ func TestSign(t *testing.T) {
 	}
 	csrECPEM := generateCSR(t, skEC, x509.ECDSAWithSHA256)
 
+	skEd25519, err := pki.GenerateEd25519PrivateKey()
+	if err != nil {
+		t.Errorf("failed to generate Ed25519 private key: %s", err)
+		t.FailNow()
+	}
+	skEd25519PEM, err := pki.EncodePKCS8PrivateKey(skEd25519)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	ed25519KeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rsaKeySecret.Name,
+			Namespace: gen.DefaultTestNamespace,
+		},
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: skEd25519PEM,
+		},
+	}
+	csrEd25519PEM := generateCSR(t, skEd25519, x509.PureEd25519)
+
 	baseCR := gen.CertificateRequest("test-cr",
 		gen.SetCertificateRequestAnnotations(
 			map[string]string{
@@ -141,11 +162,19 @@ func TestSign(t *testing.T) {
 			Group: certmanager.GroupName,
 			Kind:  "Issuer",
 		}),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionApproved,
+			Status: cmmeta.ConditionTrue,
+		}),
 	)
 	ecCR := gen.CertificateRequestFrom(baseCR,
 		gen.SetCertificateRequestCSR(csrECPEM),
 	)
 
+	ed25519CR := gen.CertificateRequestFrom(baseCR,
+		gen.SetCertificateRequestCSR(csrEd25519PEM),
+	)
+
 	templateRSA, err := pki.GenerateTemplateFromCertificateRequest(baseCR)
 	if err != nil {
 		t.Error(err)
@@ -168,6 +197,17 @@ func TestSign(t *testing.T) {
 		t.FailNow()
 	}
 
+	templateEd25519, err := pki.GenerateTemplateFromCertificateRequest(ed25519CR)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	certEd25519PEM, _, err := pki.SignCertificate(templateEd25519, templateEd25519, skEd25519.Public(), skEd25519)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
 	tests := map[string]testT{
 		"a CertificateRequest with no cert-manager.io/selfsigned-private-key annotation should fail": {
 			certificateRequest: gen.CertificateRequestFrom(baseCR,
@@ -519,6 +559,44 @@ func TestSign(t *testing.T) {
 				},
 			},
 		},
+		"should sign an Ed25519 key set condition to Ready": {
+			certificateRequest: ed25519CR.DeepCopy(),
+			signingFn: func(c1 *x509.Certificate, c2 *x509.Certificate, pk crypto.PublicKey, sk interface{}) ([]byte, *x509.Certificate, error) {
+				// We still check that it will sign and not error
+				// Return error if we do
+				_, _, err := pki.SignCertificate(c1, c2, pk, sk)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				return certEd25519PEM, nil, nil
+			},
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{ed25519KeySecret},
+				CertManagerObjects: []runtime.Object{ed25519CR.DeepCopy(), baseIssuer},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(ed25519CR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCertificate(certEd25519PEM),
+							gen.SetCertificateRequestCA(certEd25519PEM),
+						),
+					)),
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -101,6 +101,21 @@ func (c *Controller) Sync(ctx context.Context, cr *v1alpha2.CertificateRequest)
 		return nil
 	}
 
+	// If the CertificateRequest has been denied by an approval controller it
+	// must never be signed, so mark it as failed.
+	if apiutil.CertificateRequestIsDenied(crCopy) {
+		dbg.Info("certificate request has been denied so marking as failed")
+		c.reporter.Denied(crCopy)
+		return nil
+	}
+
+	// Wait for an approval controller to approve the CertificateRequest
+	// before attempting to sign it.
+	if !apiutil.CertificateRequestIsApproved(crCopy) {
+		dbg.Info("certificate request has not been approved so skipping processing")
+		return nil
+	}
+
 	// check ready condition
 	if !apiutil.IssuerHasCondition(issuerObj, v1alpha2.IssuerCondition{
 		Type:   v1alpha2.IssuerConditionReady,
@@ -27,10 +27,12 @@ import (
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/metrics"
 )
 
 const (
-	readyMessage = "Certificate fetched from issuer successfully"
+	readyMessage  = "Certificate fetched from issuer successfully"
+	deniedMessage = "The CertificateRequest was denied by an approval controller"
 )
 
 type Reporter struct {
@@ -46,6 +48,11 @@ func NewReporter(clock clock.Clock, recorder record.EventRecorder) *Reporter {
 }
 
 func (r *Reporter) Failed(cr *cmapi.CertificateRequest, err error, reason, message string) {
+	// Only count the failure once, if the request has not already failed.
+	if apiutil.CertificateRequestReadyReason(cr) != cmapi.CertificateRequestReasonFailed {
+		metrics.Default.IncrementCertificateRequestFailureCount(cr, reason)
+	}
+
 	// Set the FailureTime to c.clock.Now(), only if it has not been already set.
 	if cr.Status.FailureTime == nil {
 		nowTime := metav1.NewTime(r.clock.Now())
@@ -59,6 +66,24 @@ func (r *Reporter) Failed(cr *cmapi.CertificateRequest, err error, reason, messa
 
 }
 
+// Denied marks the CertificateRequest as Failed, as it has been denied by an
+// approval controller and so must never be signed.
+func (r *Reporter) Denied(cr *cmapi.CertificateRequest) {
+	if apiutil.CertificateRequestReadyReason(cr) != cmapi.CertificateRequestReasonFailed {
+		metrics.Default.IncrementCertificateRequestFailureCount(cr, "Denied")
+	}
+
+	// Set the FailureTime to c.clock.Now(), only if it has not been already set.
+	if cr.Status.FailureTime == nil {
+		nowTime := metav1.NewTime(r.clock.Now())
+		cr.Status.FailureTime = &nowTime
+	}
+
+	r.recorder.Event(cr, corev1.EventTypeWarning, "Denied", deniedMessage)
+	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady,
+		cmmeta.ConditionFalse, cmapi.CertificateRequestReasonFailed, deniedMessage)
+}
+
 func (r *Reporter) InvalidRequest(cr *cmapi.CertificateRequest, reason, message string) {
 	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionInvalidRequest,
 		cmmeta.ConditionTrue, reason, message)
@@ -81,6 +106,11 @@ func (r *Reporter) Pending(cr *cmapi.CertificateRequest, err error, reason, mess
 }
 
 func (r *Reporter) Ready(cr *cmapi.CertificateRequest) {
+	// Only observe the issuance once, if the request was not already Ready.
+	if apiutil.CertificateRequestReadyReason(cr) != cmapi.CertificateRequestReasonIssued {
+		metrics.Default.ObserveCertificateRequestIssuance(cr, r.clock.Now())
+	}
+
 	r.recorder.Event(cr, corev1.EventTypeNormal, "CertificateIssued", readyMessage)
 	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady,
 		cmmeta.ConditionTrue, cmapi.CertificateRequestReasonIssued, readyMessage)
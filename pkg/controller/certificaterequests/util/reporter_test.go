@@ -68,6 +68,14 @@ func TestReporter(t *testing.T) {
 		LastTransitionTime: &nowMetaTime,
 	}
 
+	deniedCondition := cmapi.CertificateRequestCondition{
+		Type:               cmapi.CertificateRequestConditionReady,
+		Reason:             "Failed",
+		Message:            "The CertificateRequest was denied by an approval controller",
+		Status:             "False",
+		LastTransitionTime: &nowMetaTime,
+	}
+
 	invalidRequestCondition := cmapi.CertificateRequestCondition{
 		Type:               cmapi.CertificateRequestConditionInvalidRequest,
 		Status:             "True",
@@ -133,6 +141,18 @@ func TestReporter(t *testing.T) {
 			call: "failed",
 		},
 
+		"a denied report should update the conditions and set FailureTime as it is nil": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR),
+
+			expectedEvents: []string{
+				"Warning Denied The CertificateRequest was denied by an approval controller",
+			},
+			expectedConditions:  []cmapi.CertificateRequestCondition{deniedCondition},
+			expectedFailureTime: &nowMetaTime,
+
+			call: "denied",
+		},
+
 		"a report with invalid request should update the conditions and set FailureTime as it is nil": {
 			certificateRequest: gen.CertificateRequestFrom(baseCR),
 			err:                nil,
@@ -223,6 +243,8 @@ func (tt *reporterT) runTest(t *testing.T) {
 	case "failed":
 		reporter.Failed(tt.certificateRequest, tt.err,
 			tt.reason, tt.message)
+	case "denied":
+		reporter.Denied(tt.certificateRequest)
 	case "invalid-request":
 		reporter.InvalidRequest(tt.certificateRequest, tt.reason, tt.message)
 	case "pending":
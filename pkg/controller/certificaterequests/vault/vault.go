@@ -18,6 +18,7 @@ package vault
 
 import (
 	"context"
+	"fmt"
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	corelisters "k8s.io/client-go/listers/core/v1"
@@ -66,6 +67,16 @@ func (v *Vault) Sign(ctx context.Context, cr *v1alpha2.CertificateRequest, issue
 	log := logf.FromContext(ctx, "sign")
 	log = logf.WithRelatedResource(log, issuerObj)
 
+	params := vaultinternal.ParametersFromAnnotations(cr.Annotations)
+	if err := vaultinternal.ValidateParameters(issuerObj, params); err != nil {
+		message := "Invalid Vault signing request parameters"
+
+		v.reporter.Failed(cr, err, "InvalidParameters", message)
+		v.reporter.InvalidRequest(cr, "InvalidParameters", fmt.Sprintf("%s: %v", message, err))
+		log.Error(err, message)
+		return nil, nil
+	}
+
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
 	client, err := v.vaultClientBuilder(resourceNamespace, v.secretsLister, issuerObj)
@@ -86,7 +97,7 @@ func (v *Vault) Sign(ctx context.Context, cr *v1alpha2.CertificateRequest, issue
 	}
 
 	certDuration := apiutil.DefaultCertDuration(cr.Spec.Duration)
-	certPem, caPem, err := client.Sign(cr.Spec.CSRPEM, certDuration)
+	certPem, caPem, err := client.Sign(cr.Spec.CSRPEM, certDuration, params)
 	if err != nil {
 		message := "Vault failed to sign certificate"
 
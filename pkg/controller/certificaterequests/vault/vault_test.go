@@ -122,6 +122,10 @@ func TestSign(t *testing.T) {
 			Group: certmanager.GroupName,
 			Kind:  baseIssuer.Kind,
 		}),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionApproved,
+			Status: cmmeta.ConditionTrue,
+		}),
 	)
 
 	rsaPEMCert, err := generateSelfSignedCertFromCR(baseCR, rsaSK, time.Hour*24*60)
@@ -151,13 +155,13 @@ func TestSign(t *testing.T) {
 	}
 
 	tests := map[string]testT{
-		"no token, app role secret or kubernetes auth reference should report pending": {
+		"no token, app role secret, kubernetes auth or client certificate reference should report pending": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
 				KubeObjects:        []runtime.Object{},
 				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					"Normal VaultInitError Failed to initialise vault client for signing: error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set",
+					"Normal VaultInitError Failed to initialise vault client for signing: error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes auth role or clientCertificate not set",
 				},
 				ExpectedActions: []testpkg.Action{
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -169,7 +173,7 @@ func TestSign(t *testing.T) {
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonPending,
-								Message:            "Failed to initialise vault client for signing: error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set",
+								Message:            "Failed to initialise vault client for signing: error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes auth role or clientCertificate not set",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
@@ -364,6 +368,112 @@ func TestSign(t *testing.T) {
 			},
 			fakeVault: fakevault.New().WithSign(nil, nil, errors.New("failed to sign")),
 		},
+		"a parameter annotation not in the issuer's allowed parameters should report an invalid request": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.AddCertificateRequestAnnotations(map[string]string{
+					"vault.cert-manager.io/param.other_sans": "1.2.3;utf8:abc",
+				}),
+			),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{tokenSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), gen.IssuerFrom(baseIssuer,
+					gen.SetIssuerVault(cmapi.VaultIssuer{
+						Auth: cmapi.VaultAuth{
+							TokenSecretRef: &cmmeta.SecretKeySelector{
+								Key: "my-token-key",
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "token-secret",
+								},
+							},
+						},
+						AllowedParameters: []string{"format"},
+					}),
+				)},
+				ExpectedEvents: []string{
+					`Warning InvalidParameters Invalid Vault signing request parameters: vault parameter "other_sans" is not in the issuer's allowed parameters`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								"vault.cert-manager.io/param.other_sans": "1.2.3;utf8:abc",
+							}),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Invalid Vault signing request parameters: vault parameter "other_sans" is not in the issuer's allowed parameters`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionInvalidRequest,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "InvalidParameters",
+								Message:            `Invalid Vault signing request parameters: vault parameter "other_sans" is not in the issuer's allowed parameters`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+		},
+		"a parameter annotation overriding a reserved parameter should report an invalid request": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.AddCertificateRequestAnnotations(map[string]string{
+					"vault.cert-manager.io/param.common_name": "other",
+				}),
+			),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{tokenSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), gen.IssuerFrom(baseIssuer,
+					gen.SetIssuerVault(cmapi.VaultIssuer{
+						Auth: cmapi.VaultAuth{
+							TokenSecretRef: &cmmeta.SecretKeySelector{
+								Key: "my-token-key",
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "token-secret",
+								},
+							},
+						},
+						AllowedParameters: []string{"common_name"},
+					}),
+				)},
+				ExpectedEvents: []string{
+					`Warning InvalidParameters Invalid Vault signing request parameters: vault parameter "common_name" is set by cert-manager and cannot be overridden`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								"vault.cert-manager.io/param.common_name": "other",
+							}),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Invalid Vault signing request parameters: vault parameter "common_name" is set by cert-manager and cannot be overridden`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionInvalidRequest,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "InvalidParameters",
+								Message:            `Invalid Vault signing request parameters: vault parameter "common_name" is set by cert-manager and cannot be overridden`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+		},
 		"a client with a token secret referenced with token and signs should return certificate": {
 			certificateRequest: baseCR,
 			builder: &testpkg.Builder{
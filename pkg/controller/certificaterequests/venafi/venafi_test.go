@@ -136,6 +136,10 @@ func TestSign(t *testing.T) {
 
 	baseCR := gen.CertificateRequest("test-cr",
 		gen.SetCertificateRequestCSR(csrPEM),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionApproved,
+			Status: cmmeta.ConditionTrue,
+		}),
 	)
 
 	tppCR := gen.CertificateRequestFrom(baseCR,
@@ -69,10 +69,26 @@ func certificatesForSecret(certificateLister cmlisters.CertificateLister, secret
 		if crt.Namespace != secret.Namespace {
 			continue
 		}
-		if crt.Spec.SecretName == secret.Name {
+		if crt.Spec.SecretName == secret.Name || certificateUsesKeystorePasswordSecret(crt, secret.Name) {
 			affected = append(affected, crt)
 		}
 	}
 
 	return affected, nil
 }
+
+// certificateUsesKeystorePasswordSecret returns true if any keystore enabled on
+// the Certificate reads its password from the named Secret.
+func certificateUsesKeystorePasswordSecret(crt *cmapi.Certificate, name string) bool {
+	ks := crt.Spec.Keystores
+	if ks == nil {
+		return false
+	}
+	if ks.JKS != nil && ks.JKS.Create && ks.JKS.PasswordSecretRef.Name == name {
+		return true
+	}
+	if ks.PKCS12 != nil && ks.PKCS12.Create && ks.PKCS12.PasswordSecretRef.Name == name {
+		return true
+	}
+	return false
+}
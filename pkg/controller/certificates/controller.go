@@ -28,10 +28,12 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
+	"github.com/jetstack/cert-manager/pkg/acme"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/scheduler"
 )
@@ -74,9 +76,24 @@ type certificateRequestManager struct {
 	// to the controller context, and to make it easier to fake out this call during tests.
 	calculateDurationUntilRenew calculateDurationUntilRenewFn
 
+	// calculateRenewalTime returns the time at which the controller should
+	// begin attempting to renew the certificate, which is recorded on the
+	// Certificate's status.
+	calculateRenewalTime calculateRenewalTimeFn
+
 	// localTemporarySigner signs a certificate that is stored temporarily
 	localTemporarySigner localTemporarySignerFn
 
+	// renewalInfo fetches the renewal window suggested by the ACME server
+	// that issued a certificate.
+	// This is a field on the controller struct to make it easier to fake out
+	// this call during tests.
+	renewalInfo renewalInfoFn
+
+	// helpers used to obtain ACME clients when fetching renewal information
+	issuerHelper issuer.Helper
+	acmeHelper   acme.Helper
+
 	// if true, Secret resources created by the controller will have an
 	// 'owner reference' set, meaning when the Certificate is deleted, the
 	// Secret resource will be automatically deleted.
@@ -114,6 +131,19 @@ func (c *certificateRequestManager) Register(ctx *controllerpkg.Context) (workqu
 	c.secretLister = secretsInformer.Lister()
 	c.certificateLister = certificateInformer.Lister()
 
+	// issuers are used to fetch the renewal information of certificates
+	// issued by ACME issuers
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().Issuers()
+	mustSync = append(mustSync, issuerInformer.Informer().HasSynced)
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().ClusterIssuers()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+	}
+	c.issuerHelper = issuer.NewHelper(issuerInformer.Lister(), clusterIssuerLister)
+	c.acmeHelper = acme.NewHelper(c.secretLister, ctx.ClusterResourceNamespace)
+
 	// register handler functions
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
 	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: controllerpkg.HandleOwnedResourceNamespacedFunc(log, c.queue, certificateGvk, certificateGetter(c.certificateLister))})
@@ -132,11 +162,13 @@ func (c *certificateRequestManager) Register(ctx *controllerpkg.Context) (workqu
 
 	c.certificateNeedsRenew = ctx.IssuerOptions.CertificateNeedsRenew
 	c.calculateDurationUntilRenew = ctx.IssuerOptions.CalculateDurationUntilRenew
+	c.calculateRenewalTime = ctx.IssuerOptions.CalculateRenewalTime
 	c.generatePrivateKeyBytes = generatePrivateKeyBytesImpl
 	c.generateCSR = generateCSRImpl
 	// the localTemporarySigner is used to sign 'temporary certificates' during
 	// asynchronous certificate issuance flows
 	c.localTemporarySigner = generateLocallySignedTemporaryCertificate
+	c.renewalInfo = c.acmeRenewalInfo
 	c.enableSecretOwnerReferences = ctx.CertificateOptions.EnableOwnerRef
 
 	c.cmClient = ctx.CMClient
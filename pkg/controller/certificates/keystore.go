@@ -0,0 +1,345 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	jks "github.com/pavel-v-chernykh/keystore-go"
+	corev1 "k8s.io/api/core/v1"
+	"software.sslmate.com/src/go-pkcs12"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	// jksCertificateAlias is the alias used for the private key entry in JKS
+	// keystores.
+	jksCertificateAlias = "certificate"
+	// jksCAAlias is the alias used for the CA certificate entry in JKS
+	// keystores and truststores.
+	jksCAAlias = "ca"
+)
+
+// keystoreData is the decoded form of the private key, certificate chain and
+// CA certificate that will be stored in additional keystore formats.
+type keystoreData struct {
+	// pk is the DER encoded PKCS#8 private key
+	pk []byte
+	// chain is the certificate chain, starting with the leaf certificate
+	chain []*x509.Certificate
+	// ca is the CA certificate, or nil if no CA certificate is available
+	ca *x509.Certificate
+}
+
+// setKeystoreValues will update the Secret resource 's' with JKS and PKCS#12
+// encoded keystores of the given secretData, if enabled on the Certificate.
+// Keystores that are not enabled, or cannot be built because the secretData
+// does not contain both a private key and certificate, will be removed.
+// Existing keystores are only re-encoded if their contents are out of date, as
+// both formats embed random salts and would otherwise change on every call.
+// setKeystoreValues will NOT actually update the resource in the apiserver.
+func (c *certificateRequestManager) setKeystoreValues(crt *cmapi.Certificate, s *corev1.Secret, data secretData) error {
+	var ks keystoreData
+	complete := len(data.pk) > 0 && len(data.cert) > 0
+	if complete {
+		var err error
+		ks, err = decodeKeystoreData(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	var jksSpec *cmapi.JKSKeystore
+	var pkcs12Spec *cmapi.PKCS12Keystore
+	if crt.Spec.Keystores != nil {
+		jksSpec = crt.Spec.Keystores.JKS
+		pkcs12Spec = crt.Spec.Keystores.PKCS12
+	}
+
+	if complete && jksSpec != nil && jksSpec.Create {
+		password, err := c.keystorePassword(crt.Namespace, jksSpec.PasswordSecretRef)
+		if err != nil {
+			return err
+		}
+
+		if !jksKeystoreUpToDate(s.Data[cmapi.JKSSecretKey], password, ks) {
+			keystore, err := encodeJKSKeystore(password, ks)
+			if err != nil {
+				return err
+			}
+			s.Data[cmapi.JKSSecretKey] = keystore
+		}
+
+		if ks.ca == nil {
+			delete(s.Data, cmapi.JKSTruststoreKey)
+		} else if !jksTruststoreUpToDate(s.Data[cmapi.JKSTruststoreKey], password, ks.ca) {
+			truststore, err := encodeJKSTruststore(password, ks.ca)
+			if err != nil {
+				return err
+			}
+			s.Data[cmapi.JKSTruststoreKey] = truststore
+		}
+	} else {
+		delete(s.Data, cmapi.JKSSecretKey)
+		delete(s.Data, cmapi.JKSTruststoreKey)
+	}
+
+	if complete && pkcs12Spec != nil && pkcs12Spec.Create {
+		password, err := c.keystorePassword(crt.Namespace, pkcs12Spec.PasswordSecretRef)
+		if err != nil {
+			return err
+		}
+
+		if !pkcs12KeystoreUpToDate(s.Data[cmapi.PKCS12SecretKey], string(password), ks) {
+			keystore, err := encodePKCS12Keystore(string(password), ks)
+			if err != nil {
+				return err
+			}
+			s.Data[cmapi.PKCS12SecretKey] = keystore
+		}
+
+		if ks.ca == nil {
+			delete(s.Data, cmapi.PKCS12TruststoreKey)
+		} else if !pkcs12TruststoreUpToDate(s.Data[cmapi.PKCS12TruststoreKey], string(password), ks.ca) {
+			truststore, err := encodePKCS12Truststore(string(password), ks.ca)
+			if err != nil {
+				return err
+			}
+			s.Data[cmapi.PKCS12TruststoreKey] = truststore
+		}
+	} else {
+		delete(s.Data, cmapi.PKCS12SecretKey)
+		delete(s.Data, cmapi.PKCS12TruststoreKey)
+	}
+
+	return nil
+}
+
+// keystorePassword reads the keystore password referenced by 'ref' from a
+// Secret resource in the given namespace.
+func (c *certificateRequestManager) keystorePassword(namespace string, ref cmmeta.SecretKeySelector) ([]byte, error) {
+	secret, err := c.secretLister.Secrets(namespace).Get(ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keystore password Secret %q: %v", ref.Name, err)
+	}
+
+	password, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("no data for key %q in keystore password Secret %q", ref.Key, ref.Name)
+	}
+
+	return password, nil
+}
+
+func decodeKeystoreData(data secretData) (keystoreData, error) {
+	key, err := pki.DecodePrivateKeyBytes(data.pk)
+	if err != nil {
+		return keystoreData{}, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return keystoreData{}, err
+	}
+
+	chain, err := pki.DecodeX509CertificateChainBytes(data.cert)
+	if err != nil {
+		return keystoreData{}, err
+	}
+
+	ks := keystoreData{pk: der, chain: chain}
+	if len(data.ca) > 0 {
+		ks.ca, err = pki.DecodeX509CertificateBytes(data.ca)
+		if err != nil {
+			return keystoreData{}, err
+		}
+	}
+
+	return ks, nil
+}
+
+// caCertificates returns the certificates that should be stored alongside the
+// leaf certificate in a keystore, i.e. any intermediates followed by the CA.
+func (ks keystoreData) caCertificates() []*x509.Certificate {
+	var cas []*x509.Certificate
+	cas = append(cas, ks.chain[1:]...)
+	if ks.ca != nil {
+		cas = append(cas, ks.ca)
+	}
+	return cas
+}
+
+func encodePKCS12Keystore(password string, ks keystoreData) ([]byte, error) {
+	key, err := x509.ParsePKCS8PrivateKey(ks.pk)
+	if err != nil {
+		return nil, err
+	}
+
+	return pkcs12.Encode(rand.Reader, key, ks.chain[0], ks.caCertificates(), password)
+}
+
+func encodePKCS12Truststore(password string, ca *x509.Certificate) ([]byte, error) {
+	return pkcs12.EncodeTrustStore(rand.Reader, []*x509.Certificate{ca}, password)
+}
+
+func pkcs12KeystoreUpToDate(existing []byte, password string, ks keystoreData) bool {
+	if len(existing) == 0 {
+		return false
+	}
+
+	key, cert, cas, err := pkcs12.DecodeChain(existing, password)
+	if err != nil {
+		return false
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil || !bytes.Equal(der, ks.pk) {
+		return false
+	}
+
+	return certificatesEqual(append([]*x509.Certificate{cert}, cas...), append([]*x509.Certificate{ks.chain[0]}, ks.caCertificates()...))
+}
+
+func pkcs12TruststoreUpToDate(existing []byte, password string, ca *x509.Certificate) bool {
+	if len(existing) == 0 {
+		return false
+	}
+
+	certs, err := pkcs12.DecodeTrustStore(existing, password)
+	if err != nil {
+		return false
+	}
+
+	return certificatesEqual(certs, []*x509.Certificate{ca})
+}
+
+func encodeJKSKeystore(password []byte, ks keystoreData) ([]byte, error) {
+	now := time.Now()
+
+	var chain []jks.Certificate
+	for _, cert := range ks.chain {
+		chain = append(chain, jks.Certificate{Type: "X509", Content: cert.Raw})
+	}
+
+	keystore := jks.KeyStore{
+		jksCertificateAlias: &jks.PrivateKeyEntry{
+			Entry:     jks.Entry{CreationDate: now},
+			PrivKey:   ks.pk,
+			CertChain: chain,
+		},
+	}
+	if ks.ca != nil {
+		keystore[jksCAAlias] = &jks.TrustedCertificateEntry{
+			Entry:       jks.Entry{CreationDate: now},
+			Certificate: jks.Certificate{Type: "X509", Content: ks.ca.Raw},
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := jks.Encode(buf, keystore, password); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encodeJKSTruststore(password []byte, ca *x509.Certificate) ([]byte, error) {
+	truststore := jks.KeyStore{
+		jksCAAlias: &jks.TrustedCertificateEntry{
+			Entry:       jks.Entry{CreationDate: time.Now()},
+			Certificate: jks.Certificate{Type: "X509", Content: ca.Raw},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := jks.Encode(buf, truststore, password); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func jksKeystoreUpToDate(existing, password []byte, ks keystoreData) bool {
+	if len(existing) == 0 {
+		return false
+	}
+
+	keystore, err := jks.Decode(bytes.NewReader(existing), password)
+	if err != nil {
+		return false
+	}
+
+	expectedEntries := 1
+	if ks.ca != nil {
+		expectedEntries++
+		if !jksTrustedCertificateEqual(keystore[jksCAAlias], ks.ca) {
+			return false
+		}
+	}
+	if len(keystore) != expectedEntries {
+		return false
+	}
+
+	entry, ok := keystore[jksCertificateAlias].(*jks.PrivateKeyEntry)
+	if !ok || !bytes.Equal(entry.PrivKey, ks.pk) || len(entry.CertChain) != len(ks.chain) {
+		return false
+	}
+	for i, cert := range entry.CertChain {
+		if !bytes.Equal(cert.Content, ks.chain[i].Raw) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func jksTruststoreUpToDate(existing, password []byte, ca *x509.Certificate) bool {
+	if len(existing) == 0 {
+		return false
+	}
+
+	truststore, err := jks.Decode(bytes.NewReader(existing), password)
+	if err != nil {
+		return false
+	}
+
+	return len(truststore) == 1 && jksTrustedCertificateEqual(truststore[jksCAAlias], ca)
+}
+
+func jksTrustedCertificateEqual(entry interface{}, cert *x509.Certificate) bool {
+	trusted, ok := entry.(*jks.TrustedCertificateEntry)
+	return ok && bytes.Equal(trusted.Certificate.Content, cert.Raw)
+}
+
+func certificatesEqual(a, b []*x509.Certificate) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
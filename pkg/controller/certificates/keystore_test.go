@@ -0,0 +1,182 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"bytes"
+	"crypto/x509"
+	"testing"
+
+	jks "github.com/pavel-v-chernykh/keystore-go"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"software.sslmate.com/src/go-pkcs12"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestSetKeystoreValues(t *testing.T) {
+	baseCert := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test", Kind: "something", Group: "not-empty"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	bundle := mustCreateCryptoBundle(t, baseCert)
+	caBundle := mustCreateCryptoBundle(t, gen.CertificateFrom(baseCert, gen.SetCertificateIsCA(true)))
+
+	passwordSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "keystore-password"},
+		Data:       map[string][]byte{"password": []byte("changeit")},
+	}
+	passwordRef := cmmeta.SecretKeySelector{
+		LocalObjectReference: cmmeta.LocalObjectReference{Name: "keystore-password"},
+		Key:                  "password",
+	}
+	keystoreCert := gen.CertificateFrom(baseCert, func(crt *cmapi.Certificate) {
+		crt.Spec.Keystores = &cmapi.CertificateKeystores{
+			JKS:    &cmapi.JKSKeystore{Create: true, PasswordSecretRef: passwordRef},
+			PKCS12: &cmapi.PKCS12Keystore{Create: true, PasswordSecretRef: passwordRef},
+		}
+	})
+	data := secretData{pk: bundle.privateKeyBytes, cert: bundle.certBytes, ca: caBundle.certBytes}
+
+	builder := &testpkg.Builder{
+		T:           t,
+		KubeObjects: []runtime.Object{passwordSecret},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	testManager := &certificateRequestManager{}
+	testManager.Register(builder.Context)
+	builder.Start()
+
+	t.Run("creates keystores and truststores", func(t *testing.T) {
+		s := &corev1.Secret{Data: map[string][]byte{}}
+		if err := testManager.setKeystoreValues(keystoreCert, s, data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		key, cert, cas, err := pkcs12.DecodeChain(s.Data[cmapi.PKCS12SecretKey], "changeit")
+		if err != nil {
+			t.Fatalf("failed to decode PKCS#12 keystore: %v", err)
+		}
+		der, _ := x509.MarshalPKCS8PrivateKey(key)
+		expectedDER, _ := x509.MarshalPKCS8PrivateKey(bundle.privateKey)
+		if !bytes.Equal(der, expectedDER) {
+			t.Errorf("PKCS#12 keystore contains unexpected private key")
+		}
+		if !cert.Equal(bundle.cert) {
+			t.Errorf("PKCS#12 keystore contains unexpected certificate")
+		}
+		if len(cas) != 1 || !cas[0].Equal(caBundle.cert) {
+			t.Errorf("PKCS#12 keystore contains unexpected CA certificates")
+		}
+
+		truststore, err := pkcs12.DecodeTrustStore(s.Data[cmapi.PKCS12TruststoreKey], "changeit")
+		if err != nil {
+			t.Fatalf("failed to decode PKCS#12 truststore: %v", err)
+		}
+		if len(truststore) != 1 || !truststore[0].Equal(caBundle.cert) {
+			t.Errorf("PKCS#12 truststore contains unexpected certificates")
+		}
+
+		keystore, err := jks.Decode(bytes.NewReader(s.Data[cmapi.JKSSecretKey]), []byte("changeit"))
+		if err != nil {
+			t.Fatalf("failed to decode JKS keystore: %v", err)
+		}
+		entry, ok := keystore[jksCertificateAlias].(*jks.PrivateKeyEntry)
+		if !ok {
+			t.Fatalf("JKS keystore does not contain a private key entry")
+		}
+		if !bytes.Equal(entry.PrivKey, expectedDER) {
+			t.Errorf("JKS keystore contains unexpected private key")
+		}
+		if len(entry.CertChain) != 1 || !bytes.Equal(entry.CertChain[0].Content, bundle.cert.Raw) {
+			t.Errorf("JKS keystore contains unexpected certificate chain")
+		}
+		if !jksTrustedCertificateEqual(keystore[jksCAAlias], caBundle.cert) {
+			t.Errorf("JKS keystore contains unexpected CA certificate")
+		}
+
+		if !jksTruststoreUpToDate(s.Data[cmapi.JKSTruststoreKey], []byte("changeit"), caBundle.cert) {
+			t.Errorf("JKS truststore contains unexpected certificates")
+		}
+	})
+
+	t.Run("does not re-encode up to date keystores", func(t *testing.T) {
+		s := &corev1.Secret{Data: map[string][]byte{}}
+		if err := testManager.setKeystoreValues(keystoreCert, s, data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		existing := s.DeepCopy()
+
+		if err := testManager.setKeystoreValues(keystoreCert, s, data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, k := range []string{cmapi.JKSSecretKey, cmapi.JKSTruststoreKey, cmapi.PKCS12SecretKey, cmapi.PKCS12TruststoreKey} {
+			if !bytes.Equal(existing.Data[k], s.Data[k]) {
+				t.Errorf("expected %q to not be changed", k)
+			}
+		}
+	})
+
+	t.Run("removes keystores when disabled", func(t *testing.T) {
+		s := &corev1.Secret{Data: map[string][]byte{}}
+		if err := testManager.setKeystoreValues(keystoreCert, s, data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := testManager.setKeystoreValues(baseCert, s, data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(s.Data) != 0 {
+			t.Errorf("expected all keystores to be removed, got keys: %v", s.Data)
+		}
+	})
+
+	t.Run("omits truststores if no CA is available", func(t *testing.T) {
+		s := &corev1.Secret{Data: map[string][]byte{}}
+		if err := testManager.setKeystoreValues(keystoreCert, s, secretData{pk: data.pk, cert: data.cert}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := s.Data[cmapi.JKSTruststoreKey]; ok {
+			t.Errorf("expected JKS truststore to not be set")
+		}
+		if _, ok := s.Data[cmapi.PKCS12TruststoreKey]; ok {
+			t.Errorf("expected PKCS#12 truststore to not be set")
+		}
+		if len(s.Data[cmapi.JKSSecretKey]) == 0 || len(s.Data[cmapi.PKCS12SecretKey]) == 0 {
+			t.Errorf("expected keystores to be set")
+		}
+	})
+
+	t.Run("errors if the password Secret does not exist", func(t *testing.T) {
+		crt := keystoreCert.DeepCopy()
+		crt.Spec.Keystores.JKS.PasswordSecretRef.Name = "does-not-exist"
+		s := &corev1.Secret{Data: map[string][]byte{}}
+		if err := testManager.setKeystoreValues(crt, s, data); err == nil {
+			t.Errorf("expected an error but got none")
+		}
+	})
+
+	builder.CheckAndFinish()
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"bytes"
+	"encoding/pem"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+)
+
+// setAdditionalOutputFormatValues will update the Secret resource 's' with
+// the additional output formats enabled on the Certificate, built from the
+// given secretData.
+// Output formats that are not enabled, or cannot be built because the
+// secretData does not contain the required private key or certificate, will
+// be removed.
+// The Data field of 's' must be non-nil.
+func setAdditionalOutputFormatValues(crt *cmapi.Certificate, s *corev1.Secret, data secretData) error {
+	var der, combinedPEM bool
+	for _, f := range crt.Spec.AdditionalOutputFormats {
+		switch f.Type {
+		case cmapi.CertificateOutputFormatDER:
+			der = true
+		case cmapi.CertificateOutputFormatCombinedPEM:
+			combinedPEM = true
+		}
+	}
+
+	if der && len(data.pk) > 0 {
+		block, _ := pem.Decode(data.pk)
+		if block == nil {
+			return fmt.Errorf("failed to decode private key PEM data for %s output format", cmapi.CertificateOutputFormatDER)
+		}
+		s.Data[cmapi.CertificateOutputFormatDERKey] = block.Bytes
+	} else {
+		delete(s.Data, cmapi.CertificateOutputFormatDERKey)
+	}
+
+	if combinedPEM && len(data.pk) > 0 && len(data.cert) > 0 {
+		s.Data[cmapi.CertificateOutputFormatCombinedPEMKey] = combinePEM(data.pk, data.cert)
+	} else {
+		delete(s.Data, cmapi.CertificateOutputFormatCombinedPEMKey)
+	}
+
+	return nil
+}
+
+// combinePEM concatenates the PEM encoded private key and certificate chain,
+// ensuring the private key data is terminated by a newline.
+func combinePEM(pk, chain []byte) []byte {
+	pk = bytes.TrimRight(pk, "\n")
+	combined := make([]byte, 0, len(pk)+1+len(chain))
+	combined = append(combined, pk...)
+	combined = append(combined, '\n')
+	return append(combined, chain...)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"bytes"
+	"encoding/pem"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestSetAdditionalOutputFormatValues(t *testing.T) {
+	baseCert := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test", Kind: "something", Group: "not-empty"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	bundle := mustCreateCryptoBundle(t, baseCert)
+	formatsCert := gen.CertificateFrom(baseCert, func(crt *cmapi.Certificate) {
+		crt.Spec.AdditionalOutputFormats = []cmapi.CertificateAdditionalOutputFormat{
+			{Type: cmapi.CertificateOutputFormatDER},
+			{Type: cmapi.CertificateOutputFormatCombinedPEM},
+		}
+	})
+	data := secretData{pk: bundle.privateKeyBytes, cert: bundle.certBytes}
+
+	t.Run("writes DER and combined PEM output formats", func(t *testing.T) {
+		s := &corev1.Secret{Data: map[string][]byte{}}
+		if err := setAdditionalOutputFormatValues(formatsCert, s, data); err != nil {
+			t.Fatal(err)
+		}
+
+		block, _ := pem.Decode(bundle.privateKeyBytes)
+		if !bytes.Equal(s.Data[cmapi.CertificateOutputFormatDERKey], block.Bytes) {
+			t.Errorf("expected %s to contain the DER encoded private key", cmapi.CertificateOutputFormatDERKey)
+		}
+
+		combined := s.Data[cmapi.CertificateOutputFormatCombinedPEMKey]
+		keyBlock, rest := pem.Decode(combined)
+		if keyBlock == nil || !bytes.Equal(keyBlock.Bytes, block.Bytes) {
+			t.Fatalf("expected %s to start with the private key", cmapi.CertificateOutputFormatCombinedPEMKey)
+		}
+		certBlock, _ := pem.Decode(bundle.certBytes)
+		gotCertBlock, _ := pem.Decode(rest)
+		if gotCertBlock == nil || !bytes.Equal(gotCertBlock.Bytes, certBlock.Bytes) {
+			t.Errorf("expected %s to contain the certificate after the private key", cmapi.CertificateOutputFormatCombinedPEMKey)
+		}
+	})
+
+	t.Run("removes output formats that are no longer enabled", func(t *testing.T) {
+		s := &corev1.Secret{Data: map[string][]byte{}}
+		if err := setAdditionalOutputFormatValues(formatsCert, s, data); err != nil {
+			t.Fatal(err)
+		}
+		if err := setAdditionalOutputFormatValues(baseCert, s, data); err != nil {
+			t.Fatal(err)
+		}
+		for _, k := range []string{cmapi.CertificateOutputFormatDERKey, cmapi.CertificateOutputFormatCombinedPEMKey} {
+			if _, ok := s.Data[k]; ok {
+				t.Errorf("expected %s to be removed", k)
+			}
+		}
+	})
+
+	t.Run("does not write combined PEM without a certificate", func(t *testing.T) {
+		s := &corev1.Secret{Data: map[string][]byte{}}
+		if err := setAdditionalOutputFormatValues(formatsCert, s, secretData{pk: bundle.privateKeyBytes}); err != nil {
+			t.Fatal(err)
+		}
+		if len(s.Data[cmapi.CertificateOutputFormatDERKey]) == 0 {
+			t.Errorf("expected %s to be set", cmapi.CertificateOutputFormatDERKey)
+		}
+		if _, ok := s.Data[cmapi.CertificateOutputFormatCombinedPEMKey]; ok {
+			t.Errorf("expected %s to not be set", cmapi.CertificateOutputFormatCombinedPEMKey)
+		}
+	})
+}
+
+func TestCombinePEM(t *testing.T) {
+	for _, pk := range []string{"KEY", "KEY\n", "KEY\n\n"} {
+		if got := string(combinePEM([]byte(pk), []byte("CERT\n"))); got != "KEY\nCERT\n" {
+			t.Errorf("unexpected combined PEM for %q: %q", pk, got)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+// renewalInfoFn returns the renewal information suggested by the ACME server
+// that issued the given certificate, or nil if the Certificate's issuer is
+// not an ACME issuer. If the ACME server does not support renewal
+// information, acmecl.ErrRenewalInfoNotSupported is returned.
+type renewalInfoFn func(ctx context.Context, crt *cmapi.Certificate, cert *x509.Certificate) (*acmecl.RenewalInfo, error)
+
+func (c *certificateRequestManager) acmeRenewalInfo(ctx context.Context, crt *cmapi.Certificate, cert *x509.Certificate) (*acmecl.RenewalInfo, error) {
+	if !(crt.Spec.IssuerRef.Group == "" || crt.Spec.IssuerRef.Group == certmanager.GroupName) {
+		return nil, nil
+	}
+
+	iss, err := c.issuerHelper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if iss.GetSpec().ACME == nil {
+		return nil, nil
+	}
+
+	cl, err := c.acmeHelper.ClientForIssuer(iss)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.GetRenewalInfo(ctx, cert)
+}
+
+// updateRenewalWindow records the renewal window suggested by the ACME
+// server for the given certificate on the Certificate's status, fetching it
+// again once the server's Retry-After time has passed. Failing to fetch
+// renewal information does not prevent the certificate from being renewed,
+// so errors are only logged, and the check is retried after
+// acmecl.DefaultRenewalInfoRetryAfter.
+func (c *certificateRequestManager) updateRenewalWindow(ctx context.Context, crt *cmapi.Certificate, cert *x509.Certificate) {
+	log := logf.FromContext(ctx)
+
+	serial := fmt.Sprintf("%X", cert.SerialNumber)
+	window := crt.Status.SuggestedRenewalWindow
+	if window != nil && window.SerialNumber != serial {
+		crt.Status.SuggestedRenewalWindow = nil
+		window = nil
+	}
+
+	now := c.clock.Now()
+	if window != nil && window.NextCheckTime != nil && now.Before(window.NextCheckTime.Time) {
+		return
+	}
+
+	info, err := c.renewalInfo(ctx, crt, cert)
+	if err == acmecl.ErrRenewalInfoNotSupported {
+		log.V(logf.DebugLevel).Info("ACME server does not support renewal information")
+	} else if err != nil {
+		log.Error(err, "failed to fetch renewal information from ACME server")
+	}
+	if err != nil {
+		// Record when renewal information should next be requested, keeping
+		// any window already suggested for the certificate, so that the ACME
+		// server is not queried again on every sync.
+		if window == nil {
+			window = &cmapi.CertificateRenewalWindow{SerialNumber: serial}
+		}
+		nextCheckTime := metav1.NewTime(now.Add(acmecl.DefaultRenewalInfoRetryAfter))
+		window.NextCheckTime = &nextCheckTime
+		crt.Status.SuggestedRenewalWindow = window
+		return
+	}
+	if info == nil {
+		return
+	}
+
+	log.V(logf.DebugLevel).Info("fetched renewal window from ACME server", "window_start", info.SuggestedWindow.Start, "window_end", info.SuggestedWindow.End)
+
+	nextCheckTime := metav1.NewTime(now.Add(info.RetryAfter))
+	crt.Status.SuggestedRenewalWindow = &cmapi.CertificateRenewalWindow{
+		SerialNumber:   serial,
+		Start:          &metav1.Time{Time: info.SuggestedWindow.Start},
+		End:            &metav1.Time{Time: info.SuggestedWindow.End},
+		ExplanationURL: info.ExplanationURL,
+		NextCheckTime:  &nextCheckTime,
+	}
+}
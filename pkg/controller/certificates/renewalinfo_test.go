@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestUpdateRenewalWindow(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	windowStart := now.Add(time.Hour * 24)
+	windowEnd := now.Add(time.Hour * 48)
+
+	cert := &x509.Certificate{SerialNumber: big.NewInt(0x1a2b)}
+
+	window := func(serial string, nextCheck time.Time) *cmapi.CertificateRenewalWindow {
+		next := metav1.NewTime(nextCheck)
+		return &cmapi.CertificateRenewalWindow{
+			SerialNumber:   serial,
+			Start:          &metav1.Time{Time: windowStart},
+			End:            &metav1.Time{Time: windowEnd},
+			ExplanationURL: "https://example.com/incident",
+			NextCheckTime:  &next,
+		}
+	}
+
+	nextCheck := func(serial string, nextCheck time.Time) *cmapi.CertificateRenewalWindow {
+		next := metav1.NewTime(nextCheck)
+		return &cmapi.CertificateRenewalWindow{
+			SerialNumber:  serial,
+			NextCheckTime: &next,
+		}
+	}
+
+	info := &acmecl.RenewalInfo{
+		SuggestedWindow: acmecl.RenewalWindow{Start: windowStart, End: windowEnd},
+		ExplanationURL:  "https://example.com/incident",
+		RetryAfter:      time.Hour * 6,
+	}
+
+	tests := map[string]struct {
+		existing *cmapi.CertificateRenewalWindow
+		info     *acmecl.RenewalInfo
+		err      error
+
+		expectedFetch  bool
+		expectedWindow *cmapi.CertificateRenewalWindow
+	}{
+		"a renewal window should be fetched for a certificate without one": {
+			info:           info,
+			expectedFetch:  true,
+			expectedWindow: window("1A2B", now.Add(time.Hour*6)),
+		},
+		"a renewal window should not be fetched again before the next check time": {
+			existing:       window("1A2B", now.Add(time.Hour)),
+			expectedWindow: window("1A2B", now.Add(time.Hour)),
+		},
+		"a renewal window should be fetched again after the next check time": {
+			existing:       window("1A2B", now.Add(-time.Hour)),
+			info:           info,
+			expectedFetch:  true,
+			expectedWindow: window("1A2B", now.Add(time.Hour*6)),
+		},
+		"a renewal window for another certificate should be replaced": {
+			existing:       window("3C4D", now.Add(time.Hour)),
+			info:           info,
+			expectedFetch:  true,
+			expectedWindow: window("1A2B", now.Add(time.Hour*6)),
+		},
+		"a renewal window for another certificate should be removed if the issuer does not suggest one": {
+			existing:      window("3C4D", now.Add(time.Hour)),
+			expectedFetch: true,
+		},
+		"the existing renewal window should be kept and checked again later if fetching a new one fails": {
+			existing:       window("1A2B", now.Add(-time.Hour)),
+			err:            errors.New("server unavailable"),
+			expectedFetch:  true,
+			expectedWindow: window("1A2B", now.Add(acmecl.DefaultRenewalInfoRetryAfter)),
+		},
+		"a failed check should be recorded so it is retried later": {
+			err:            errors.New("server unavailable"),
+			expectedFetch:  true,
+			expectedWindow: nextCheck("1A2B", now.Add(acmecl.DefaultRenewalInfoRetryAfter)),
+		},
+		"a check against a server that does not support renewal information should be recorded so it is retried later": {
+			existing:       window("3C4D", now.Add(time.Hour)),
+			err:            acmecl.ErrRenewalInfoNotSupported,
+			expectedFetch:  true,
+			expectedWindow: nextCheck("1A2B", now.Add(acmecl.DefaultRenewalInfoRetryAfter)),
+		},
+		"renewal information should not be fetched again before the next check time after a failed check": {
+			existing:       nextCheck("1A2B", now.Add(time.Hour)),
+			expectedWindow: nextCheck("1A2B", now.Add(time.Hour)),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := gen.Certificate("test")
+			crt.Status.SuggestedRenewalWindow = test.existing
+
+			var fetched bool
+			c := &certificateRequestManager{
+				clock: fakeclock.NewFakeClock(now),
+				renewalInfo: func(context.Context, *cmapi.Certificate, *x509.Certificate) (*acmecl.RenewalInfo, error) {
+					fetched = true
+					return test.info, test.err
+				},
+			}
+
+			c.updateRenewalWindow(context.Background(), crt, cert)
+
+			if fetched != test.expectedFetch {
+				t.Errorf("unexpected fetch of renewal information, exp=%t got=%t", test.expectedFetch, fetched)
+			}
+			if !reflect.DeepEqual(crt.Status.SuggestedRenewalWindow, test.expectedWindow) {
+				t.Errorf("unexpected renewal window, exp=%+v got=%+v", test.expectedWindow, crt.Status.SuggestedRenewalWindow)
+			}
+		})
+	}
+}
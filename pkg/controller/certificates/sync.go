@@ -17,13 +17,17 @@ limitations under the License.
 package certificates
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -95,6 +99,21 @@ func (c *certificateRequestManager) updateCertificateStatus(ctx context.Context,
 	if len(reqs) == 1 {
 		req = reqs[0]
 	}
+	// If a revision history is retained, the CertificateRequests for previous
+	// revisions are also owned by the Certificate so the request for the next
+	// revision must be found by name.
+	if crt.Spec.RevisionHistoryLimit != nil {
+		req = nil
+		expectedReqName, err := certificateRequestName(crt)
+		if err != nil {
+			return fmt.Errorf("internal error hashing certificate spec: %v", err)
+		}
+		for _, r := range reqs {
+			if r.Name == expectedReqName {
+				req = r
+			}
+		}
+	}
 	var cert *x509.Certificate
 	var certExpired bool
 	if len(certs) > 0 {
@@ -118,9 +137,15 @@ func (c *certificateRequestManager) updateCertificateStatus(ctx context.Context,
 	// begin setting certificate status fields
 	if !matches || isTempCert {
 		crt.Status.NotAfter = nil
+		crt.Status.NotBefore = nil
+		crt.Status.RenewalTime = nil
 	} else {
 		metaNotAfter := metav1.NewTime(cert.NotAfter)
 		crt.Status.NotAfter = &metaNotAfter
+		metaNotBefore := metav1.NewTime(cert.NotBefore)
+		crt.Status.NotBefore = &metaNotBefore
+		metaRenewalTime := metav1.NewTime(c.calculateRenewalTime(ctx, cert, crt))
+		crt.Status.RenewalTime = &metaRenewalTime
 	}
 
 	// Derive & set 'Ready' condition on Certificate resource
@@ -190,12 +215,23 @@ func (c *certificateRequestManager) processCertificate(ctx context.Context, crt
 	dbg := log.V(logf.DebugLevel)
 
 	// The certificate request name is a product of the certificate's spec,
-	// which makes it unique and predictable.
+	// and of its revision if a revision history is retained, which makes it
+	// unique and predictable.
 	// First we compute what we expect it to be.
-	expectedReqName, err := apiutil.ComputeCertificateRequestName(crt)
+	expectedReqName, err := certificateRequestName(crt)
 	if err != nil {
 		return fmt.Errorf("internal error hashing certificate spec: %v", err)
 	}
+	specHash, err := apiutil.ComputeCertificateRequestName(crt)
+	if err != nil {
+		return fmt.Errorf("internal error hashing certificate spec: %v", err)
+	}
+
+	// Failed issuance attempts no longer apply once the spec they were made
+	// for has changed.
+	if resetIssuanceBackoffForChangedSpec(crt, specHash) {
+		log.Info("reset failed issuance attempts as the spec of the Certificate has changed")
+	}
 
 	// Clean up any 'owned' CertificateRequest resources that do not have the
 	// expected name computed above
@@ -237,12 +273,23 @@ func (c *certificateRequestManager) processCertificate(ctx context.Context, crt
 	if err != nil {
 		return err
 	}
-	// If the private key is not 'up to date', we generate a new private key
-	if !validKey {
+	// If the private key is not 'up to date', we generate a new private key.
+	// When private keys are rotated on every issuance, the new private key
+	// will instead be generated for the next CertificateRequest so that the
+	// Secret is never left with a key that does not match its certificate.
+	if !validKey && !certificateRotatesPrivateKey(crt) {
 		log.Info("existing private key does not match requirements specified on Certificate resource, generating new private key")
 		return c.generateAndStorePrivateKey(ctx, crt, existingSecret)
 	}
 
+	// Once the Secret has been observed to contain the next private key, the
+	// Secret storing the next private key is no longer required.
+	// Processing continues once the deletion has been observed.
+	deleted, err := c.cleanupNextPrivateKey(ctx, crt, existingKey)
+	if err != nil || deleted {
+		return err
+	}
+
 	// Attempt to fetch the CertificateRequest with the expected name computed above.
 	dbg.Info("checking for existing CertificateRequest for Certificate")
 	existingReq, err := c.certificateRequestLister.CertificateRequests(crt.Namespace).Get(expectedReqName)
@@ -279,6 +326,11 @@ func (c *certificateRequestManager) processCertificate(ctx context.Context, crt
 			needsIssue = true
 		}
 
+		if !needsIssue && !validKey {
+			dbg.Info("existing private key does not match requirements specified on Certificate resource")
+			needsIssue = true
+		}
+
 		if !needsIssue {
 			dbg.Info("existing certificate does not need re-issuance")
 		} else {
@@ -286,6 +338,41 @@ func (c *certificateRequestManager) processCertificate(ctx context.Context, crt
 		}
 	}
 
+	// If a re-issuance has been manually requested by setting the Issuing
+	// condition, a new certificate must be requested even if the existing one
+	// is still valid.
+	// A CertificateRequest that issued the certificate already stored in the
+	// Secret is deleted, as it would otherwise just store the existing
+	// certificate again, unless it was created for this re-issuance. In that
+	// case the re-issuance has already completed, but removing the Issuing
+	// condition was not persisted, so it is removed now. This is decided from
+	// the stored certificate and the Issuing condition recorded on the
+	// CertificateRequest rather than by comparing timestamps, as the clocks
+	// of the API server and of the client that requested the re-issuance
+	// cannot be compared.
+	if _, ok := issuingConditionTime(crt); ok {
+		issuedExistingCert := existingReq != nil && len(existingReq.Status.Certificate) > 0 && bytes.Equal(existingReq.Status.Certificate, existingCert)
+		switch {
+		case issuedExistingCert && certificateRequestForIssuing(crt, existingReq):
+			log.Info("manually requested re-issuance has already completed")
+			apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+
+		case issuedExistingCert:
+			log.Info("deleting CertificateRequest that issued the existing certificate as re-issuance was manually requested")
+			err := c.cmClient.CertmanagerV1alpha2().CertificateRequests(existingReq.Namespace).Delete(existingReq.Name, nil)
+			if err != nil {
+				return err
+			}
+
+			c.recorder.Eventf(crt, corev1.EventTypeNormal, "ManualRenewal", "Deleted CertificateRequest %q as re-issuance has been manually requested", existingReq.Name)
+			return nil
+
+		case !needsIssue:
+			log.Info("re-issuance has been manually requested, issuing certificate")
+			needsIssue = true
+		}
+	}
+
 	// Exit early if the certificate doesn't need issuing to save extra work
 	if !needsIssue {
 		if existingReq != nil {
@@ -303,6 +390,28 @@ func (c *certificateRequestManager) processCertificate(ctx context.Context, crt
 			log.Info("updated Secret resource metadata as it was out of date")
 		}
 
+		// The revision of the certificate stored in the Secret is normally
+		// recorded when the Secret is updated, but it is recorded here too in
+		// case updating the status of the Certificate failed at the time.
+		if existingReq != nil && existingReq.Name != crt.Status.CertificateRequestName &&
+			apiutil.CertificateRequestReadyReason(existingReq) == cmapi.CertificateRequestReasonIssued &&
+			bytes.Equal(existingReq.Status.Certificate, existingCert) {
+			log.Info("recording revision of the certificate issued by the existing CertificateRequest")
+			recordIssuedRevision(crt, existingReq)
+		}
+
+		// Fetch the renewal window suggested by the ACME server that issued
+		// the certificate, if any, so it is taken into account when
+		// scheduling the renewal below.
+		if cert, err := pki.DecodeX509CertificateBytes(existingCert); err == nil {
+			c.updateRenewalWindow(ctx, crt, cert)
+			if w := crt.Status.SuggestedRenewalWindow; w != nil && w.NextCheckTime != nil {
+				if key, err := keyFunc(crt); err == nil {
+					c.queue.AddAfter(key, w.NextCheckTime.Sub(c.clock.Now()))
+				}
+			}
+		}
+
 		// As the Certificate has been validated as Ready, schedule a renewal
 		// for near the expiry date.
 		scheduleRenewal(ctx, c.secretLister, c.calculateDurationUntilRenew, c.scheduledWorkQueue.Add, crt)
@@ -362,10 +471,29 @@ func (c *certificateRequestManager) processCertificate(ctx context.Context, crt
 		}
 	}
 
+	// requestKey is the private key used to create and validate the
+	// CertificateRequest for this Certificate.
+	// If the private key rotation policy is Always, this will be a newly
+	// generated private key that is only stored in the target Secret once the
+	// certificate has been issued.
+	requestKey := existingKey
+	requestPrivateKey := privateKey
+	if certificateRotatesPrivateKey(crt) {
+		requestKey, err = c.ensureNextPrivateKey(ctx, crt, existingKey)
+		if err != nil {
+			return err
+		}
+
+		requestPrivateKey, err = pki.DecodePrivateKeyBytes(requestKey)
+		if err != nil {
+			return err
+		}
+	}
+
 	if existingReq == nil {
 		// If no existing CertificateRequest resource exists, we must create one
 		log.Info("no existing CertificateRequest resource exists, creating new request...")
-		req, err := c.buildCertificateRequest(log, crt, expectedReqName, existingKey)
+		req, err := c.buildCertificateRequest(log, crt, expectedReqName, requestKey)
 		if err != nil {
 			return err
 		}
@@ -390,7 +518,7 @@ func (c *certificateRequestManager) processCertificate(ctx context.Context, crt
 	}
 
 	// Ensure the stored private key is a 'pair' to the CSR
-	publicKeyMatches, err := pki.PublicKeyMatchesCSR(privateKey.Public(), x509CSR)
+	publicKeyMatches, err := pki.PublicKeyMatchesCSR(requestPrivateKey.Public(), x509CSR)
 	if err != nil {
 		return err
 	}
@@ -399,12 +527,29 @@ func (c *certificateRequestManager) processCertificate(ctx context.Context, crt
 	// CertificateRequest resource, delete the resource as we won't be able to
 	// do anything with the certificate if it is issued
 	if !publicKeyMatches {
+		// when rotating the private key, the existing CertificateRequest is
+		// usually the one that was created for the private key currently
+		// stored in the Secret, which is expected and not a lost key
+		rotatingKey := false
+		if certificateRotatesPrivateKey(crt) {
+			rotatingKey, err = pki.PublicKeyMatchesCSR(privateKey.Public(), x509CSR)
+			if err != nil {
+				return err
+			}
+		}
+
 		log.Info("stored private key is not valid for CSR stored on existing CertificateRequest, recreating CertificateRequest resource")
 		err := c.cmClient.CertmanagerV1alpha2().CertificateRequests(existingReq.Namespace).Delete(existingReq.Name, nil)
 		if err != nil {
 			return err
 		}
 
+		if rotatingKey {
+			c.recorder.Eventf(crt, corev1.EventTypeNormal, "RotatingPrivateKey", "Deleted CertificateRequest %q for the previous private key, creating a new one for the next private key", existingReq.Name)
+			log.Info("deleted existing CertificateRequest as it was created for the previous private key")
+			return nil
+		}
+
 		c.recorder.Eventf(crt, corev1.EventTypeNormal, "PrivateKeyLost", "Lost private key for CertificateRequest %q, deleting old resource", existingReq.Name)
 		log.Info("deleted existing CertificateRequest as the stored private key does not match the CSR")
 		return nil
@@ -425,13 +570,18 @@ func (c *certificateRequestManager) processCertificate(ctx context.Context, crt
 	// Determine the status reason of the CertificateRequest and process accordingly
 	switch reason {
 
-	// If the CertificateRequest exists but has failed then we check the if the
-	// failure time doesn't exist or is over an hour in the past then delete the
-	// request so it can be re-created on the next sync. If the failure time is
-	// less than an hour in the past then schedule this owning Certificate for a
-	// re-sync in an hour.
+	// If the CertificateRequest exists but has failed then the failure is
+	// recorded on the Certificate's status, and the time before it is retried
+	// doubles with every consecutive failure. Once the next retry time has
+	// passed, or if the failure time doesn't exist, delete the request so it
+	// can be re-created on the next sync. Otherwise schedule this owning
+	// Certificate for a re-sync at the next retry time.
 	case cmapi.CertificateRequestReasonFailed:
-		if existingReq.Status.FailureTime == nil || c.clock.Since(existingReq.Status.FailureTime.Time) > time.Hour {
+		if existingReq.Status.FailureTime != nil && recordIssuanceFailure(crt, specHash, *existingReq.Status.FailureTime) {
+			log.Info("recorded failed issuance attempt", "attempts", *crt.Status.FailedIssuanceAttempts, "next_retry_time", crt.Status.NextRetryTime.Time)
+		}
+
+		if existingReq.Status.FailureTime == nil || !c.clock.Now().Before(crt.Status.NextRetryTime.Time) {
 			log.Info("deleting failed certificate request")
 			err := c.cmClient.CertmanagerV1alpha2().CertificateRequests(existingReq.Namespace).Delete(existingReq.Name, nil)
 			if err != nil {
@@ -442,7 +592,8 @@ func (c *certificateRequestManager) processCertificate(ctx context.Context, crt
 			return nil
 		}
 
-		log.Info("the failed existing certificate request failed less than an hour ago, will be scheduled for reprocessing in an hour")
+		retryIn := crt.Status.NextRetryTime.Sub(c.clock.Now())
+		log.Info("the failed existing certificate request will be scheduled for reprocessing at the next retry time", "retry_in", retryIn.String())
 
 		key, err := keyFunc(crt)
 		if err != nil {
@@ -451,7 +602,7 @@ func (c *certificateRequestManager) processCertificate(ctx context.Context, crt
 		}
 
 		// We don't fire an event here as this could be called multiple times in quick succession
-		c.scheduledWorkQueue.Add(key, time.Hour)
+		c.scheduledWorkQueue.Add(key, retryIn)
 		return nil
 
 		// If the CertificateRequest is in a Ready state then we can decode,
@@ -483,11 +634,27 @@ func (c *certificateRequestManager) processCertificate(ctx context.Context, crt
 		// across the status.certificate field into the Secret resource.
 		log.Info("CertificateRequest contains a valid certificate for issuance. Issuing certificate...")
 
-		_, err = c.updateSecretData(ctx, crt, existingSecret, secretData{pk: existingKey, cert: existingReq.Status.Certificate, ca: existingReq.Status.CA})
+		_, err = c.updateSecretData(ctx, crt, existingSecret, secretData{pk: requestKey, cert: existingReq.Status.Certificate, ca: existingReq.Status.CA})
 		if err != nil {
 			return err
 		}
 
+		// Record the revision of the newly issued certificate and the
+		// CertificateRequest that issued it.
+		recordIssuedRevision(crt, existingReq)
+
+		// Issuance succeeded, so any previously failed attempts no longer
+		// delay the next issuance.
+		resetIssuanceBackoff(crt)
+
+		// A manually requested re-issuance is now complete.
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+
+		// The Secret storing the next private key is not deleted until the
+		// updated Secret has been observed. Otherwise, a sync that still sees
+		// the previous private key would generate yet another private key and
+		// discard this CertificateRequest.
+
 		c.recorder.Eventf(crt, corev1.EventTypeNormal, "Issued", "Certificate issued successfully")
 		return nil
 
@@ -527,6 +694,10 @@ func (c *certificateRequestManager) updateSecretData(ctx context.Context, crt *c
 	if err != nil {
 		return false, err
 	}
+	err = c.setKeystoreValues(crt, newSecret, data)
+	if err != nil {
+		return false, err
+	}
 	if reflect.DeepEqual(s, newSecret) {
 		return false, nil
 	}
@@ -635,12 +806,16 @@ func (c *certificateRequestManager) buildCertificateRequest(log logr.Logger, crt
 		return nil, err
 	}
 
-	annotations := make(map[string]string, len(crt.Annotations)+2)
+	annotations := make(map[string]string, len(crt.Annotations)+4)
 	for k, v := range crt.Annotations {
 		annotations[k] = v
 	}
 	annotations[cmapi.CRPrivateKeyAnnotationKey] = crt.Spec.SecretName
 	annotations[cmapi.CertificateNameKey] = crt.Name
+	annotations[cmapi.CertificateRequestRevisionAnnotationKey] = strconv.Itoa(nextRevision(crt))
+	if issuingTime, ok := issuingConditionTime(crt); ok {
+		annotations[cmapi.CertificateRequestIssuingTimeAnnotationKey] = issuingTime
+	}
 
 	cr := &cmapi.CertificateRequest{
 		ObjectMeta: metav1.ObjectMeta{
@@ -668,6 +843,9 @@ func (c *certificateRequestManager) cleanupExistingCertificateRequests(log logr.
 		return err
 	}
 
+	// CertificateRequests that issued a certificate are retained as the
+	// revision history of the Certificate if a history limit is set.
+	var history, remove []*cmapi.CertificateRequest
 	for _, req := range reqs {
 		log := logf.WithRelatedResource(log, req)
 		if req.Name == retain {
@@ -675,6 +853,27 @@ func (c *certificateRequestManager) cleanupExistingCertificateRequests(log logr.
 			continue
 		}
 
+		if crt.Spec.RevisionHistoryLimit != nil && apiutil.CertificateRequestReadyReason(req) == cmapi.CertificateRequestReasonIssued {
+			history = append(history, req)
+			continue
+		}
+
+		remove = append(remove, req)
+	}
+
+	if limit := crt.Spec.RevisionHistoryLimit; limit != nil && len(history) > int(*limit) {
+		// keep the most recent revisions. CertificateRequests without a
+		// revision are treated as the oldest.
+		sort.SliceStable(history, func(i, j int) bool {
+			ri, _ := certificateRequestRevision(history[i])
+			rj, _ := certificateRequestRevision(history[j])
+			return ri > rj
+		})
+		remove = append(remove, history[*limit:]...)
+	}
+
+	for _, req := range remove {
+		log := logf.WithRelatedResource(log, req)
 		err = c.cmClient.CertmanagerV1alpha2().CertificateRequests(req.Namespace).Delete(req.Name, nil)
 		if err != nil {
 			return err
@@ -740,6 +939,12 @@ func validatePrivateKeyUpToDate(log logr.Logger, pk []byte, crt *cmapi.Certifica
 			return false, nil
 		}
 		// TODO: check keySize
+	case cmapi.Ed25519KeyAlgorithm:
+		_, ok := signer.(ed25519.PrivateKey)
+		if !ok {
+			log.Info("expected private key's algorithm to be Ed25519 but it is not")
+			return false, nil
+		}
 	}
 
 	return true, nil
@@ -765,6 +970,112 @@ func (c *certificateRequestManager) generateAndStorePrivateKey(ctx context.Conte
 	return nil
 }
 
+// ensureNextPrivateKey returns the private key that should be used for the
+// next CertificateRequest when the Certificate's private key rotation policy
+// is Always.
+// The private key is persisted in a Secret owned by the Certificate until the
+// certificate has been issued. If no such Secret exists, or the private key
+// stored within it is no longer valid or is already in use by the current
+// certificate, a new private key will be generated and stored.
+func (c *certificateRequestManager) ensureNextPrivateKey(ctx context.Context, crt *cmapi.Certificate, currentKey []byte) ([]byte, error) {
+	log := logf.FromContext(ctx)
+	name := nextPrivateKeySecretName(crt)
+
+	existingSecret, err := c.secretLister.Secrets(crt.Namespace).Get(name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if existingSecret != nil {
+		if !metav1.IsControlledBy(existingSecret, crt) {
+			return nil, fmt.Errorf("refusing to store next private key in Secret %q as it is not owned by the Certificate", name)
+		}
+
+		pk := existingSecret.Data[corev1.TLSPrivateKeyKey]
+		if len(pk) > 0 && !bytes.Equal(pk, currentKey) {
+			validKey, err := validatePrivateKeyUpToDate(log, pk, crt)
+			if err != nil && !errors.IsInvalidData(err) {
+				return nil, err
+			}
+			if err == nil && validKey {
+				return pk, nil
+			}
+		}
+	}
+
+	log.Info("generating new private key for the next CertificateRequest")
+	pk, err := c.generatePrivateKeyBytes(ctx, crt)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingSecret == nil {
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       crt.Namespace,
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(crt, certificateGvk)},
+				Annotations: map[string]string{
+					cmapi.CertificateNameKey: crt.Name,
+				},
+			},
+			Data: map[string][]byte{
+				corev1.TLSPrivateKeyKey: pk,
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+		_, err = c.kubeClient.CoreV1().Secrets(s.Namespace).Create(s)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		s := existingSecret.DeepCopy()
+		s.Data = map[string][]byte{
+			corev1.TLSPrivateKeyKey: pk,
+		}
+		_, err = c.kubeClient.CoreV1().Secrets(s.Namespace).Update(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.recorder.Eventf(crt, corev1.EventTypeNormal, "GeneratedKey", "Generated a new private key for the next CertificateRequest")
+
+	return pk, nil
+}
+
+// cleanupNextPrivateKey deletes the Secret used to store the private key for
+// the next CertificateRequest once the given private key, read from the
+// target Secret, is the same private key. At that point the certificate for
+// the next private key has been issued and its storage has been observed, so
+// it must not be used again for any future CertificateRequest.
+// It returns true if the Secret was deleted.
+func (c *certificateRequestManager) cleanupNextPrivateKey(ctx context.Context, crt *cmapi.Certificate, currentKey []byte) (bool, error) {
+	log := logf.FromContext(ctx)
+	name := nextPrivateKeySecretName(crt)
+
+	s, err := c.secretLister.Secrets(crt.Namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if !metav1.IsControlledBy(s, crt) || !bytes.Equal(s.Data[corev1.TLSPrivateKeyKey], currentKey) {
+		return false, nil
+	}
+
+	err = c.kubeClient.CoreV1().Secrets(crt.Namespace).Delete(name, nil)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+
+	log.Info("deleted Secret storing the next private key as it is now stored in the target Secret")
+
+	return true, nil
+}
+
 type generatePrivateKeyBytesFn func(context.Context, *cmapi.Certificate) ([]byte, error)
 
 func generatePrivateKeyBytesImpl(ctx context.Context, crt *cmapi.Certificate) ([]byte, error) {
@@ -787,7 +1098,8 @@ type secretData struct {
 }
 
 // setSecretValues will update the Secret resource 's' with the data contained
-// in the given secretData.
+// in the given secretData, including any additional output formats enabled on
+// the Certificate.
 // It will update labels and annotations on the Secret resource appropriately.
 // The Secret resource 's' must be non-nil, although may be a resource that does
 // not exist in the Kubernetes apiserver yet.
@@ -805,6 +1117,10 @@ func setSecretValues(ctx context.Context, crt *cmapi.Certificate, s *corev1.Secr
 	s.Data[corev1.TLSCertKey] = data.cert
 	s.Data[cmmeta.TLSCAKey] = data.ca
 
+	if err := setAdditionalOutputFormatValues(crt, s, data); err != nil {
+		return err
+	}
+
 	if s.Annotations == nil {
 		s.Annotations = make(map[string]string)
 	}
@@ -841,5 +1157,74 @@ func setSecretValues(ctx context.Context, crt *cmapi.Certificate, s *corev1.Secr
 		s.Annotations[cmapi.URISANAnnotationKey] = strings.Join(pki.URLsToString(x509Cert.URIs), ",")
 	}
 
+	setSecretTemplateValues(crt, s)
+
 	return nil
 }
+
+const (
+	// secretTemplateLabelsAnnotationKey and
+	// secretTemplateAnnotationsAnnotationKey record the keys that were last
+	// copied from the Certificate's secretTemplate onto the Secret, so that
+	// keys removed from the template can also be removed from the Secret
+	// without touching labels and annotations managed by anything else.
+	secretTemplateLabelsAnnotationKey      = "cert-manager.io/secret-template-labels"
+	secretTemplateAnnotationsAnnotationKey = "cert-manager.io/secret-template-annotations"
+)
+
+// setSecretTemplateValues will copy the labels and annotations defined in the
+// Certificate's secretTemplate onto the Secret resource 's', removing any
+// that were previously copied but are no longer present in the template.
+// The Annotations field of 's' must be non-nil.
+func setSecretTemplateValues(crt *cmapi.Certificate, s *corev1.Secret) {
+	var labels, annotations map[string]string
+	if crt.Spec.SecretTemplate != nil {
+		labels = crt.Spec.SecretTemplate.Labels
+		annotations = crt.Spec.SecretTemplate.Annotations
+	}
+
+	s.Labels = applySecretTemplateKeys(s.Labels, labels, s.Annotations[secretTemplateLabelsAnnotationKey])
+	setSecretTemplateKeysAnnotation(s, secretTemplateLabelsAnnotationKey, labels)
+
+	s.Annotations = applySecretTemplateKeys(s.Annotations, annotations, s.Annotations[secretTemplateAnnotationsAnnotationKey])
+	setSecretTemplateKeysAnnotation(s, secretTemplateAnnotationsAnnotationKey, annotations)
+}
+
+// applySecretTemplateKeys deletes the comma separated list of 'previous' keys
+// from 'current' if they are not present in 'desired', and then sets all
+// values from 'desired'.
+// The map 'current' will only be initialised if there are values to set.
+func applySecretTemplateKeys(current, desired map[string]string, previous string) map[string]string {
+	if len(previous) > 0 {
+		for _, k := range strings.Split(previous, ",") {
+			if _, ok := desired[k]; !ok {
+				delete(current, k)
+			}
+		}
+	}
+
+	for k, v := range desired {
+		if current == nil {
+			current = make(map[string]string)
+		}
+		current[k] = v
+	}
+
+	return current
+}
+
+// setSecretTemplateKeysAnnotation records the keys of 'values' on the Secret
+// using the given annotation, or removes the annotation if there are none.
+func setSecretTemplateKeysAnnotation(s *corev1.Secret, annotation string, values map[string]string) {
+	if len(values) == 0 {
+		delete(s.Annotations, annotation)
+		return
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	s.Annotations[annotation] = strings.Join(keys, ",")
+}
@@ -36,6 +36,7 @@ import (
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	"github.com/jetstack/cert-manager/pkg/controller"
 	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
 	"github.com/jetstack/cert-manager/test/unit/gen"
 )
@@ -115,6 +116,7 @@ func createCryptoBundle(crt *cmapi.Certificate) (*cryptoBundle, error) {
 	}
 	annotations[cmapi.CRPrivateKeyAnnotationKey] = crt.Spec.SecretName
 	annotations[cmapi.CertificateNameKey] = crt.Name
+	annotations[cmapi.CertificateRequestRevisionAnnotationKey] = "1"
 	certificateRequest := &cmapi.CertificateRequest{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            reqName,
@@ -298,6 +300,21 @@ func certificateNotAfter(b []byte) time.Time {
 	return cert.NotAfter
 }
 
+// setCertificateStatusTimes sets the status fields of a Certificate that are
+// derived from the given certificate when it is renewed renewBefore its
+// expiry.
+func setCertificateStatusTimes(b []byte, renewBefore time.Duration) gen.CertificateModifier {
+	cert, err := pki.DecodeX509CertificateBytes(b)
+	if err != nil {
+		panic("failed to decode certificate: " + err.Error())
+	}
+	return func(crt *cmapi.Certificate) {
+		gen.SetCertificateNotAfter(metav1.NewTime(cert.NotAfter))(crt)
+		gen.SetCertificateNotBefore(metav1.NewTime(cert.NotBefore))(crt)
+		gen.SetCertificateRenewalTime(metav1.NewTime(cert.NotAfter.Add(-renewBefore)))(crt)
+	}
+}
+
 func testGeneratePrivateKeyBytesFn(b []byte) generatePrivateKeyBytesFn {
 	return func(context.Context, *cmapi.Certificate) ([]byte, error) {
 		return b, nil
@@ -350,8 +367,9 @@ func TestBuildCertificateRequest(t *testing.T) {
 			expectedErr: false,
 
 			expectedCertificateRequestAnnotations: map[string]string{
-				cmapi.CRPrivateKeyAnnotationKey: baseCert.Spec.SecretName,
-				cmapi.CertificateNameKey:        baseCert.Name,
+				cmapi.CRPrivateKeyAnnotationKey:               baseCert.Spec.SecretName,
+				cmapi.CertificateNameKey:                      baseCert.Name,
+				cmapi.CertificateRequestRevisionAnnotationKey: "1",
 			},
 		},
 	}
@@ -395,6 +413,78 @@ func TestProcessCertificate(t *testing.T) {
 		gen.SetCertificateDNSNames("example.com"),
 		gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm),
 	))
+	exampleRotateBundle := mustCreateCryptoBundle(t, gen.CertificateFrom(baseCert,
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificatePrivateKeyRotationPolicy(cmapi.RotationPolicyAlways),
+	))
+	manualRenewalTime := metav1.NewTime(time.Now())
+	exampleManualRenewalCertificate := gen.CertificateFrom(exampleBundle1.certificate,
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionIssuing,
+			Status:             cmmeta.ConditionTrue,
+			LastTransitionTime: &manualRenewalTime,
+		}),
+	)
+	exampleManualRenewalCert := exampleBundle1.generateTestCertificate(exampleBundle1.certificate, nil)
+	exampleManualRenewalSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: gen.DefaultTestNamespace,
+			Name:      "output",
+			Annotations: map[string]string{
+				"custom-annotation":           "value",
+				cmapi.CertificateNameKey:      "test",
+				cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+				cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+				cmapi.IPSANAnnotationKey:      "",
+				cmapi.AltNamesAnnotationKey:   "example.com",
+				cmapi.CommonNameAnnotationKey: "",
+				cmapi.URISANAnnotationKey:     "",
+			},
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       exampleManualRenewalCert,
+			corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+			cmmeta.TLSCAKey:         nil,
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+	exampleKeyCompromiseCertificate := gen.CertificateFrom(exampleBundle1.certificate,
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionIssuing,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             cmapi.CertificateIssuingReasonKeyCompromise,
+			LastTransitionTime: &manualRenewalTime,
+		}),
+	)
+	exampleKeyCompromiseBundle := mustCreateCryptoBundle(t, exampleBundle1.certificate)
+	exampleKeyCompromiseNextKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       gen.DefaultTestNamespace,
+			Name:            "test-next-private-key",
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(exampleKeyCompromiseCertificate, certificateGvk)},
+			Annotations: map[string]string{
+				cmapi.CertificateNameKey: "test",
+			},
+		},
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: exampleKeyCompromiseBundle.privateKeyBytes,
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	exampleRotateNextKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       gen.DefaultTestNamespace,
+			Name:            "test-next-private-key",
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(exampleRotateBundle.certificate, certificateGvk)},
+			Annotations: map[string]string{
+				cmapi.CertificateNameKey: "test",
+			},
+		},
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: exampleRotateBundle.privateKeyBytes,
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
 
 	tests := map[string]testT{
 		"generate a private key and create a new secret if one does not exist": {
@@ -650,49 +740,663 @@ func TestProcessCertificate(t *testing.T) {
 					testpkg.NewAction(coretesting.NewDeleteAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						gen.DefaultTestNamespace,
-						"not-expected-name",
-					)),
-					testpkg.NewAction(coretesting.NewCreateAction(
-						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						gen.DefaultTestNamespace,
-						exampleBundle1.certificateRequest,
+						"not-expected-name",
+					)),
+					testpkg.NewAction(coretesting.NewCreateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						exampleBundle1.certificateRequest,
+					)),
+				},
+				ExpectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-850937773"`},
+			},
+		},
+		"do nothing and wait if an up to date certificaterequest resource exists and is not Ready": {
+			certificate: exampleBundle1.certificate,
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: gen.DefaultTestNamespace,
+							Name:      "output",
+							Annotations: map[string]string{
+								"custom-annotation":           "value",
+								cmapi.CertificateNameKey:      "test",
+								cmapi.IssuerKindAnnotationKey: "Issuer",
+								cmapi.IssuerNameAnnotationKey: "test",
+							},
+						},
+						Data: map[string][]byte{
+							corev1.TLSCertKey:       nil,
+							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+							cmmeta.TLSCAKey:         nil,
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleBundle1.certificate,
+					exampleBundle1.certificateRequest,
+				},
+			},
+		},
+		"create a new CertificateRequest if existing Certificate expires soon": {
+			certificate: exampleBundle1.certificate,
+			generateCSR: testGenerateCSRFn(exampleBundle1.csrBytes),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: gen.DefaultTestNamespace,
+							Name:      "output",
+							Annotations: map[string]string{
+								"custom-annotation":           "value",
+								cmapi.CertificateNameKey:      "test",
+								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+								cmapi.IPSANAnnotationKey:      "",
+								cmapi.AltNamesAnnotationKey:   "example.com",
+								cmapi.CommonNameAnnotationKey: "",
+								cmapi.URISANAnnotationKey:     "",
+							},
+						},
+						Data: map[string][]byte{
+							corev1.TLSCertKey:       exampleBundle1.generateCertificateExpiring1H(exampleBundle1.certificate),
+							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+							cmmeta.TLSCAKey:         nil,
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleBundle1.certificate,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						exampleBundle1.certificateRequest,
+					)),
+				},
+				ExpectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-850937773"`},
+			},
+		},
+		"with private key rotation, generate a new private key and create a new CertificateRequest if existing Certificate expires soon": {
+			certificate:             exampleRotateBundle.certificate,
+			generatePrivateKeyBytes: testGeneratePrivateKeyBytesFn(exampleRotateBundle.privateKeyBytes),
+			generateCSR:             testGenerateCSRFn(exampleRotateBundle.csrBytes),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: gen.DefaultTestNamespace,
+							Name:      "output",
+							Annotations: map[string]string{
+								cmapi.CertificateNameKey:      "test",
+								cmapi.IssuerKindAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Kind,
+								cmapi.IssuerNameAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Name,
+							},
+						},
+						Data: map[string][]byte{
+							corev1.TLSCertKey:       exampleBundle1.generateCertificateExpiring1H(exampleRotateBundle.certificate),
+							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+							cmmeta.TLSCAKey:         nil,
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleRotateBundle.certificate,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						exampleRotateNextKeySecret,
+					)),
+					testpkg.NewAction(coretesting.NewCreateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						exampleRotateBundle.certificateRequest,
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal GeneratedKey Generated a new private key for the next CertificateRequest",
+					fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, exampleRotateBundle.expectedRequestName),
+				},
+			},
+		},
+		"with private key rotation, delete the CertificateRequest created for the private key stored in the Secret without reporting the key as lost": {
+			certificate: exampleRotateBundle.certificate,
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: gen.DefaultTestNamespace,
+							Name:      "output",
+							Annotations: map[string]string{
+								cmapi.CertificateNameKey:      "test",
+								cmapi.IssuerKindAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Kind,
+								cmapi.IssuerNameAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Name,
+							},
+						},
+						Data: map[string][]byte{
+							corev1.TLSCertKey:       exampleBundle1.generateCertificateExpiring1H(exampleRotateBundle.certificate),
+							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+							cmmeta.TLSCAKey:         nil,
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+					exampleRotateNextKeySecret,
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleRotateBundle.certificate,
+					gen.CertificateRequestFrom(exampleRotateBundle.certificateRequest,
+						gen.SetCertificateRequestCSR(exampleBundle1.csrBytes),
+					),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewDeleteAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						exampleRotateBundle.expectedRequestName,
+					)),
+				},
+				ExpectedEvents: []string{fmt.Sprintf(`Normal RotatingPrivateKey Deleted CertificateRequest %q for the previous private key, creating a new one for the next private key`, exampleRotateBundle.expectedRequestName)},
+			},
+		},
+		"with private key rotation, report the key as lost if the CertificateRequest matches neither the stored nor the next private key": {
+			certificate: exampleRotateBundle.certificate,
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: gen.DefaultTestNamespace,
+							Name:      "output",
+							Annotations: map[string]string{
+								cmapi.CertificateNameKey:      "test",
+								cmapi.IssuerKindAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Kind,
+								cmapi.IssuerNameAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Name,
+							},
+						},
+						Data: map[string][]byte{
+							corev1.TLSCertKey:       exampleBundle1.generateCertificateExpiring1H(exampleRotateBundle.certificate),
+							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+							cmmeta.TLSCAKey:         nil,
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+					exampleRotateNextKeySecret,
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleRotateBundle.certificate,
+					gen.CertificateRequestFrom(exampleRotateBundle.certificateRequest,
+						gen.SetCertificateRequestCSR(exampleECBundle.csrBytes),
+					),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewDeleteAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						exampleRotateBundle.expectedRequestName,
+					)),
+				},
+				ExpectedEvents: []string{fmt.Sprintf(`Normal PrivateKeyLost Lost private key for CertificateRequest %q, deleting old resource`, exampleRotateBundle.expectedRequestName)},
+			},
+		},
+		"with private key rotation, delete the Secret storing the next private key instead of reusing a private key that is already stored in the Secret": {
+			certificate: exampleRotateBundle.certificate,
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: gen.DefaultTestNamespace,
+							Name:      "output",
+							Annotations: map[string]string{
+								cmapi.CertificateNameKey:      "test",
+								cmapi.IssuerKindAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Kind,
+								cmapi.IssuerNameAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Name,
+							},
+						},
+						Data: map[string][]byte{
+							corev1.TLSCertKey:       exampleBundle1.generateCertificateExpiring1H(exampleRotateBundle.certificate),
+							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+							cmmeta.TLSCAKey:         nil,
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+					gen.SecretFrom(exampleRotateNextKeySecret,
+						gen.SetSecretData(map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+						}),
+					),
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleRotateBundle.certificate,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewDeleteAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						"test-next-private-key",
+					)),
+				},
+			},
+		},
+		"with private key rotation, do nothing and wait if an up to date CertificateRequest exists for the next private key and is not Ready": {
+			certificate: exampleRotateBundle.certificate,
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: gen.DefaultTestNamespace,
+							Name:      "output",
+							Annotations: map[string]string{
+								cmapi.CertificateNameKey:      "test",
+								cmapi.IssuerKindAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Kind,
+								cmapi.IssuerNameAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Name,
+							},
+						},
+						Data: map[string][]byte{
+							corev1.TLSCertKey:       exampleBundle1.generateCertificateExpiring1H(exampleRotateBundle.certificate),
+							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+							cmmeta.TLSCAKey:         nil,
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+					exampleRotateNextKeySecret,
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleRotateBundle.certificate,
+					exampleRotateBundle.certificateRequest,
+				},
+			},
+		},
+		"with private key rotation, store the next private key and signed certificate in the Secret once the CertificateRequest is ready": {
+			certificate: exampleRotateBundle.certificate,
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: gen.DefaultTestNamespace,
+							Name:      "output",
+							Annotations: map[string]string{
+								cmapi.CertificateNameKey:      "test",
+								cmapi.IssuerKindAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Kind,
+								cmapi.IssuerNameAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Name,
+							},
+						},
+						Data: map[string][]byte{
+							corev1.TLSCertKey:       exampleBundle1.generateCertificateExpiring1H(exampleRotateBundle.certificate),
+							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+							cmmeta.TLSCAKey:         nil,
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+					exampleRotateNextKeySecret,
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleRotateBundle.certificate,
+					exampleRotateBundle.certificateRequestReady,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						&corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: gen.DefaultTestNamespace,
+								Name:      "output",
+								Annotations: map[string]string{
+									cmapi.CertificateNameKey:      "test",
+									cmapi.IssuerKindAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Kind,
+									cmapi.IssuerNameAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Name,
+									cmapi.IPSANAnnotationKey:      "",
+									cmapi.AltNamesAnnotationKey:   "example.com",
+									cmapi.CommonNameAnnotationKey: "",
+									cmapi.URISANAnnotationKey:     "",
+								},
+							},
+							Data: map[string][]byte{
+								corev1.TLSCertKey:       exampleRotateBundle.certBytes,
+								corev1.TLSPrivateKeyKey: exampleRotateBundle.privateKeyBytes,
+								cmmeta.TLSCAKey:         nil,
+							},
+							Type: corev1.SecretTypeTLS,
+						},
+					)),
+				},
+				ExpectedEvents: []string{"Normal Issued Certificate issued successfully"},
+			},
+		},
+		"with private key rotation, delete the Secret storing the next private key once the Secret is observed to contain it": {
+			certificate: exampleRotateBundle.certificate,
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: gen.DefaultTestNamespace,
+							Name:      "output",
+							Annotations: map[string]string{
+								cmapi.CertificateNameKey:      "test",
+								cmapi.IssuerKindAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Kind,
+								cmapi.IssuerNameAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Name,
+								cmapi.IPSANAnnotationKey:      "",
+								cmapi.AltNamesAnnotationKey:   "example.com",
+								cmapi.CommonNameAnnotationKey: "",
+								cmapi.URISANAnnotationKey:     "",
+							},
+						},
+						Data: map[string][]byte{
+							corev1.TLSCertKey:       exampleRotateBundle.certBytes,
+							corev1.TLSPrivateKeyKey: exampleRotateBundle.privateKeyBytes,
+							cmmeta.TLSCAKey:         nil,
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+					exampleRotateNextKeySecret,
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleRotateBundle.certificate,
+					exampleRotateBundle.certificateRequestReady,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewDeleteAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						"test-next-private-key",
+					)),
+				},
+			},
+		},
+		"with private key rotation, do not replace the private key in the Secret if it has a differing keyAlgorithm": {
+			certificate:             exampleRotateBundle.certificate,
+			generatePrivateKeyBytes: testGeneratePrivateKeyBytesFn(exampleRotateBundle.privateKeyBytes),
+			generateCSR:             testGenerateCSRFn(exampleRotateBundle.csrBytes),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: gen.DefaultTestNamespace,
+							Name:      "output",
+							Annotations: map[string]string{
+								cmapi.CertificateNameKey:      "test",
+								cmapi.IssuerKindAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Kind,
+								cmapi.IssuerNameAnnotationKey: exampleRotateBundle.certificate.Spec.IssuerRef.Name,
+								cmapi.IPSANAnnotationKey:      "",
+								cmapi.AltNamesAnnotationKey:   "example.com",
+								cmapi.CommonNameAnnotationKey: "",
+								cmapi.URISANAnnotationKey:     "",
+							},
+						},
+						Data: map[string][]byte{
+							corev1.TLSCertKey:       exampleECBundle.generateTestCertificate(exampleECBundle.certificate, nil),
+							corev1.TLSPrivateKeyKey: exampleECBundle.privateKeyBytes,
+							cmmeta.TLSCAKey:         nil,
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleRotateBundle.certificate,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						exampleRotateNextKeySecret,
+					)),
+					testpkg.NewAction(coretesting.NewCreateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						exampleRotateBundle.certificateRequest,
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal GeneratedKey Generated a new private key for the next CertificateRequest",
+					fmt.Sprintf(`Normal Requested Created new CertificateRequest resource %q`, exampleRotateBundle.expectedRequestName),
+				},
+			},
+		},
+		"do nothing if existing x509 certificate is up to date and valid for the cert and no other CertificateRequest exists": {
+			certificate: exampleBundle1.certificate,
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: gen.DefaultTestNamespace,
+							Name:      "output",
+							Annotations: map[string]string{
+								"custom-annotation":           "value",
+								cmapi.CertificateNameKey:      "test",
+								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+								cmapi.IPSANAnnotationKey:      "",
+								cmapi.AltNamesAnnotationKey:   "example.com",
+								cmapi.CommonNameAnnotationKey: "",
+								cmapi.URISANAnnotationKey:     "",
+							},
+						},
+						Data: map[string][]byte{
+							corev1.TLSCertKey:       exampleBundle1.certBytes,
+							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+							cmmeta.TLSCAKey:         nil,
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleBundle1.certificate,
+				},
+			},
+		},
+		"record the revision of the certificate stored in the Secret if updating the status failed when the Secret was updated": {
+			certificate: exampleBundle1.certificate,
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					gen.SecretFrom(exampleManualRenewalSecret, gen.SetSecretData(map[string][]byte{
+						corev1.TLSCertKey:       exampleBundle1.certBytes,
+						corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+						cmmeta.TLSCAKey:         nil,
+					})),
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleBundle1.certificate,
+					exampleBundle1.certificateRequestReady,
+				},
+			},
+			checkCertificate: func(t *testing.T, crt *cmapi.Certificate) {
+				if crt.Status.Revision == nil || *crt.Status.Revision != 1 {
+					t.Errorf("expected revision 1 to be recorded, got %v", crt.Status.Revision)
+				}
+				if crt.Status.CertificateRequestName != exampleBundle1.expectedRequestName {
+					t.Errorf("expected CertificateRequest %q to be recorded, got %q", exampleBundle1.expectedRequestName, crt.Status.CertificateRequestName)
+				}
+			},
+		},
+		"do not record the revision of a CertificateRequest that did not issue the certificate stored in the Secret": {
+			certificate: exampleBundle1.certificate,
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{exampleManualRenewalSecret},
+				CertManagerObjects: []runtime.Object{
+					exampleBundle1.certificate,
+					exampleBundle1.certificateRequestReady,
+				},
+			},
+			checkCertificate: func(t *testing.T, crt *cmapi.Certificate) {
+				if crt.Status.Revision != nil || crt.Status.CertificateRequestName != "" {
+					t.Errorf("expected no revision to be recorded, got %v %q", crt.Status.Revision, crt.Status.CertificateRequestName)
+				}
+			},
+		},
+		"with a manual renewal requested, create a new CertificateRequest even if the existing certificate is valid": {
+			certificate: gen.CertificateFrom(exampleManualRenewalCertificate),
+			generateCSR: testGenerateCSRFn(exampleBundle1.csrBytes),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{exampleManualRenewalSecret},
+				CertManagerObjects: []runtime.Object{
+					exampleManualRenewalCertificate,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(exampleBundle1.certificateRequest,
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.CertificateRequestIssuingTimeAnnotationKey: manualRenewalTime.UTC().Format(time.RFC3339),
+							}),
+						),
+					)),
+				},
+				ExpectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-850937773"`},
+			},
+		},
+		"with a manual renewal requested, delete an existing CertificateRequest that issued the certificate stored in the Secret": {
+			certificate: gen.CertificateFrom(exampleManualRenewalCertificate),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{exampleManualRenewalSecret},
+				CertManagerObjects: []runtime.Object{
+					exampleManualRenewalCertificate,
+					gen.CertificateRequestFrom(exampleBundle1.certificateRequestReady,
+						gen.SetCertificateRequestCertificate(exampleManualRenewalCert),
+						func(cr *cmapi.CertificateRequest) {
+							cr.CreationTimestamp = metav1.NewTime(manualRenewalTime.Add(-time.Hour))
+						},
+					),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewDeleteAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						exampleBundle1.expectedRequestName,
+					)),
+				},
+				ExpectedEvents: []string{`Normal ManualRenewal Deleted CertificateRequest "test-850937773" as re-issuance has been manually requested`},
+			},
+		},
+		"with a manual renewal requested, delete an existing CertificateRequest that issued the certificate stored in the Secret even if it was created in the same second": {
+			certificate: gen.CertificateFrom(exampleManualRenewalCertificate),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{exampleManualRenewalSecret},
+				CertManagerObjects: []runtime.Object{
+					exampleManualRenewalCertificate,
+					gen.CertificateRequestFrom(exampleBundle1.certificateRequestReady,
+						gen.SetCertificateRequestCertificate(exampleManualRenewalCert),
+						func(cr *cmapi.CertificateRequest) {
+							cr.CreationTimestamp = metav1.NewTime(manualRenewalTime.Time)
+						},
+					),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewDeleteAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						exampleBundle1.expectedRequestName,
+					)),
+				},
+				ExpectedEvents: []string{`Normal ManualRenewal Deleted CertificateRequest "test-850937773" as re-issuance has been manually requested`},
+			},
+		},
+		"with a re-issuance requested as the private key is compromised, generate a new private key and create a new CertificateRequest": {
+			certificate:             gen.CertificateFrom(exampleKeyCompromiseCertificate),
+			generatePrivateKeyBytes: testGeneratePrivateKeyBytesFn(exampleKeyCompromiseBundle.privateKeyBytes),
+			generateCSR:             testGenerateCSRFn(exampleKeyCompromiseBundle.csrBytes),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{exampleManualRenewalSecret},
+				CertManagerObjects: []runtime.Object{
+					exampleKeyCompromiseCertificate,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						exampleKeyCompromiseNextKeySecret,
+					)),
+					testpkg.NewAction(coretesting.NewCreateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(exampleKeyCompromiseBundle.certificateRequest,
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.CertificateRequestIssuingTimeAnnotationKey: manualRenewalTime.UTC().Format(time.RFC3339),
+							}),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal GeneratedKey Generated a new private key for the next CertificateRequest",
+					`Normal Requested Created new CertificateRequest resource "test-850937773"`,
+				},
+			},
+		},
+		"with a manual renewal requested, remove the Issuing condition instead of re-issuing again if the status update failed after the Secret was updated with the certificate issued for it": {
+			certificate: gen.CertificateFrom(exampleManualRenewalCertificate),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{exampleManualRenewalSecret},
+				CertManagerObjects: []runtime.Object{
+					exampleManualRenewalCertificate,
+					gen.CertificateRequestFrom(exampleBundle1.certificateRequestReady,
+						gen.SetCertificateRequestCertificate(exampleManualRenewalCert),
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestIssuingTimeAnnotationKey: manualRenewalTime.UTC().Format(time.RFC3339),
+						}),
+					),
+				},
+			},
+			checkCertificate: func(t *testing.T, crt *cmapi.Certificate) {
+				if apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing) != nil {
+					t.Errorf("expected the Issuing condition to be removed")
+				}
+			},
+		},
+		"with a manual renewal requested, delete an existing CertificateRequest created for an earlier re-issuance that issued the certificate stored in the Secret": {
+			certificate: gen.CertificateFrom(exampleManualRenewalCertificate),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{exampleManualRenewalSecret},
+				CertManagerObjects: []runtime.Object{
+					exampleManualRenewalCertificate,
+					gen.CertificateRequestFrom(exampleBundle1.certificateRequestReady,
+						gen.SetCertificateRequestCertificate(exampleManualRenewalCert),
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestIssuingTimeAnnotationKey: manualRenewalTime.Add(-time.Hour).UTC().Format(time.RFC3339),
+						}),
+					),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewDeleteAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						exampleBundle1.expectedRequestName,
 					)),
 				},
-				ExpectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-850937773"`},
+				ExpectedEvents: []string{`Normal ManualRenewal Deleted CertificateRequest "test-850937773" as re-issuance has been manually requested`},
 			},
 		},
-		"do nothing and wait if an up to date certificaterequest resource exists and is not Ready": {
-			certificate: exampleBundle1.certificate,
+		"with a manual renewal requested, update the Secret resource once a CertificateRequest containing a new certificate is ready": {
+			certificate: gen.CertificateFrom(exampleManualRenewalCertificate),
 			builder: &testpkg.Builder{
-				KubeObjects: []runtime.Object{
-					&corev1.Secret{
-						ObjectMeta: metav1.ObjectMeta{
-							Namespace: gen.DefaultTestNamespace,
-							Name:      "output",
-							Annotations: map[string]string{
-								"custom-annotation":           "value",
-								cmapi.CertificateNameKey:      "test",
-								cmapi.IssuerKindAnnotationKey: "Issuer",
-								cmapi.IssuerNameAnnotationKey: "test",
-							},
-						},
-						Data: map[string][]byte{
-							corev1.TLSCertKey:       nil,
+				KubeObjects: []runtime.Object{exampleManualRenewalSecret},
+				CertManagerObjects: []runtime.Object{
+					exampleManualRenewalCertificate,
+					gen.CertificateRequestFrom(exampleBundle1.certificateRequestReady, func(cr *cmapi.CertificateRequest) {
+						cr.CreationTimestamp = metav1.NewTime(manualRenewalTime.Add(-time.Hour))
+					}),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						gen.SecretFrom(exampleManualRenewalSecret, gen.SetSecretData(map[string][]byte{
+							corev1.TLSCertKey:       exampleBundle1.certBytes,
 							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
 							cmmeta.TLSCAKey:         nil,
-						},
-						Type: corev1.SecretTypeTLS,
-					},
-				},
-				CertManagerObjects: []runtime.Object{
-					exampleBundle1.certificate,
-					exampleBundle1.certificateRequest,
+						})),
+					)),
 				},
+				ExpectedEvents: []string{"Normal Issued Certificate issued successfully"},
 			},
 		},
-		"create a new CertificateRequest if existing Certificate expires soon": {
+		"update secret resource metadata if existing certificate is valid but missing annotations": {
 			certificate: exampleBundle1.certificate,
-			generateCSR: testGenerateCSRFn(exampleBundle1.csrBytes),
 			builder: &testpkg.Builder{
 				KubeObjects: []runtime.Object{
 					&corev1.Secret{
@@ -701,17 +1405,12 @@ func TestProcessCertificate(t *testing.T) {
 							Name:      "output",
 							Annotations: map[string]string{
 								"custom-annotation":           "value",
-								cmapi.CertificateNameKey:      "test",
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
-								cmapi.IPSANAnnotationKey:      "",
-								cmapi.AltNamesAnnotationKey:   "example.com",
-								cmapi.CommonNameAnnotationKey: "",
-								cmapi.URISANAnnotationKey:     "",
 							},
 						},
 						Data: map[string][]byte{
-							corev1.TLSCertKey:       exampleBundle1.generateCertificateExpiring1H(exampleBundle1.certificate),
+							corev1.TLSCertKey:       exampleBundle1.certBytes,
 							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
 							cmmeta.TLSCAKey:         nil,
 						},
@@ -722,25 +1421,50 @@ func TestProcessCertificate(t *testing.T) {
 					exampleBundle1.certificate,
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewCreateAction(
-						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
 						gen.DefaultTestNamespace,
-						exampleBundle1.certificateRequest,
+						&corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: gen.DefaultTestNamespace,
+								Name:      "output",
+								Annotations: map[string]string{
+									"custom-annotation":           "value",
+									cmapi.CertificateNameKey:      "test",
+									cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+									cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+									cmapi.IPSANAnnotationKey:      "",
+									cmapi.AltNamesAnnotationKey:   "example.com",
+									cmapi.CommonNameAnnotationKey: "",
+									cmapi.URISANAnnotationKey:     "",
+								},
+							},
+							Data: map[string][]byte{
+								corev1.TLSCertKey:       exampleBundle1.certBytes,
+								corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+								cmmeta.TLSCAKey:         nil,
+							},
+							Type: corev1.SecretTypeTLS,
+						},
 					)),
 				},
-				ExpectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-850937773"`},
+				ExpectedEvents: []string{"Normal UpdateMeta Updated metadata on Secret resource"},
 			},
 		},
-		"do nothing if existing x509 certificate is up to date and valid for the cert and no other CertificateRequest exists": {
-			certificate: exampleBundle1.certificate,
+		"update secret resource labels and annotations from the secretTemplate": {
+			certificate: gen.CertificateFrom(exampleBundle1.certificate,
+				gen.SetCertificateSecretTemplate(map[string]string{"example-label": "label-value"}, map[string]string{"example-annotation": "annotation-value"}),
+			),
 			builder: &testpkg.Builder{
 				KubeObjects: []runtime.Object{
 					&corev1.Secret{
 						ObjectMeta: metav1.ObjectMeta{
 							Namespace: gen.DefaultTestNamespace,
 							Name:      "output",
+							Labels: map[string]string{
+								"custom-label": "value",
+							},
 							Annotations: map[string]string{
-								"custom-annotation":           "value",
 								cmapi.CertificateNameKey:      "test",
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
@@ -761,20 +1485,70 @@ func TestProcessCertificate(t *testing.T) {
 				CertManagerObjects: []runtime.Object{
 					exampleBundle1.certificate,
 				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						&corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: gen.DefaultTestNamespace,
+								Name:      "output",
+								Labels: map[string]string{
+									"custom-label":  "value",
+									"example-label": "label-value",
+								},
+								Annotations: map[string]string{
+									"example-annotation":                   "annotation-value",
+									secretTemplateLabelsAnnotationKey:      "example-label",
+									secretTemplateAnnotationsAnnotationKey: "example-annotation",
+									cmapi.CertificateNameKey:               "test",
+									cmapi.IssuerKindAnnotationKey:          exampleBundle1.certificate.Spec.IssuerRef.Kind,
+									cmapi.IssuerNameAnnotationKey:          exampleBundle1.certificate.Spec.IssuerRef.Name,
+									cmapi.IPSANAnnotationKey:               "",
+									cmapi.AltNamesAnnotationKey:            "example.com",
+									cmapi.CommonNameAnnotationKey:          "",
+									cmapi.URISANAnnotationKey:              "",
+								},
+							},
+							Data: map[string][]byte{
+								corev1.TLSCertKey:       exampleBundle1.certBytes,
+								corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+								cmmeta.TLSCAKey:         nil,
+							},
+							Type: corev1.SecretTypeTLS,
+						},
+					)),
+				},
+				ExpectedEvents: []string{"Normal UpdateMeta Updated metadata on Secret resource"},
 			},
 		},
-		"update secret resource metadata if existing certificate is valid but missing annotations": {
-			certificate: exampleBundle1.certificate,
+		"remove secret resource labels and annotations that were removed from the secretTemplate": {
+			certificate: gen.CertificateFrom(exampleBundle1.certificate,
+				gen.SetCertificateSecretTemplate(map[string]string{"example-label": "label-value"}, nil),
+			),
 			builder: &testpkg.Builder{
 				KubeObjects: []runtime.Object{
 					&corev1.Secret{
 						ObjectMeta: metav1.ObjectMeta{
 							Namespace: gen.DefaultTestNamespace,
 							Name:      "output",
+							Labels: map[string]string{
+								"custom-label":  "value",
+								"example-label": "label-value",
+								"removed-label": "value",
+							},
 							Annotations: map[string]string{
-								"custom-annotation":           "value",
-								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
-								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+								"custom-annotation":                    "value",
+								"example-annotation":                   "annotation-value",
+								secretTemplateLabelsAnnotationKey:      "example-label,removed-label",
+								secretTemplateAnnotationsAnnotationKey: "example-annotation",
+								cmapi.CertificateNameKey:               "test",
+								cmapi.IssuerKindAnnotationKey:          exampleBundle1.certificate.Spec.IssuerRef.Kind,
+								cmapi.IssuerNameAnnotationKey:          exampleBundle1.certificate.Spec.IssuerRef.Name,
+								cmapi.IPSANAnnotationKey:               "",
+								cmapi.AltNamesAnnotationKey:            "example.com",
+								cmapi.CommonNameAnnotationKey:          "",
+								cmapi.URISANAnnotationKey:              "",
 							},
 						},
 						Data: map[string][]byte{
@@ -796,15 +1570,20 @@ func TestProcessCertificate(t *testing.T) {
 							ObjectMeta: metav1.ObjectMeta{
 								Namespace: gen.DefaultTestNamespace,
 								Name:      "output",
+								Labels: map[string]string{
+									"custom-label":  "value",
+									"example-label": "label-value",
+								},
 								Annotations: map[string]string{
-									"custom-annotation":           "value",
-									cmapi.CertificateNameKey:      "test",
-									cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
-									cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
-									cmapi.IPSANAnnotationKey:      "",
-									cmapi.AltNamesAnnotationKey:   "example.com",
-									cmapi.CommonNameAnnotationKey: "",
-									cmapi.URISANAnnotationKey:     "",
+									"custom-annotation":               "value",
+									secretTemplateLabelsAnnotationKey: "example-label",
+									cmapi.CertificateNameKey:          "test",
+									cmapi.IssuerKindAnnotationKey:     exampleBundle1.certificate.Spec.IssuerRef.Kind,
+									cmapi.IssuerNameAnnotationKey:     exampleBundle1.certificate.Spec.IssuerRef.Name,
+									cmapi.IPSANAnnotationKey:          "",
+									cmapi.AltNamesAnnotationKey:       "example.com",
+									cmapi.CommonNameAnnotationKey:     "",
+									cmapi.URISANAnnotationKey:         "",
 								},
 							},
 							Data: map[string][]byte{
@@ -1246,6 +2025,123 @@ func TestProcessCertificate(t *testing.T) {
 				ExpectedEvents:  []string{`Warning CertificateRequestInvalidRequest The failed CertificateRequest "test-850937773" is an invalid request and will no longer be processed`},
 			},
 		},
+		"if the request has failed again after a previous failed attempt, do not retry before the doubled backoff has passed": {
+			certificate: gen.CertificateFrom(exampleBundle1.certificate,
+				gen.SetCertificateLastFailureTime(metav1.NewTime(fixedClockStart.Add(-time.Hour*5))),
+				gen.SetCertificateFailedIssuanceAttempts(1),
+				gen.SetCertificateNextRetryTime(metav1.NewTime(fixedClockStart.Add(-time.Hour*4))),
+			),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      exampleBundle1.certificate.Spec.SecretName,
+							Namespace: exampleBundle1.certificate.Namespace,
+							Annotations: map[string]string{
+								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+							},
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+							corev1.TLSCertKey:       exampleBundle1.localTemporaryCertificateBytes,
+						},
+					},
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleBundle1.certificate,
+					gen.CertificateRequestFrom(exampleBundle1.certificateRequestFailed,
+						gen.SetCertificateRequestFailureTime(metav1.Time{
+							Time: fixedClockStart.Add(-time.Minute * 61),
+						})),
+				},
+				ExpectedActions: []testpkg.Action{},
+				ExpectedEvents:  []string{},
+			},
+		},
+		"if the request has failed again after a previous failed attempt, delete the request to retry once the doubled backoff has passed": {
+			certificate: gen.CertificateFrom(exampleBundle1.certificate,
+				gen.SetCertificateLastFailureTime(metav1.NewTime(fixedClockStart.Add(-time.Hour*5))),
+				gen.SetCertificateFailedIssuanceAttempts(1),
+				gen.SetCertificateNextRetryTime(metav1.NewTime(fixedClockStart.Add(-time.Hour*4))),
+			),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      exampleBundle1.certificate.Spec.SecretName,
+							Namespace: exampleBundle1.certificate.Namespace,
+							Annotations: map[string]string{
+								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+							},
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+							corev1.TLSCertKey:       exampleBundle1.localTemporaryCertificateBytes,
+						},
+					},
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleBundle1.certificate,
+					gen.CertificateRequestFrom(exampleBundle1.certificateRequestFailed,
+						gen.SetCertificateRequestFailureTime(metav1.Time{
+							Time: fixedClockStart.Add(-time.Minute * 121),
+						})),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewDeleteAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						exampleBundle1.certificateRequestFailed.Name,
+					)),
+				},
+				ExpectedEvents: []string{`Normal CertificateRequestRetry The failed CertificateRequest "test-850937773" will be retried now`},
+			},
+		},
+		"if the request has failed after failed attempts for a previous spec, only back off for the failures of the current spec": {
+			certificate: gen.CertificateFrom(exampleBundle1.certificate,
+				gen.SetCertificateLastFailureTime(metav1.NewTime(fixedClockStart.Add(-time.Hour*5))),
+				gen.SetCertificateFailedIssuanceAttempts(3),
+				gen.SetCertificateNextRetryTime(metav1.NewTime(fixedClockStart.Add(-time.Hour))),
+				func(crt *cmapi.Certificate) {
+					crt.Status.FailedIssuanceSpecHash = "test-1234"
+				},
+			),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      exampleBundle1.certificate.Spec.SecretName,
+							Namespace: exampleBundle1.certificate.Namespace,
+							Annotations: map[string]string{
+								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+							},
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+							corev1.TLSCertKey:       exampleBundle1.localTemporaryCertificateBytes,
+						},
+					},
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleBundle1.certificate,
+					gen.CertificateRequestFrom(exampleBundle1.certificateRequestFailed,
+						gen.SetCertificateRequestFailureTime(metav1.Time{
+							Time: fixedClockStart.Add(-time.Minute * 61),
+						})),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewDeleteAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						exampleBundle1.certificateRequestFailed.Name,
+					)),
+				},
+				ExpectedEvents: []string{`Normal CertificateRequestRetry The failed CertificateRequest "test-850937773" will be retried now`},
+			},
+		},
 		"with secret owner references enabled, should set the ownerReference field when generating a new private key Secret": {
 			certificate:             exampleBundle1.certificate,
 			generatePrivateKeyBytes: testGeneratePrivateKeyBytesFn(exampleBundle1.privateKeyBytes),
@@ -1950,7 +2846,7 @@ func TestUpdateStatus(t *testing.T) {
 								Message:            "Certificate is up to date and has not expired",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.SetCertificateNotAfter(metav1.NewTime(exampleBundle1.cert.NotAfter)),
+							setCertificateStatusTimes(exampleBundle1.certBytes, time.Hour*36),
 						),
 					)),
 				},
@@ -1992,7 +2888,7 @@ func TestUpdateStatus(t *testing.T) {
 								Message:            "Certificate is up to date and has not expired",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.SetCertificateNotAfter(metav1.NewTime(certificateNotAfter(exampleBundle1.generateCertificateExpiring1H(exampleBundle1.certificate)))),
+							setCertificateStatusTimes(exampleBundle1.generateCertificateExpiring1H(exampleBundle1.certificate), time.Hour*36),
 						),
 					)),
 				},
@@ -2033,7 +2929,7 @@ func TestUpdateStatus(t *testing.T) {
 								Message:            fmt.Sprintf("Certificate has expired on %s", certificateNotAfter(exampleBundle1.generateCertificateExpired(exampleBundle1.certificate)).Format(time.RFC822)),
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.SetCertificateNotAfter(metav1.NewTime(certificateNotAfter(exampleBundle1.generateCertificateExpired(exampleBundle1.certificate)))),
+							setCertificateStatusTimes(exampleBundle1.generateCertificateExpired(exampleBundle1.certificate), time.Hour*36),
 						),
 					)),
 				},
@@ -2075,7 +2971,7 @@ func TestUpdateStatus(t *testing.T) {
 								Message:            fmt.Sprintf("Waiting for CertificateRequest %q to complete", exampleBundle1.certificateRequest.Name),
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.SetCertificateNotAfter(metav1.NewTime(certificateNotAfter(exampleBundle1.generateCertificateExpired(exampleBundle1.certificate)))),
+							setCertificateStatusTimes(exampleBundle1.generateCertificateExpired(exampleBundle1.certificate), time.Hour*36),
 						),
 					)),
 				},
@@ -2117,7 +3013,7 @@ func TestUpdateStatus(t *testing.T) {
 								Message:            fmt.Sprintf("Waiting for CertificateRequest %q to complete", exampleBundle1.certificateRequest.Name),
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.SetCertificateNotAfter(metav1.NewTime(certificateNotAfter(exampleBundle1.generateCertificateExpired(exampleBundle1.certificate)))),
+							setCertificateStatusTimes(exampleBundle1.generateCertificateExpired(exampleBundle1.certificate), time.Hour*36),
 						),
 					)),
 				},
@@ -2319,6 +3215,111 @@ func TestUpdateStatus(t *testing.T) {
 	}
 }
 
+func TestCleanupExistingCertificateRequests(t *testing.T) {
+	baseCert := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test", Kind: "something", Group: "not-empty"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRevisionHistoryLimit(2),
+		gen.SetCertificateRevision(3),
+	)
+
+	request := func(name, revision string, reason string) *cmapi.CertificateRequest {
+		return gen.CertificateRequest(name,
+			gen.SetCertificateRequestAnnotations(map[string]string{
+				cmapi.CertificateRequestRevisionAnnotationKey: revision,
+			}),
+			gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:   cmapi.CertificateRequestConditionReady,
+				Status: cmmeta.ConditionFalse,
+				Reason: reason,
+			}),
+			func(cr *cmapi.CertificateRequest) {
+				cr.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(baseCert, certificateGvk)}
+			},
+		)
+	}
+
+	deleteRequest := func(name string) testpkg.Action {
+		return testpkg.NewAction(coretesting.NewDeleteAction(
+			cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+			gen.DefaultTestNamespace,
+			name,
+		))
+	}
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		retain      string
+		requests    []runtime.Object
+		expected    []testpkg.Action
+	}{
+		"CertificateRequests that issued previous revisions should be retained up to the revisionHistoryLimit": {
+			certificate: baseCert,
+			retain:      "test-4",
+			requests: []runtime.Object{
+				request("test-1", "1", cmapi.CertificateRequestReasonIssued),
+				request("test-2", "2", cmapi.CertificateRequestReasonIssued),
+				request("test-3", "3", cmapi.CertificateRequestReasonIssued),
+				request("test-4", "4", cmapi.CertificateRequestReasonPending),
+			},
+			expected: []testpkg.Action{deleteRequest("test-1")},
+		},
+		"CertificateRequests without a revision should be deleted before those with one": {
+			certificate: baseCert,
+			retain:      "test-4",
+			requests: []runtime.Object{
+				request("test-old", "", cmapi.CertificateRequestReasonIssued),
+				request("test-2", "2", cmapi.CertificateRequestReasonIssued),
+				request("test-3", "3", cmapi.CertificateRequestReasonIssued),
+			},
+			expected: []testpkg.Action{deleteRequest("test-old")},
+		},
+		"CertificateRequests that did not issue a certificate should not be retained": {
+			certificate: baseCert,
+			retain:      "test-4",
+			requests: []runtime.Object{
+				request("test-2", "2", cmapi.CertificateRequestReasonFailed),
+				request("test-3", "3", cmapi.CertificateRequestReasonIssued),
+			},
+			expected: []testpkg.Action{deleteRequest("test-2")},
+		},
+		"CertificateRequests should not be retained if no revisionHistoryLimit is set": {
+			certificate: gen.CertificateFrom(baseCert, func(crt *cmapi.Certificate) {
+				crt.Spec.RevisionHistoryLimit = nil
+			}),
+			retain: "test-current",
+			requests: []runtime.Object{
+				request("test-current", "4", cmapi.CertificateRequestReasonIssued),
+				request("test-previous", "3", cmapi.CertificateRequestReasonIssued),
+			},
+			expected: []testpkg.Action{deleteRequest("test-previous")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				CertManagerObjects: append([]runtime.Object{test.certificate}, test.requests...),
+				ExpectedActions:    test.expected,
+			}
+			builder.Init()
+			defer builder.Stop()
+
+			testManager := &certificateRequestManager{}
+			testManager.Register(builder.Context)
+			builder.Start()
+
+			err := testManager.cleanupExistingCertificateRequests(logf.Log, test.certificate, test.retain)
+			if err != nil {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			builder.CheckAndFinish(err)
+		})
+	}
+}
+
 type testT struct {
 	builder                 *testpkg.Builder
 	generatePrivateKeyBytes generatePrivateKeyBytesFn
@@ -2326,6 +3327,10 @@ type testT struct {
 	localTemporarySigner    localTemporarySignerFn
 	certificate             *cmapi.Certificate
 	expectedErr             bool
+
+	// checkCertificate, if set, is called with the Certificate after it has
+	// been processed to check the changes made to its status.
+	checkCertificate func(t *testing.T, crt *cmapi.Certificate)
 }
 
 func runTest(t *testing.T, test testT) {
@@ -2340,13 +3345,19 @@ func runTest(t *testing.T, test testT) {
 	testManager.localTemporarySigner = test.localTemporarySigner
 	test.builder.Start()
 
-	err := testManager.processCertificate(context.Background(), test.certificate)
+	// processCertificate modifies the status of the Certificate in place, so
+	// a copy is passed as is done by ProcessItem
+	crt := test.certificate.DeepCopy()
+	err := testManager.processCertificate(context.Background(), crt)
 	if err != nil && !test.expectedErr {
 		t.Errorf("expected to not get an error, but got: %v", err)
 	}
 	if err == nil && test.expectedErr {
 		t.Errorf("expected to get an error but did not get one")
 	}
+	if test.checkCertificate != nil {
+		test.checkCertificate(t, crt)
+	}
 
 	test.builder.CheckAndFinish(err)
 }
@@ -24,16 +24,19 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/kr/pretty"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
@@ -50,6 +53,8 @@ var (
 
 type calculateDurationUntilRenewFn func(context.Context, *x509.Certificate, *v1alpha2.Certificate) time.Duration
 
+type calculateRenewalTimeFn func(context.Context, *x509.Certificate, *v1alpha2.Certificate) time.Time
+
 func getCertificateForKey(ctx context.Context, key string, lister cmlisters.CertificateLister) (*v1alpha2.Certificate, error) {
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
@@ -247,6 +252,169 @@ func updateCertificateStatus(ctx context.Context, cmClient cmclient.Interface, o
 	return cmClient.CertmanagerV1alpha2().Certificates(new.Namespace).UpdateStatus(new)
 }
 
+// certificateRotatesPrivateKey returns true if a new private key should be
+// generated for the next CertificateRequest created for the given
+// Certificate, either because private keys are rotated on every issuance or
+// because a re-issuance has been requested as the private key is compromised.
+func certificateRotatesPrivateKey(crt *v1alpha2.Certificate) bool {
+	if crt.Spec.PrivateKey != nil && crt.Spec.PrivateKey.RotationPolicy == v1alpha2.RotationPolicyAlways {
+		return true
+	}
+
+	issuing := apiutil.GetCertificateCondition(crt, v1alpha2.CertificateConditionIssuing)
+	return issuing != nil && issuing.Status == cmmeta.ConditionTrue && issuing.Reason == v1alpha2.CertificateIssuingReasonKeyCompromise
+}
+
+// nextPrivateKeySecretName returns the name of the Secret used to store the
+// private key for the next CertificateRequest when private keys are rotated.
+func nextPrivateKeySecretName(crt *v1alpha2.Certificate) string {
+	return crt.Name + "-next-private-key"
+}
+
+const (
+	// initialIssuanceBackoff is the time to wait before retrying issuance
+	// after the first failed attempt.
+	initialIssuanceBackoff = time.Hour
+	// maxIssuanceBackoff is the maximum time to wait before retrying
+	// issuance after a failed attempt.
+	maxIssuanceBackoff = time.Hour * 32
+)
+
+// issuanceBackoff returns the time to wait before retrying issuance after the
+// given number of consecutive failed attempts.
+func issuanceBackoff(attempts int) time.Duration {
+	backoff := initialIssuanceBackoff
+	for i := 1; i < attempts && backoff < maxIssuanceBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxIssuanceBackoff {
+		backoff = maxIssuanceBackoff
+	}
+	return backoff
+}
+
+// recordIssuanceFailure records a failed issuance attempt for the spec with
+// the given hash that failed at the given time on the status of the
+// Certificate, and sets the time after which issuance should be retried. A
+// failure that has already been recorded is not counted again. It returns
+// true if the failure was newly recorded.
+func recordIssuanceFailure(crt *v1alpha2.Certificate, specHash string, failureTime metav1.Time) bool {
+	if crt.Status.LastFailureTime != nil && crt.Status.LastFailureTime.Equal(&failureTime) && crt.Status.NextRetryTime != nil {
+		return false
+	}
+
+	attempts := 1
+	if crt.Status.FailedIssuanceAttempts != nil {
+		attempts = *crt.Status.FailedIssuanceAttempts + 1
+	}
+	nextRetryTime := metav1.NewTime(failureTime.Add(issuanceBackoff(attempts)))
+
+	crt.Status.LastFailureTime = &failureTime
+	crt.Status.FailedIssuanceAttempts = &attempts
+	crt.Status.NextRetryTime = &nextRetryTime
+	crt.Status.FailedIssuanceSpecHash = specHash
+
+	return true
+}
+
+// resetIssuanceBackoff removes any failed issuance attempts recorded on the
+// status of the Certificate.
+func resetIssuanceBackoff(crt *v1alpha2.Certificate) {
+	crt.Status.LastFailureTime = nil
+	crt.Status.FailedIssuanceAttempts = nil
+	crt.Status.NextRetryTime = nil
+	crt.Status.FailedIssuanceSpecHash = ""
+}
+
+// resetIssuanceBackoffForChangedSpec removes any failed issuance attempts
+// recorded on the status of the Certificate for a spec other than the one
+// with the given hash. It returns true if failed attempts were removed.
+func resetIssuanceBackoffForChangedSpec(crt *v1alpha2.Certificate, specHash string) bool {
+	if crt.Status.FailedIssuanceSpecHash == "" || crt.Status.FailedIssuanceSpecHash == specHash {
+		return false
+	}
+	resetIssuanceBackoff(crt)
+	return true
+}
+
+// issuingConditionTime returns the lastTransitionTime of the Certificate's
+// Issuing condition, formatted as it is recorded on the CertificateRequests
+// created for the re-issuance. It returns false if no re-issuance has been
+// manually requested.
+func issuingConditionTime(crt *v1alpha2.Certificate) (string, bool) {
+	issuing := apiutil.GetCertificateCondition(crt, v1alpha2.CertificateConditionIssuing)
+	if issuing == nil || issuing.Status != cmmeta.ConditionTrue {
+		return "", false
+	}
+	if issuing.LastTransitionTime == nil {
+		return "", true
+	}
+	return issuing.LastTransitionTime.UTC().Format(time.RFC3339), true
+}
+
+// certificateRequestForIssuing returns true if the CertificateRequest was
+// created for the re-issuance requested by the Certificate's current Issuing
+// condition.
+func certificateRequestForIssuing(crt *v1alpha2.Certificate, req *v1alpha2.CertificateRequest) bool {
+	issuingTime, ok := issuingConditionTime(crt)
+	if !ok {
+		return false
+	}
+	reqTime, ok := req.Annotations[v1alpha2.CertificateRequestIssuingTimeAnnotationKey]
+	return ok && reqTime == issuingTime
+}
+
+// nextRevision returns the revision of the given Certificate that will be
+// issued by the next CertificateRequest created for it.
+func nextRevision(crt *v1alpha2.Certificate) int {
+	if crt.Status.Revision == nil {
+		return 1
+	}
+	return *crt.Status.Revision + 1
+}
+
+// recordIssuedRevision records the revision of the certificate issued by the
+// given CertificateRequest, and the name of the request, on the status of the
+// Certificate.
+func recordIssuedRevision(crt *v1alpha2.Certificate, req *v1alpha2.CertificateRequest) {
+	revision, ok := certificateRequestRevision(req)
+	if !ok {
+		revision = nextRevision(crt)
+	}
+	crt.Status.Revision = &revision
+	crt.Status.CertificateRequestName = req.Name
+}
+
+// certificateRequestRevision returns the revision of the owning Certificate
+// that the given CertificateRequest issues. It returns false if the
+// CertificateRequest does not have a valid revision annotation, e.g. because
+// it was created by an older version of cert-manager.
+func certificateRequestRevision(req *v1alpha2.CertificateRequest) (int, bool) {
+	revision, err := strconv.Atoi(req.Annotations[v1alpha2.CertificateRequestRevisionAnnotationKey])
+	if err != nil || revision < 1 {
+		return 0, false
+	}
+	return revision, true
+}
+
+// certificateRequestName returns the name of the CertificateRequest that is
+// used to issue the next revision of the given Certificate.
+func certificateRequestName(crt *v1alpha2.Certificate) (string, error) {
+	name, err := apiutil.ComputeCertificateRequestName(crt)
+	if err != nil {
+		return "", err
+	}
+
+	// When a revision history is retained, the revision is appended to the
+	// name so that renewing the certificate does not replace the
+	// CertificateRequest that issued the previous revision.
+	if crt.Spec.RevisionHistoryLimit != nil {
+		name = fmt.Sprintf("%s-%d", name, nextRevision(crt))
+	}
+
+	return name, nil
+}
+
 func certificateHasTemporaryCertificateAnnotation(crt *v1alpha2.Certificate) bool {
 	if crt.Annotations == nil {
 		return false
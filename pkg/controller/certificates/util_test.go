@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	"github.com/jetstack/cert-manager/pkg/util"
@@ -283,3 +284,136 @@ func TestCertificateMatchesSpec(t *testing.T) {
 	}
 
 }
+
+func TestCertificateRequestName(t *testing.T) {
+	baseCert := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+
+	tests := map[string]struct {
+		crt            *cmapi.Certificate
+		expectedSuffix string
+	}{
+		"without a revisionHistoryLimit the name should only depend on the spec": {
+			crt: gen.CertificateFrom(baseCert, gen.SetCertificateRevision(3)),
+		},
+		"with a revisionHistoryLimit the name of the first revision should be suffixed with 1": {
+			crt:            gen.CertificateFrom(baseCert, gen.SetCertificateRevisionHistoryLimit(1)),
+			expectedSuffix: "-1",
+		},
+		"with a revisionHistoryLimit the name should be suffixed with the next revision": {
+			crt:            gen.CertificateFrom(baseCert, gen.SetCertificateRevisionHistoryLimit(1), gen.SetCertificateRevision(3)),
+			expectedSuffix: "-4",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			specName, err := apiutil.ComputeCertificateRequestName(test.crt)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := certificateRequestName(test.crt)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if exp := specName + test.expectedSuffix; got != exp {
+				t.Errorf("unexpected name, exp=%s got=%s", exp, got)
+			}
+		})
+	}
+}
+
+func TestIssuanceBackoff(t *testing.T) {
+	tests := map[int]time.Duration{
+		1:  time.Hour,
+		2:  time.Hour * 2,
+		3:  time.Hour * 4,
+		6:  time.Hour * 32,
+		7:  time.Hour * 32,
+		50: time.Hour * 32,
+	}
+
+	for attempts, exp := range tests {
+		if got := issuanceBackoff(attempts); got != exp {
+			t.Errorf("unexpected backoff after %d attempts, exp=%s got=%s", attempts, exp, got)
+		}
+	}
+}
+
+func TestRecordIssuanceFailure(t *testing.T) {
+	failureTime := metav1.NewTime(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC))
+	crt := gen.Certificate("test")
+
+	if !recordIssuanceFailure(crt, "test-1234", failureTime) {
+		t.Errorf("expected the first failure to be recorded")
+	}
+	if *crt.Status.FailedIssuanceAttempts != 1 || !crt.Status.NextRetryTime.Equal(&metav1.Time{Time: failureTime.Add(time.Hour)}) || crt.Status.FailedIssuanceSpecHash != "test-1234" {
+		t.Errorf("unexpected status after first failure: %+v", crt.Status)
+	}
+
+	if recordIssuanceFailure(crt, "test-1234", failureTime) {
+		t.Errorf("expected the same failure not to be recorded twice")
+	}
+	if *crt.Status.FailedIssuanceAttempts != 1 {
+		t.Errorf("expected 1 failed attempt but got %d", *crt.Status.FailedIssuanceAttempts)
+	}
+
+	secondFailureTime := metav1.NewTime(failureTime.Add(time.Hour * 2))
+	if !recordIssuanceFailure(crt, "test-1234", secondFailureTime) {
+		t.Errorf("expected the second failure to be recorded")
+	}
+	if *crt.Status.FailedIssuanceAttempts != 2 || !crt.Status.NextRetryTime.Equal(&metav1.Time{Time: secondFailureTime.Add(time.Hour * 2)}) {
+		t.Errorf("unexpected status after second failure: %+v", crt.Status)
+	}
+
+	resetIssuanceBackoff(crt)
+	if crt.Status.FailedIssuanceAttempts != nil || crt.Status.NextRetryTime != nil || crt.Status.LastFailureTime != nil || crt.Status.FailedIssuanceSpecHash != "" {
+		t.Errorf("expected failed attempts to be reset: %+v", crt.Status)
+	}
+}
+
+func TestResetIssuanceBackoffForChangedSpec(t *testing.T) {
+	failureTime := metav1.NewTime(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	tests := map[string]struct {
+		recordedSpecHash string
+		specHash         string
+		expectedReset    bool
+	}{
+		"failed attempts for the same spec should be kept": {
+			recordedSpecHash: "test-1234",
+			specHash:         "test-1234",
+		},
+		"failed attempts for a changed spec should be reset": {
+			recordedSpecHash: "test-1234",
+			specHash:         "test-5678",
+			expectedReset:    true,
+		},
+		"failed attempts recorded without a spec should be kept": {
+			specHash: "test-1234",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := gen.Certificate("test",
+				gen.SetCertificateLastFailureTime(failureTime),
+				gen.SetCertificateFailedIssuanceAttempts(3),
+				gen.SetCertificateNextRetryTime(metav1.NewTime(failureTime.Add(time.Hour*4))),
+			)
+			crt.Status.FailedIssuanceSpecHash = test.recordedSpecHash
+
+			if reset := resetIssuanceBackoffForChangedSpec(crt, test.specHash); reset != test.expectedReset {
+				t.Errorf("unexpected reset, exp=%t got=%t", test.expectedReset, reset)
+			}
+			if reset := crt.Status.FailedIssuanceAttempts == nil; reset != test.expectedReset {
+				t.Errorf("unexpected failed attempts after reset: %+v", crt.Status)
+			}
+		})
+	}
+}
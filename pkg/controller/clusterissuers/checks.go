@@ -86,6 +86,12 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1alpha2.Cluste
 					continue
 				}
 			}
+			if iss.Spec.Vault.Auth.ClientCertificate != nil {
+				if iss.Spec.Vault.Auth.ClientCertificate.SecretName == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
+			}
 		}
 	}
 
@@ -28,6 +28,7 @@ import (
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	internalapi "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/metrics"
 	"github.com/jetstack/cert-manager/pkg/webhook"
@@ -52,6 +53,10 @@ func (c *controller) Sync(ctx context.Context, iss *v1alpha2.ClusterIssuer) (err
 		}
 	}()
 
+	if issuerCopy.DeletionTimestamp != nil {
+		return c.finalize(ctx, iss, issuerCopy)
+	}
+
 	el := webhook.ValidationRegistry.Validate(issuerCopy, internalapi.SchemeGroupVersion.WithKind("ClusterIssuer"))
 	if len(el) > 0 {
 		msg := fmt.Sprintf("Resource validation failed: %v", el.ToAggregate())
@@ -75,6 +80,18 @@ func (c *controller) Sync(ctx context.Context, iss *v1alpha2.ClusterIssuer) (err
 		return err
 	}
 
+	updated, err := issuer.SyncFinalizer(ctx, issuerCopy, i)
+	if err != nil {
+		return err
+	}
+	if updated {
+		updatedIssuer, err := c.cmClient.CertmanagerV1alpha2().ClusterIssuers().Update(issuerCopy)
+		if err != nil {
+			return err
+		}
+		issuerCopy.ResourceVersion = updatedIssuer.ResourceVersion
+	}
+
 	err = i.Setup(ctx)
 	if err != nil {
 		s := messageErrorInitIssuer + err.Error()
@@ -86,6 +103,34 @@ func (c *controller) Sync(ctx context.Context, iss *v1alpha2.ClusterIssuer) (err
 	return nil
 }
 
+// finalize finalizes the deleted issuer and removes the finalizers added by
+// issuer implementations. This is done before the issuer is validated, so
+// that an invalid issuer, or one whose issuer type has changed, can still be
+// deleted.
+func (c *controller) finalize(ctx context.Context, iss, issuerCopy *v1alpha2.ClusterIssuer) error {
+	log := logf.FromContext(ctx)
+
+	i, err := c.issuerFactory.IssuerFor(issuerCopy)
+	if err != nil {
+		log.Error(err, "removing finalizers without finalizing the issuer")
+		i = nil
+	}
+
+	updated, err := issuer.FinalizeDeleted(ctx, issuerCopy, i)
+	if err != nil || !updated {
+		return err
+	}
+
+	if _, err := c.cmClient.CertmanagerV1alpha2().ClusterIssuers().Update(issuerCopy); err != nil {
+		return err
+	}
+
+	// the resource is about to be removed, so there is no status left to
+	// persist
+	issuerCopy.Status = iss.Status
+	return nil
+}
+
 func (c *controller) updateIssuerStatus(old, new *v1alpha2.ClusterIssuer) (*v1alpha2.ClusterIssuer, error) {
 	if reflect.DeepEqual(old.Status, new.Status) {
 		return nil, nil
@@ -17,6 +17,8 @@ limitations under the License.
 package clusterissuers
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"runtime/debug"
 	"testing"
@@ -25,9 +27,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgotesting "k8s.io/client-go/testing"
 
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/issuer/fake"
+	"github.com/jetstack/cert-manager/test/unit/gen"
 )
 
 func newFakeIssuerWithStatus(name string, status v1alpha2.IssuerStatus) *v1alpha2.ClusterIssuer {
@@ -43,6 +49,78 @@ func TestSync(t *testing.T) {
 
 }
 
+func TestSyncDeleted(t *testing.T) {
+	now := metav1.Now()
+	deletedClusterIssuer := func(mods ...gen.IssuerModifier) *v1alpha2.ClusterIssuer {
+		iss := gen.ClusterIssuer("test", mods...)
+		iss.DeletionTimestamp = &now
+		iss.Finalizers = []string{cmacme.ACMEAccountDeactivationFinalizer, "other"}
+		return iss
+	}
+	finalizedClusterIssuer := func(iss *v1alpha2.ClusterIssuer) *v1alpha2.ClusterIssuer {
+		iss = iss.DeepCopy()
+		iss.Finalizers = []string{"other"}
+		return iss
+	}
+
+	invalidClusterIssuer := deletedClusterIssuer()
+	caClusterIssuer := deletedClusterIssuer(gen.SetIssuerCA(v1alpha2.CAIssuer{SecretName: "ca"}))
+
+	tests := map[string]struct {
+		issuer      *v1alpha2.ClusterIssuer
+		issuerFor   func(v1alpha2.GenericIssuer) (issuer.Interface, error)
+		expectedErr bool
+	}{
+		"the finalizer of an invalid issuer should be removed": {
+			issuer: invalidClusterIssuer,
+			issuerFor: func(v1alpha2.GenericIssuer) (issuer.Interface, error) {
+				return nil, errors.New("could not get issuer type")
+			},
+		},
+		"the finalizer of an issuer whose type changed should be removed without finalizing it": {
+			issuer: caClusterIssuer,
+			issuerFor: func(v1alpha2.GenericIssuer) (issuer.Interface, error) {
+				return &fake.Issuer{
+					SetupFunc: func(context.Context) error {
+						t.Error("unexpected setup of a deleted issuer")
+						return nil
+					},
+				}, nil
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := &testpkg.Builder{
+				T:                  t,
+				CertManagerObjects: []runtime.Object{test.issuer},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(clientgotesting.NewUpdateAction(
+						v1alpha2.SchemeGroupVersion.WithResource("clusterissuers"),
+						test.issuer.Namespace,
+						finalizedClusterIssuer(test.issuer),
+					)),
+				},
+			}
+			b.Init()
+			defer b.Stop()
+
+			c := &controller{}
+			c.Register(b.Context)
+			c.issuerFactory = &fake.Factory{IssuerForFunc: test.issuerFor}
+			b.Start()
+
+			err := c.Sync(context.Background(), test.issuer)
+			if (err != nil) != test.expectedErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+
+			b.CheckAndFinish(err)
+		})
+	}
+}
+
 func TestUpdateIssuerStatus(t *testing.T) {
 	b := &testpkg.Builder{
 		T: t,
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/dynamic"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -48,6 +49,10 @@ type Context struct {
 	Client kubernetes.Interface
 	// CMClient is a cert-manager clientset
 	CMClient clientset.Interface
+	// DynamicClient is a Kubernetes dynamic client, used to manage resources
+	// that cert-manager does not have a typed clientset for, such as
+	// Gateway API resources
+	DynamicClient dynamic.Interface
 	// Recorder to record events to
 	Recorder record.EventRecorder
 
@@ -19,6 +19,8 @@ package controller
 import (
 	"context"
 	"crypto/x509"
+	"fmt"
+	"hash/fnv"
 	"time"
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
@@ -53,7 +55,13 @@ var now = time.Now
 // CalculateDurationUntilRenew calculates how long cert-manager should wait to
 // until attempting to renew this certificate resource.
 func (o IssuerOptions) CalculateDurationUntilRenew(ctx context.Context, cert *x509.Certificate, crt *cmapi.Certificate) time.Duration {
-	log := logs.FromContext(ctx, "CalculateDurationUntilRenew")
+	return o.CalculateRenewalTime(ctx, cert, crt).Sub(now())
+}
+
+// CalculateRenewalTime calculates the time at which cert-manager should
+// attempt to renew this certificate resource.
+func (o IssuerOptions) CalculateRenewalTime(ctx context.Context, cert *x509.Certificate, crt *cmapi.Certificate) time.Time {
+	log := logs.FromContext(ctx, "CalculateRenewalTime")
 
 	// validate if the certificate received was with the issuer configured
 	// duration. If not we generate an event to warn the user of that fact.
@@ -80,10 +88,34 @@ func (o IssuerOptions) CalculateDurationUntilRenew(ctx context.Context, cert *x5
 		renewBefore = certDuration / 3
 	}
 
-	// calculate the amount of time until expiry
-	durationUntilExpiry := cert.NotAfter.Sub(now())
-	// calculate how long until we should start attempting to renew the certificate
-	renewIn := durationUntilExpiry - renewBefore
+	// calculate when we should start attempting to renew the certificate
+	renewalTime := cert.NotAfter.Add(-renewBefore)
+
+	// If the ACME server that issued the certificate suggests renewing it
+	// earlier, e.g. because it is going to be revoked, renew it within the
+	// suggested window instead.
+	if w := crt.Status.SuggestedRenewalWindow; w != nil && w.Start != nil && w.End != nil && w.SerialNumber == fmt.Sprintf("%X", cert.SerialNumber) {
+		if windowRenewalTime := renewalWindowTime(w); windowRenewalTime.Before(renewalTime) {
+			log.V(logs.DebugLevel).Info("renewing certificate within the renewal window suggested by the ACME server", "window_start", w.Start, "window_end", w.End)
+			renewalTime = windowRenewalTime
+		}
+	}
+
+	return renewalTime
+}
+
+// renewalWindowTime returns the time within a suggested renewal window at
+// which the certificate should be renewed. The time is picked from the
+// certificate's serial number, so that the renewals of many certificates are
+// spread across the window without the time changing on every resync.
+func renewalWindowTime(w *cmapi.CertificateRenewalWindow) time.Time {
+	window := w.End.Sub(w.Start.Time)
+	if window <= 0 {
+		return w.Start.Time
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(w.SerialNumber))
 
-	return renewIn
+	return w.Start.Add(time.Duration(h.Sum64() % uint64(window)))
 }
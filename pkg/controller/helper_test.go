@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"crypto/x509"
+	"math/big"
 	"testing"
 	"time"
 
@@ -40,6 +41,7 @@ func TestCalculateDurationUntilRenew(t *testing.T) {
 		notAfter       time.Time
 		duration       *metav1.Duration
 		renewBefore    *metav1.Duration
+		renewalWindow  *v1alpha2.CertificateRenewalWindow
 		expectedExpiry time.Duration
 	}{
 		{
@@ -99,6 +101,49 @@ func TestCalculateDurationUntilRenew(t *testing.T) {
 			renewBefore:    &metav1.Duration{Duration: time.Hour*2159 + time.Minute*50},
 			expectedExpiry: -time.Minute * 50,
 		},
+		{
+			desc:      "renew within an earlier renewal window suggested by the ACME server",
+			notBefore: now(),
+			notAfter:  now().Add(time.Hour * 24 * 90),
+			renewalWindow: &v1alpha2.CertificateRenewalWindow{
+				SerialNumber: "1A2B",
+				Start:        &metav1.Time{Time: now().Add(time.Hour * 24 * 10)},
+				End:          &metav1.Time{Time: now().Add(time.Hour*24*10 + 1)},
+			},
+			expectedExpiry: time.Hour * 24 * 10,
+		},
+		{
+			desc:      "ignore a renewal window later than the renewal time",
+			notBefore: now(),
+			notAfter:  now().Add(time.Hour * 24 * 90),
+			renewalWindow: &v1alpha2.CertificateRenewalWindow{
+				SerialNumber: "1A2B",
+				Start:        &metav1.Time{Time: now().Add(time.Hour * 24 * 70)},
+				End:          &metav1.Time{Time: now().Add(time.Hour * 24 * 72)},
+			},
+			expectedExpiry: time.Hour * 24 * 60,
+		},
+		{
+			desc:      "ignore a renewal window suggested for another certificate",
+			notBefore: now(),
+			notAfter:  now().Add(time.Hour * 24 * 90),
+			renewalWindow: &v1alpha2.CertificateRenewalWindow{
+				SerialNumber: "3C4D",
+				Start:        &metav1.Time{Time: now().Add(time.Hour * 24 * 10)},
+				End:          &metav1.Time{Time: now().Add(time.Hour*24*10 + 1)},
+			},
+			expectedExpiry: time.Hour * 24 * 60,
+		},
+		{
+			desc:      "ignore a renewal window that only records the next check time",
+			notBefore: now(),
+			notAfter:  now().Add(time.Hour * 24 * 90),
+			renewalWindow: &v1alpha2.CertificateRenewalWindow{
+				SerialNumber:  "1A2B",
+				NextCheckTime: &metav1.Time{Time: now().Add(time.Hour * 6)},
+			},
+			expectedExpiry: time.Hour * 24 * 60,
+		},
 	}
 	for k, v := range tests {
 		cert := &v1alpha2.Certificate{
@@ -106,8 +151,11 @@ func TestCalculateDurationUntilRenew(t *testing.T) {
 				Duration:    v.duration,
 				RenewBefore: v.renewBefore,
 			},
+			Status: v1alpha2.CertificateStatus{
+				SuggestedRenewalWindow: v.renewalWindow,
+			},
 		}
-		x509Cert := &x509.Certificate{NotBefore: v.notBefore, NotAfter: v.notAfter}
+		x509Cert := &x509.Certificate{SerialNumber: big.NewInt(0x1a2b), NotBefore: v.notBefore, NotAfter: v.notAfter}
 		duration := c.CalculateDurationUntilRenew(context.Background(), x509Cert, cert)
 		if duration != v.expectedExpiry {
 			t.Errorf("test # %d - %s: got %v, expected %v", k, v.desc, duration, v.expectedExpiry)
@@ -19,21 +19,26 @@ package controller
 import (
 	"fmt"
 
-	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 )
 
-func (c *controller) ingressesForCertificate(crt *v1alpha2.Certificate) ([]*extv1beta1.Ingress, error) {
+func (c *controller) ingressesForCertificate(crt *v1alpha2.Certificate) ([]metav1.Object, error) {
+	if c.networkingIngressLister != nil {
+		return controllersOfCertificate(c.networkingIngressLister, crt)
+	}
+
 	ings, err := c.ingressLister.List(labels.NewSelector())
 
 	if err != nil {
 		return nil, fmt.Errorf("error listing certificiates: %s", err.Error())
 	}
 
-	var affected []*extv1beta1.Ingress
+	var affected []metav1.Object
 	for _, ing := range ings {
 		if crt.Namespace != ing.Namespace {
 			continue
@@ -46,3 +51,33 @@ func (c *controller) ingressesForCertificate(crt *v1alpha2.Certificate) ([]*extv
 
 	return affected, nil
 }
+
+func (c *controller) gatewaysForCertificate(crt *v1alpha2.Certificate) ([]metav1.Object, error) {
+	if c.gatewayLister == nil {
+		return nil, nil
+	}
+	return controllersOfCertificate(c.gatewayLister, crt)
+}
+
+// controllersOfCertificate returns the objects in the given lister that
+// control the Certificate.
+func controllersOfCertificate(lister cache.GenericLister, crt *v1alpha2.Certificate) ([]metav1.Object, error) {
+	objs, err := lister.ByNamespace(crt.Namespace).List(labels.NewSelector())
+	if err != nil {
+		return nil, fmt.Errorf("error listing resources: %s", err.Error())
+	}
+
+	var affected []metav1.Object
+	for _, obj := range objs {
+		o, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		if metav1.IsControlledBy(crt, o) {
+			affected = append(affected, o)
+		}
+	}
+
+	return affected, nil
+}
@@ -19,10 +19,16 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/kubernetes"
 	extlisters "k8s.io/client-go/listers/extensions/v1beta1"
 	"k8s.io/client-go/tools/cache"
@@ -39,6 +45,14 @@ import (
 
 const (
 	ControllerName = "ingress-shim"
+
+	// gatewayKeyPrefix is prepended to the workqueue keys of Gateways so
+	// that they can be told apart from Ingresses with the same name.
+	gatewayKeyPrefix = "gateway/"
+
+	// resyncPeriod is the resync period of the informers for resources that
+	// are watched using the dynamic client.
+	resyncPeriod = time.Second * 30
 )
 
 type defaults struct {
@@ -58,7 +72,13 @@ type controller struct {
 	cmClient clientset.Interface
 	recorder record.EventRecorder
 
-	ingressLister       extlisters.IngressLister
+	ingressLister extlisters.IngressLister
+	// networkingIngressLister is set instead of ingressLister if the API
+	// server serves networking.k8s.io/v1 Ingresses.
+	networkingIngressLister cache.GenericLister
+	// gatewayLister is set if the API server serves Gateway API Gateways.
+	gatewayLister cache.GenericLister
+
 	certificateLister   cmlisters.CertificateLister
 	issuerLister        cmlisters.IssuerLister
 	clusterIssuerLister cmlisters.ClusterIssuerLister
@@ -78,19 +98,55 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.queue = workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)
 
 	// obtain references to all the informers used by this controller
-	ingressInformer := ctx.KubeSharedInformerFactory.Extensions().V1beta1().Ingresses()
 	certificatesInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().Certificates()
 	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().Issuers()
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
-		ingressInformer.Informer().HasSynced,
 		certificatesInformer.Informer().HasSynced,
 		issuerInformer.Informer().HasSynced,
 	}
+	// informers created here rather than by a shared informer factory must
+	// be started by the caller of Register
+	var additionalInformers []controllerpkg.RunFunc
+
+	// networking.k8s.io/v1 Ingresses and Gateways are not available in the
+	// version of client-go we build against, so they are watched using the
+	// dynamic client. Extensions Ingresses are only watched on API servers
+	// that do not serve networking.k8s.io/v1, to avoid syncing every Ingress
+	// twice.
+	networkingIngressServed, err := resourceServed(ctx.Client.Discovery(), networkingIngressGVR)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if networkingIngressServed {
+		informer := dynamicinformer.NewFilteredDynamicInformer(ctx.DynamicClient, networkingIngressGVR, ctx.Namespace,
+			resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, nil)
+		informer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+		mustSync = append(mustSync, informer.Informer().HasSynced)
+		additionalInformers = append(additionalInformers, informer.Informer().Run)
+		c.networkingIngressLister = informer.Lister()
+	} else {
+		ingressInformer := ctx.KubeSharedInformerFactory.Extensions().V1beta1().Ingresses()
+		ingressInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+		mustSync = append(mustSync, ingressInformer.Informer().HasSynced)
+		c.ingressLister = ingressInformer.Lister()
+	}
+
+	gatewayServed, err := resourceServed(ctx.Client.Discovery(), gatewayGVR)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if gatewayServed {
+		informer := dynamicinformer.NewFilteredDynamicInformer(ctx.DynamicClient, gatewayGVR, ctx.Namespace,
+			resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, nil)
+		informer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.gatewayChanged})
+		mustSync = append(mustSync, informer.Informer().HasSynced)
+		additionalInformers = append(additionalInformers, informer.Informer().Run)
+		c.gatewayLister = informer.Lister()
+	}
 
 	// set all the references to the listers for used by the Sync function
-	c.ingressLister = ingressInformer.Lister()
 	c.certificateLister = certificatesInformer.Lister()
 	c.issuerLister = issuerInformer.Lister()
 
@@ -104,7 +160,6 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	}
 
 	// register handler functions
-	ingressInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
 	certificatesInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.certificateDeleted})
 
 	c.helper = issuer.NewHelper(c.issuerLister, c.clusterIssuerLister)
@@ -118,7 +173,36 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 		ctx.DefaultIssuerGroup,
 	}
 
-	return c.queue, mustSync, nil, nil
+	return c.queue, mustSync, additionalInformers, nil
+}
+
+// resourceServed returns true if the API server serves the given resource.
+// An error is returned if discovery fails for any reason other than the
+// group version not being served, as falling back to other resources could
+// leave the controller waiting on informers that never sync.
+func resourceServed(d discovery.DiscoveryInterface, gvr schema.GroupVersionResource) (bool, error) {
+	resources, err := d.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if k8sErrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to discover API resources for %s: %v", gvr.GroupVersion().String(), err)
+	}
+	for _, r := range resources.APIResources {
+		if r.Name == gvr.Resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *controller) gatewayChanged(obj interface{}) {
+	key, err := keyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.queue.Add(gatewayKeyPrefix + key)
 }
 
 func (c *controller) certificateDeleted(obj interface{}) {
@@ -140,15 +224,31 @@ func (c *controller) certificateDeleted(obj interface{}) {
 		}
 		c.queue.Add(key)
 	}
+	gws, err := c.gatewaysForCertificate(crt)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("Error looking up gateway observing certificate: %s/%s", crt.Namespace, crt.Name))
+		return
+	}
+	for _, gw := range gws {
+		c.gatewayChanged(gw)
+	}
 }
 
 func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	if strings.HasPrefix(key, gatewayKeyPrefix) {
+		return c.processGateway(ctx, strings.TrimPrefix(key, gatewayKeyPrefix))
+	}
+
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
 		runtime.HandleError(fmt.Errorf("invalid resource key: %s", key))
 		return nil
 	}
 
+	if c.networkingIngressLister != nil {
+		return c.processUnstructured(ctx, c.networkingIngressLister, "ingress", key, namespace, name, sourceForNetworkingIngress)
+	}
+
 	crt, err := c.ingressLister.Ingresses(namespace).Get(name)
 
 	if err != nil {
@@ -163,6 +263,46 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	return c.Sync(ctx, crt)
 }
 
+func (c *controller) processGateway(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("invalid resource key: %s", gatewayKeyPrefix+key))
+		return nil
+	}
+
+	return c.processUnstructured(ctx, c.gatewayLister, "gateway", key, namespace, name, sourceForGateway)
+}
+
+// processUnstructured syncs a resource that is watched using the dynamic
+// client, reading it with the given function.
+func (c *controller) processUnstructured(ctx context.Context, lister cache.GenericLister, kind, key, namespace, name string,
+	read func(*unstructured.Unstructured) (*tlsSource, error)) error {
+	obj, err := lister.ByNamespace(namespace).Get(name)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			runtime.HandleError(fmt.Errorf("%s '%s' in work queue no longer exists", kind, key))
+			return nil
+		}
+
+		return err
+	}
+
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("%s '%s' in work queue is not an unstructured object", kind, key))
+		return nil
+	}
+
+	src, err := read(u)
+	if err != nil {
+		// a malformed resource will not be fixed by retrying
+		runtime.HandleError(fmt.Errorf("failed to read %s '%s': %v", kind, key, err))
+		return nil
+	}
+
+	return c.sync(ctx, src)
+}
+
 var keyFunc = controllerpkg.KeyFunc
 
 func init() {
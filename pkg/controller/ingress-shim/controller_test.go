@@ -0,0 +1,81 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+)
+
+// fakeDiscovery returns the given resources or error for every group version.
+type fakeDiscovery struct {
+	discovery.DiscoveryInterface
+
+	resources *metav1.APIResourceList
+	err       error
+}
+
+func (f *fakeDiscovery) ServerResourcesForGroupVersion(string) (*metav1.APIResourceList, error) {
+	return f.resources, f.err
+}
+
+func TestResourceServed(t *testing.T) {
+	tests := map[string]struct {
+		resources *metav1.APIResourceList
+		err       error
+
+		expectedServed bool
+		expectedErr    bool
+	}{
+		"a served resource should be reported as served": {
+			resources: &metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Name: "ingressclasses"}, {Name: "ingresses"}},
+			},
+			expectedServed: true,
+		},
+		"a resource missing from a served group version should not be reported as served": {
+			resources: &metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Name: "ingressclasses"}},
+			},
+		},
+		"a group version that is not served should not be reported as served": {
+			err: k8sErrors.NewNotFound(networkingIngressGVR.GroupResource(), ""),
+		},
+		"other discovery errors should be returned": {
+			err:         errors.New("connection refused"),
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := &fakeDiscovery{resources: test.resources, err: test.err}
+
+			served, err := resourceServed(d, networkingIngressGVR)
+			if (err != nil) != test.expectedErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+			if served != test.expectedServed {
+				t.Errorf("unexpected result, exp=%t got=%t", test.expectedServed, served)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	ingressGVK           = extv1beta1.SchemeGroupVersion.WithKind("Ingress")
+	networkingIngressGVK = schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}
+	gatewayGVK           = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"}
+
+	networkingIngressGVR = networkingIngressGVK.GroupVersion().WithResource("ingresses")
+	gatewayGVR           = gatewayGVK.GroupVersion().WithResource("gateways")
+)
+
+// object is a resource that ingress-shim manages Certificates for.
+type object interface {
+	metav1.Object
+	runtime.Object
+}
+
+// tlsSource is a resource requesting TLS certificates, such as an Ingress or
+// a Gateway, in a form that does not depend on its API group or version.
+type tlsSource struct {
+	// obj is the resource itself. It is used as the controlling owner of the
+	// Certificates created for it and as the subject of events.
+	obj object
+	gvk schema.GroupVersionKind
+
+	// ingressClassName is the spec.ingressClassName of a networking.k8s.io/v1
+	// Ingress, if set.
+	ingressClassName string
+
+	tls []tlsEntry
+
+	// errs holds problems found while reading the resource that should be
+	// reported to the user as a BadConfig event.
+	errs []error
+}
+
+// tlsEntry is a set of hosts that should be served using a single Secret.
+type tlsEntry struct {
+	hosts      []string
+	secretName string
+}
+
+// isIngress returns true if the source is an Ingress of any API version.
+func (s *tlsSource) isIngress() bool {
+	return s.gvk.Kind == ingressGVK.Kind
+}
+
+func sourceForIngress(ing *extv1beta1.Ingress) *tlsSource {
+	src := &tlsSource{obj: ing, gvk: ingressGVK}
+	for _, tls := range ing.Spec.TLS {
+		src.tls = append(src.tls, tlsEntry{hosts: tls.Hosts, secretName: tls.SecretName})
+	}
+	return src
+}
+
+// sourceForNetworkingIngress reads a networking.k8s.io/v1 Ingress. The typed
+// API is not available in the version of client-go we build against, so the
+// resource is handled as unstructured data.
+func sourceForNetworkingIngress(ing *unstructured.Unstructured) (*tlsSource, error) {
+	src := &tlsSource{obj: ing, gvk: networkingIngressGVK}
+
+	className, _, err := unstructured.NestedString(ing.Object, "spec", "ingressClassName")
+	if err != nil {
+		return nil, err
+	}
+	src.ingressClassName = className
+
+	tlsList, _, err := unstructured.NestedSlice(ing.Object, "spec", "tls")
+	if err != nil {
+		return nil, err
+	}
+	for i, t := range tlsList {
+		tls, ok := t.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("spec.tls[%d] is not an object", i)
+		}
+		hosts, _, err := unstructured.NestedStringSlice(tls, "hosts")
+		if err != nil {
+			return nil, err
+		}
+		secretName, _, err := unstructured.NestedString(tls, "secretName")
+		if err != nil {
+			return nil, err
+		}
+		src.tls = append(src.tls, tlsEntry{hosts: hosts, secretName: secretName})
+	}
+
+	return src, nil
+}
+
+// sourceForGateway reads a gateway.networking.k8s.io/v1 Gateway. A
+// Certificate is requested for each Secret referenced by a listener that
+// terminates TLS, covering the hostnames of all listeners using that Secret.
+func sourceForGateway(gw *unstructured.Unstructured) (*tlsSource, error) {
+	src := &tlsSource{obj: gw, gvk: gatewayGVK}
+
+	listeners, _, err := unstructured.NestedSlice(gw.Object, "spec", "listeners")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]int)
+	for i, l := range listeners {
+		listener, ok := l.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("spec.listeners[%d] is not an object", i)
+		}
+		name, _, err := unstructured.NestedString(listener, "name")
+		if err != nil {
+			return nil, err
+		}
+		tls, hasTLS, err := unstructured.NestedMap(listener, "tls")
+		if err != nil {
+			return nil, err
+		}
+		if !hasTLS {
+			continue
+		}
+		mode, _, err := unstructured.NestedString(tls, "mode")
+		if err != nil {
+			return nil, err
+		}
+		// Passthrough listeners never see the certificate, so there is
+		// nothing for us to manage.
+		if mode != "" && mode != "Terminate" {
+			continue
+		}
+
+		hostname, _, err := unstructured.NestedString(listener, "hostname")
+		if err != nil {
+			return nil, err
+		}
+
+		refs, _, err := unstructured.NestedSlice(tls, "certificateRefs")
+		if err != nil {
+			return nil, err
+		}
+		for j, r := range refs {
+			ref, ok := r.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("spec.listeners[%d].tls.certificateRefs[%d] is not an object", i, j)
+			}
+			secretName, err := secretNameForCertificateRef(gw.GetNamespace(), ref)
+			if err != nil {
+				src.errs = append(src.errs, fmt.Errorf("listener %q: %v", name, err))
+				continue
+			}
+			if hostname == "" {
+				src.errs = append(src.errs, fmt.Errorf("listener %q referencing Secret %q must specify a hostname", name, secretName))
+				continue
+			}
+
+			idx, ok := entries[secretName]
+			if !ok {
+				idx = len(src.tls)
+				entries[secretName] = idx
+				src.tls = append(src.tls, tlsEntry{secretName: secretName})
+			}
+			if !containsString(src.tls[idx].hosts, hostname) {
+				src.tls[idx].hosts = append(src.tls[idx].hosts, hostname)
+			}
+		}
+	}
+
+	return src, nil
+}
+
+// secretNameForCertificateRef returns the name of the Secret referenced by a
+// Gateway listener's certificateRef. Only Secrets in the same namespace as the
+// Gateway are supported.
+func secretNameForCertificateRef(namespace string, ref map[string]interface{}) (string, error) {
+	group, _, err := unstructured.NestedString(ref, "group")
+	if err != nil {
+		return "", err
+	}
+	kind, _, err := unstructured.NestedString(ref, "kind")
+	if err != nil {
+		return "", err
+	}
+	name, _, err := unstructured.NestedString(ref, "name")
+	if err != nil {
+		return "", err
+	}
+	refNamespace, _, err := unstructured.NestedString(ref, "namespace")
+	if err != nil {
+		return "", err
+	}
+
+	if group != "" || (kind != "" && kind != "Secret") {
+		return "", fmt.Errorf("certificateRef %q must refer to a core Secret", name)
+	}
+	if refNamespace != "" && refNamespace != namespace {
+		return "", fmt.Errorf("certificateRef %q must refer to a Secret in namespace %q", name, namespace)
+	}
+	if name == "" {
+		return "", fmt.Errorf("certificateRef must specify a name")
+	}
+
+	return name, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
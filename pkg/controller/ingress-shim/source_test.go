@@ -0,0 +1,194 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestSourceForNetworkingIngress(t *testing.T) {
+	tests := map[string]struct {
+		spec                     map[string]interface{}
+		expectedIngressClassName string
+		expectedTLS              []tlsEntry
+		expectErr                bool
+	}{
+		"ingress without TLS": {
+			spec: map[string]interface{}{},
+		},
+		"ingress with class name and TLS entries": {
+			spec: map[string]interface{}{
+				"ingressClassName": "nginx",
+				"tls": []interface{}{
+					map[string]interface{}{
+						"hosts":      []interface{}{"example.com", "www.example.com"},
+						"secretName": "example-com-tls",
+					},
+					map[string]interface{}{
+						"hosts": []interface{}{"foo.example.com"},
+					},
+				},
+			},
+			expectedIngressClassName: "nginx",
+			expectedTLS: []tlsEntry{
+				{hosts: []string{"example.com", "www.example.com"}, secretName: "example-com-tls"},
+				{hosts: []string{"foo.example.com"}},
+			},
+		},
+		"malformed TLS entry": {
+			spec: map[string]interface{}{
+				"tls": []interface{}{"example.com"},
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			src, err := sourceForNetworkingIngress(buildNetworkingIngress("ingress-name", "default-unit-test-ns", nil, test.spec))
+			if test.expectErr != (err != nil) {
+				t.Fatalf("expected error=%t but got: %v", test.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if src.gvk != networkingIngressGVK {
+				t.Errorf("unexpected gvk: %v", src.gvk)
+			}
+			if src.ingressClassName != test.expectedIngressClassName {
+				t.Errorf("expected ingressClassName %q but got %q", test.expectedIngressClassName, src.ingressClassName)
+			}
+			if !reflect.DeepEqual(src.tls, test.expectedTLS) {
+				t.Errorf("expected TLS entries %+v but got %+v", test.expectedTLS, src.tls)
+			}
+		})
+	}
+}
+
+func TestSourceForGateway(t *testing.T) {
+	tests := map[string]struct {
+		listeners    []interface{}
+		expectedTLS  []tlsEntry
+		expectedErrs []error
+	}{
+		"listeners without TLS are ignored": {
+			listeners: []interface{}{
+				map[string]interface{}{
+					"name":     "http",
+					"hostname": "example.com",
+					"protocol": "HTTP",
+				},
+			},
+		},
+		"passthrough listeners are ignored": {
+			listeners: []interface{}{
+				buildListener("tls", "example.com", "Passthrough", map[string]interface{}{"name": "example-com-tls"}),
+			},
+		},
+		"listeners sharing a Secret are combined": {
+			listeners: []interface{}{
+				buildListener("https", "example.com", "", map[string]interface{}{"name": "example-com-tls"}),
+				buildListener("https-www", "www.example.com", "Terminate", map[string]interface{}{"kind": "Secret", "name": "example-com-tls"}),
+				buildListener("https-www-again", "www.example.com", "Terminate", map[string]interface{}{"name": "example-com-tls"}),
+				buildListener("https-foo", "foo.example.com", "", map[string]interface{}{"name": "foo-example-com-tls", "namespace": "default-unit-test-ns"}),
+			},
+			expectedTLS: []tlsEntry{
+				{hosts: []string{"example.com", "www.example.com"}, secretName: "example-com-tls"},
+				{hosts: []string{"foo.example.com"}, secretName: "foo-example-com-tls"},
+			},
+		},
+		"invalid listeners are reported": {
+			listeners: []interface{}{
+				buildListener("no-hostname", "", "", map[string]interface{}{"name": "example-com-tls"}),
+				buildListener("other-namespace", "example.com", "", map[string]interface{}{"name": "example-com-tls", "namespace": "other"}),
+				buildListener("not-a-secret", "example.com", "", map[string]interface{}{"group": "example.io", "kind": "Vault", "name": "example-com-tls"}),
+			},
+			expectedErrs: []error{
+				errors.New(`listener "no-hostname" referencing Secret "example-com-tls" must specify a hostname`),
+				errors.New(`listener "other-namespace": certificateRef "example-com-tls" must refer to a Secret in namespace "default-unit-test-ns"`),
+				errors.New(`listener "not-a-secret": certificateRef "example-com-tls" must refer to a core Secret`),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			src, err := sourceForGateway(buildGateway("gateway-name", "default-unit-test-ns", nil, test.listeners...))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if src.gvk != gatewayGVK {
+				t.Errorf("unexpected gvk: %v", src.gvk)
+			}
+			if !reflect.DeepEqual(src.tls, test.expectedTLS) {
+				t.Errorf("expected TLS entries %+v but got %+v", test.expectedTLS, src.tls)
+			}
+			if !reflect.DeepEqual(src.errs, test.expectedErrs) {
+				t.Errorf("expected errors %v but got %v", test.expectedErrs, src.errs)
+			}
+		})
+	}
+}
+
+func buildNetworkingIngress(name, namespace string, annotations map[string]string, spec map[string]interface{}) *unstructured.Unstructured {
+	ing := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	ing.SetGroupVersionKind(networkingIngressGVK)
+	ing.SetName(name)
+	ing.SetNamespace(namespace)
+	ing.SetUID(types.UID(name))
+	ing.SetAnnotations(annotations)
+	return ing
+}
+
+func buildGateway(name, namespace string, annotations map[string]string, listeners ...interface{}) *unstructured.Unstructured {
+	gw := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"gatewayClassName": "example",
+			"listeners":        listeners,
+		},
+	}}
+	gw.SetGroupVersionKind(gatewayGVK)
+	gw.SetName(name)
+	gw.SetNamespace(namespace)
+	gw.SetUID(types.UID(name))
+	gw.SetAnnotations(annotations)
+	return gw
+}
+
+func buildListener(name, hostname, mode string, certificateRefs ...interface{}) map[string]interface{} {
+	tls := map[string]interface{}{
+		"certificateRefs": certificateRefs,
+	}
+	if mode != "" {
+		tls["mode"] = mode
+	}
+	listener := map[string]interface{}{
+		"name":     name,
+		"port":     int64(443),
+		"protocol": "HTTPS",
+		"tls":      tls,
+	}
+	if hostname != "" {
+		listener["hostname"] = hostname
+	}
+	return listener
+}
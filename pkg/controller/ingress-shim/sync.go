@@ -38,39 +38,44 @@ import (
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
-var ingressGVK = extv1beta1.SchemeGroupVersion.WithKind("Ingress")
-
+// Sync creates, updates and deletes the Certificates requested by an
+// extensions/v1beta1 Ingress.
 func (c *controller) Sync(ctx context.Context, ing *extv1beta1.Ingress) error {
-	log := logs.WithResource(logs.FromContext(ctx), ing)
+	return c.sync(ctx, sourceForIngress(ing))
+}
+
+func (c *controller) sync(ctx context.Context, src *tlsSource) error {
+	log := logs.WithResource(logs.FromContext(ctx), src.obj)
 	ctx = logs.NewContext(ctx, log)
+	kind := strings.ToLower(src.gvk.Kind)
 
 	metrics.Default.IncrementSyncCallCount(ControllerName)
 
-	if !shouldSync(ing, c.defaults.autoCertificateAnnotations) {
-		log.Info(fmt.Sprintf("not syncing ingress resource as it does not contain a %q or %q annotation",
-			cmapi.IngressIssuerNameAnnotationKey, cmapi.IngressClusterIssuerNameAnnotationKey))
+	if !shouldSync(src.obj, c.defaults.autoCertificateAnnotations) {
+		log.Info(fmt.Sprintf("not syncing %s resource as it does not contain a %q or %q annotation",
+			kind, cmapi.IngressIssuerNameAnnotationKey, cmapi.IngressClusterIssuerNameAnnotationKey))
 		return nil
 	}
 
-	issuerName, issuerKind, issuerGroup, err := c.issuerForIngress(ing)
+	issuerName, issuerKind, issuerGroup, err := c.issuerForIngress(src.obj)
 	if err != nil {
-		log.Error(err, "failed to determine issuer to be used for ingress resource")
-		c.recorder.Eventf(ing, corev1.EventTypeWarning, "BadConfig", "Could not determine issuer for ingress due to bad annotations: %s",
-			err)
+		log.Error(err, "failed to determine issuer to be used for "+kind+" resource")
+		c.recorder.Eventf(src.obj, corev1.EventTypeWarning, "BadConfig", "Could not determine issuer for %s due to bad annotations: %s",
+			kind, err)
 		return nil
 	}
 
-	errs := c.validateIngress(ing)
+	errs := c.validateSource(src)
 	if len(errs) > 0 {
 		errMsg := errs[0].Error()
 		if len(errs) > 1 {
 			errMsg = utilerrors.NewAggregate(errs).Error()
 		}
-		c.recorder.Eventf(ing, corev1.EventTypeWarning, "BadConfig", errMsg)
+		c.recorder.Eventf(src.obj, corev1.EventTypeWarning, "BadConfig", errMsg)
 		return nil
 	}
 
-	newCrts, updateCrts, err := c.buildCertificates(ctx, ing, issuerName, issuerKind, issuerGroup)
+	newCrts, updateCrts, err := c.buildCertificates(ctx, src, issuerName, issuerKind, issuerGroup)
 	if err != nil {
 		return err
 	}
@@ -80,7 +85,7 @@ func (c *controller) Sync(ctx context.Context, ing *extv1beta1.Ingress) error {
 		if err != nil {
 			return err
 		}
-		c.recorder.Eventf(ing, corev1.EventTypeNormal, "CreateCertificate", "Successfully created Certificate %q", crt.Name)
+		c.recorder.Eventf(src.obj, corev1.EventTypeNormal, "CreateCertificate", "Successfully created Certificate %q", crt.Name)
 	}
 
 	for _, crt := range updateCrts {
@@ -88,10 +93,10 @@ func (c *controller) Sync(ctx context.Context, ing *extv1beta1.Ingress) error {
 		if err != nil {
 			return err
 		}
-		c.recorder.Eventf(ing, corev1.EventTypeNormal, "UpdateCertificate", "Successfully updated Certificate %q", crt.Name)
+		c.recorder.Eventf(src.obj, corev1.EventTypeNormal, "UpdateCertificate", "Successfully updated Certificate %q", crt.Name)
 	}
 
-	unrequiredCrts, err := c.findUnrequiredCertificates(ing)
+	unrequiredCrts, err := c.findUnrequiredCertificates(src)
 	if err != nil {
 		return err
 	}
@@ -101,48 +106,51 @@ func (c *controller) Sync(ctx context.Context, ing *extv1beta1.Ingress) error {
 		if err != nil {
 			return err
 		}
-		c.recorder.Eventf(ing, corev1.EventTypeNormal, "DeleteCertificate", "Successfully deleted unrequired Certificate %q", crt.Name)
+		c.recorder.Eventf(src.obj, corev1.EventTypeNormal, "DeleteCertificate", "Successfully deleted unrequired Certificate %q", crt.Name)
 	}
 
 	return nil
 }
 
-func (c *controller) validateIngress(ing *extv1beta1.Ingress) []error {
-	var errs []error
-	for i, tls := range ing.Spec.TLS {
-		// validate the ingress TLS block
-		if len(tls.Hosts) == 0 {
-			errs = append(errs, fmt.Errorf("Secret %q for ingress TLS has no hosts specified", tls.SecretName))
+func (c *controller) validateSource(src *tlsSource) []error {
+	errs := append([]error(nil), src.errs...)
+	kind := strings.ToLower(src.gvk.Kind)
+	for i, tls := range src.tls {
+		// validate the TLS block
+		if len(tls.hosts) == 0 {
+			errs = append(errs, fmt.Errorf("Secret %q for %s TLS has no hosts specified", tls.secretName, kind))
 		}
-		if tls.SecretName == "" {
-			errs = append(errs, fmt.Errorf("TLS entry %d for hosts %v must specify a secretName", i, tls.Hosts))
+		if tls.secretName == "" {
+			errs = append(errs, fmt.Errorf("TLS entry %d for hosts %v must specify a secretName", i, tls.hosts))
 		}
 	}
 	return errs
 }
 
-func (c *controller) buildCertificates(ctx context.Context, ing *extv1beta1.Ingress,
+func (c *controller) buildCertificates(ctx context.Context, src *tlsSource,
 	issuerName, issuerKind, issuerGroup string) (new, update []*cmapi.Certificate, _ error) {
 	log := logs.FromContext(ctx)
+	kind := strings.ToLower(src.gvk.Kind)
+	namespace := src.obj.GetNamespace()
 
 	var newCrts []*cmapi.Certificate
 	var updateCrts []*cmapi.Certificate
-	for _, tls := range ing.Spec.TLS {
-		existingCrt, err := c.certificateLister.Certificates(ing.Namespace).Get(tls.SecretName)
+	for _, tls := range src.tls {
+		existingCrt, err := c.certificateLister.Certificates(namespace).Get(tls.secretName)
 		if !apierrors.IsNotFound(err) && err != nil {
 			return nil, nil, err
 		}
 
 		crt := &cmapi.Certificate{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:            tls.SecretName,
-				Namespace:       ing.Namespace,
-				Labels:          ing.Labels,
-				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ing, ingressGVK)},
+				Name:            tls.secretName,
+				Namespace:       namespace,
+				Labels:          src.obj.GetLabels(),
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(src.obj, src.gvk)},
 			},
 			Spec: cmapi.CertificateSpec{
-				DNSNames:   tls.Hosts,
-				SecretName: tls.SecretName,
+				DNSNames:   tls.hosts,
+				SecretName: tls.secretName,
 				IssuerRef: cmmeta.ObjectReference{
 					Name:  issuerName,
 					Kind:  issuerKind,
@@ -151,7 +159,7 @@ func (c *controller) buildCertificates(ctx context.Context, ing *extv1beta1.Ingr
 			},
 		}
 
-		err = c.setIssuerSpecificConfig(crt, ing, tls)
+		err = c.setIssuerSpecificConfig(crt, src)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -160,33 +168,33 @@ func (c *controller) buildCertificates(ctx context.Context, ing *extv1beta1.Ingr
 		// does then skip this entry
 		if existingCrt != nil {
 			log := logs.WithRelatedResource(log, existingCrt)
-			log.Info("certificate already exists for ingress resource, ensuring it is up to date")
+			log.Info("certificate already exists for " + kind + " resource, ensuring it is up to date")
 
 			if metav1.GetControllerOf(existingCrt) == nil {
-				log.Info("certificate resource has no owner. refusing to update non-owned certificate resource for ingress")
+				log.Info("certificate resource has no owner. refusing to update non-owned certificate resource for " + kind)
 				continue
 			}
 
-			if !metav1.IsControlledBy(existingCrt, ing) {
-				log.Info("certificate resource is not owned by this ingress. refusing to update non-owned certificate resource for ingress")
+			if !metav1.IsControlledBy(existingCrt, src.obj) {
+				log.Info("certificate resource is not owned by this " + kind + ". refusing to update non-owned certificate resource for " + kind)
 				continue
 			}
 
 			if !certNeedsUpdate(existingCrt, crt) {
-				log.Info("certificate resource is already up to date for ingress")
+				log.Info("certificate resource is already up to date for " + kind)
 				continue
 			}
 
 			updateCrt := existingCrt.DeepCopy()
 
-			updateCrt.Spec.DNSNames = tls.Hosts
-			updateCrt.Spec.SecretName = tls.SecretName
+			updateCrt.Spec.DNSNames = tls.hosts
+			updateCrt.Spec.SecretName = tls.secretName
 			updateCrt.Spec.IssuerRef.Name = issuerName
 			updateCrt.Spec.IssuerRef.Kind = issuerKind
 			updateCrt.Spec.IssuerRef.Group = issuerGroup
 			updateCrt.Spec.CommonName = ""
-			updateCrt.Labels = ing.Labels
-			err = c.setIssuerSpecificConfig(updateCrt, ing, tls)
+			updateCrt.Labels = src.obj.GetLabels()
+			err = c.setIssuerSpecificConfig(updateCrt, src)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -198,16 +206,16 @@ func (c *controller) buildCertificates(ctx context.Context, ing *extv1beta1.Ingr
 	return newCrts, updateCrts, nil
 }
 
-func (c *controller) findUnrequiredCertificates(ing *extv1beta1.Ingress) ([]*cmapi.Certificate, error) {
+func (c *controller) findUnrequiredCertificates(src *tlsSource) ([]*cmapi.Certificate, error) {
 	var unrequired []*cmapi.Certificate
-	// TODO: investigate selector which filters for certificates controlled by the ingress
-	crts, err := c.certificateLister.Certificates(ing.Namespace).List(labels.Everything())
+	// TODO: investigate selector which filters for certificates controlled by the source
+	crts, err := c.certificateLister.Certificates(src.obj.GetNamespace()).List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 
 	for _, crt := range crts {
-		if isUnrequiredCertificate(crt, ing) {
+		if isUnrequiredCertificate(crt, src) {
 			unrequired = append(unrequired, crt)
 		}
 	}
@@ -215,13 +223,13 @@ func (c *controller) findUnrequiredCertificates(ing *extv1beta1.Ingress) ([]*cma
 	return unrequired, nil
 }
 
-func isUnrequiredCertificate(crt *cmapi.Certificate, ing *extv1beta1.Ingress) bool {
-	if !metav1.IsControlledBy(crt, ing) {
+func isUnrequiredCertificate(crt *cmapi.Certificate, src *tlsSource) bool {
+	if !metav1.IsControlledBy(crt, src.obj) {
 		return false
 	}
 
-	for _, tls := range ing.Spec.TLS {
-		if crt.Spec.SecretName == tls.SecretName {
+	for _, tls := range src.tls {
+		if crt.Spec.SecretName == tls.secretName {
 			return false
 		}
 	}
@@ -271,26 +279,31 @@ func certNeedsUpdate(a, b *cmapi.Certificate) bool {
 	return false
 }
 
-func (c *controller) setIssuerSpecificConfig(crt *cmapi.Certificate, ing *extv1beta1.Ingress, tls extv1beta1.IngressTLS) error {
-	ingAnnotations := ing.Annotations
+func (c *controller) setIssuerSpecificConfig(crt *cmapi.Certificate, src *tlsSource) error {
+	ingAnnotations := src.obj.GetAnnotations()
 	if ingAnnotations == nil {
 		ingAnnotations = map[string]string{}
 	}
 
 	// for ACME issuers
 	editInPlaceVal, _ := ingAnnotations[cmacme.IngressEditInPlaceAnnotationKey]
-	editInPlace := editInPlaceVal == "true"
+	editInPlace := editInPlaceVal == "true" && src.isIngress()
 	if editInPlace {
 		if crt.Annotations == nil {
 			crt.Annotations = make(map[string]string)
 		}
-		crt.Annotations[cmacme.ACMECertificateHTTP01IngressNameOverride] = ing.Name
+		crt.Annotations[cmacme.ACMECertificateHTTP01IngressNameOverride] = src.obj.GetName()
 		// set IssueTemporaryCertificateAnnotation to true in order to behave
 		// better when ingress-gce is being used.
 		crt.Annotations[cmapi.IssueTemporaryCertificateAnnotation] = "true"
 	}
 
 	ingressClassVal, hasIngressClassVal := ingAnnotations[cmapi.IngressACMEIssuerHTTP01IngressClassAnnotationKey]
+	// fall back to the class of a networking.k8s.io/v1 Ingress so that the
+	// HTTP01 solver is served by the same ingress controller
+	if !hasIngressClassVal && !editInPlace && src.ingressClassName != "" {
+		ingressClassVal, hasIngressClassVal = src.ingressClassName, true
+	}
 	if hasIngressClassVal {
 		if crt.Annotations == nil {
 			crt.Annotations = make(map[string]string)
@@ -301,10 +314,10 @@ func (c *controller) setIssuerSpecificConfig(crt *cmapi.Certificate, ing *extv1b
 	return nil
 }
 
-// shouldSync returns true if this ingress should have a Certificate resource
-// created for it
-func shouldSync(ing *extv1beta1.Ingress, autoCertificateAnnotations []string) bool {
-	annotations := ing.Annotations
+// shouldSync returns true if this ingress or gateway should have a
+// Certificate resource created for it
+func shouldSync(obj metav1.Object, autoCertificateAnnotations []string) bool {
+	annotations := obj.GetAnnotations()
 	if annotations == nil {
 		annotations = map[string]string{}
 	}
@@ -325,15 +338,15 @@ func shouldSync(ing *extv1beta1.Ingress, autoCertificateAnnotations []string) bo
 }
 
 // issuerForIngress will determine the issuer that should be specified on a
-// Certificate created for the given Ingress resource. If one is not set, the
-// default issuer given to the controller will be used.
-func (c *controller) issuerForIngress(ing *extv1beta1.Ingress) (name, kind, group string, err error) {
+// Certificate created for the given Ingress or Gateway resource. If one is not
+// set, the default issuer given to the controller will be used.
+func (c *controller) issuerForIngress(obj metav1.Object) (name, kind, group string, err error) {
 	var errs []string
 
 	name = c.defaults.issuerName
 	kind = c.defaults.issuerKind
 	group = c.defaults.issuerGroup
-	annotations := ing.Annotations
+	annotations := obj.GetAnnotations()
 
 	if annotations == nil {
 		annotations = map[string]string{}
@@ -24,7 +24,9 @@ import (
 
 	extv1beta1 "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	types "k8s.io/apimachinery/pkg/types"
 	coretesting "k8s.io/client-go/testing"
 
@@ -84,8 +86,11 @@ func TestSync(t *testing.T) {
 	acmeClusterIssuer := gen.ClusterIssuer("issuer-name",
 		gen.SetIssuerACME(cmacme.ACMEIssuer{}))
 	type testT struct {
-		Name                string
-		Ingress             *extv1beta1.Ingress
+		Name    string
+		Ingress *extv1beta1.Ingress
+		// Object is a networking.k8s.io/v1 Ingress or Gateway to be synced
+		// instead of Ingress
+		Object              *unstructured.Unstructured
 		Issuer              cmapi.GenericIssuer
 		IssuerLister        []runtime.Object
 		ClusterIssuerLister []runtime.Object
@@ -864,6 +869,168 @@ func TestSync(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:   "return a single HTTP01 Certificate for a networking.k8s.io/v1 ingress using its ingressClassName",
+			Issuer: acmeClusterIssuer,
+			Object: buildNetworkingIngress("ingress-name", gen.DefaultTestNamespace, map[string]string{
+				cmapi.IngressClusterIssuerNameAnnotationKey: "issuer-name",
+			}, map[string]interface{}{
+				"ingressClassName": "nginx",
+				"tls": []interface{}{
+					map[string]interface{}{
+						"hosts":      []interface{}{"example.com"},
+						"secretName": "example-com-tls",
+					},
+				},
+			}),
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+			ExpectedCreate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "example-com-tls",
+						Namespace: gen.DefaultTestNamespace,
+						Annotations: map[string]string{
+							cmacme.ACMECertificateHTTP01IngressClassOverride: "nginx",
+						},
+						OwnerReferences: buildOwnerReferencesForGVK("ingress-name", gen.DefaultTestNamespace, networkingIngressGVK),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com"},
+						SecretName: "example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "ClusterIssuer",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:   "prefer the HTTP01 ingress class annotation over the ingressClassName of a networking.k8s.io/v1 ingress",
+			Issuer: acmeClusterIssuer,
+			Object: buildNetworkingIngress("ingress-name", gen.DefaultTestNamespace, map[string]string{
+				cmapi.IngressClusterIssuerNameAnnotationKey:            "issuer-name",
+				cmapi.IngressACMEIssuerHTTP01IngressClassAnnotationKey: "cert-ing",
+			}, map[string]interface{}{
+				"ingressClassName": "nginx",
+				"tls": []interface{}{
+					map[string]interface{}{
+						"hosts":      []interface{}{"example.com"},
+						"secretName": "example-com-tls",
+					},
+				},
+			}),
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+			ExpectedCreate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "example-com-tls",
+						Namespace: gen.DefaultTestNamespace,
+						Annotations: map[string]string{
+							cmacme.ACMECertificateHTTP01IngressClassOverride: "cert-ing",
+						},
+						OwnerReferences: buildOwnerReferencesForGVK("ingress-name", gen.DefaultTestNamespace, networkingIngressGVK),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com"},
+						SecretName: "example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "ClusterIssuer",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:   "return a Certificate for each Secret referenced by the listeners of a gateway, ignoring edit-in-place",
+			Issuer: acmeClusterIssuer,
+			Object: buildGateway("gateway-name", gen.DefaultTestNamespace, map[string]string{
+				cmapi.IngressClusterIssuerNameAnnotationKey: "issuer-name",
+				cmacme.IngressEditInPlaceAnnotationKey:      "true",
+			},
+				buildListener("https", "example.com", "", map[string]interface{}{"name": "example-com-tls"}),
+				buildListener("https-www", "www.example.com", "", map[string]interface{}{"name": "example-com-tls"}),
+				buildListener("https-foo", "foo.example.com", "", map[string]interface{}{"name": "foo-example-com-tls"}),
+			),
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+			ExpectedCreate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildOwnerReferencesForGVK("gateway-name", gen.DefaultTestNamespace, gatewayGVK),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com", "www.example.com"},
+						SecretName: "example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "ClusterIssuer",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "foo-example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildOwnerReferencesForGVK("gateway-name", gen.DefaultTestNamespace, gatewayGVK),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"foo.example.com"},
+						SecretName: "foo-example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "ClusterIssuer",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:   "update and delete Certificates owned by a gateway",
+			Issuer: acmeIssuer,
+			Object: buildGateway("gateway-name", gen.DefaultTestNamespace, map[string]string{
+				cmapi.IngressIssuerNameAnnotationKey: "issuer-name",
+			},
+				buildListener("https", "example.com", "", map[string]interface{}{"name": "example-com-tls"}),
+			),
+			IssuerLister: []runtime.Object{acmeIssuer},
+			CertificateLister: []runtime.Object{
+				buildCertificate("example-com-tls", gen.DefaultTestNamespace, buildOwnerReferencesForGVK("gateway-name", gen.DefaultTestNamespace, gatewayGVK)),
+				buildCertificate("old-example-com-tls", gen.DefaultTestNamespace, buildOwnerReferencesForGVK("gateway-name", gen.DefaultTestNamespace, gatewayGVK)),
+			},
+			ExpectedUpdate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildOwnerReferencesForGVK("gateway-name", gen.DefaultTestNamespace, gatewayGVK),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com"},
+						SecretName: "example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+						},
+					},
+				},
+			},
+			ExpectedDelete: []*cmapi.Certificate{
+				buildCertificate("old-example-com-tls", gen.DefaultTestNamespace, buildOwnerReferencesForGVK("gateway-name", gen.DefaultTestNamespace, gatewayGVK)),
+			},
+		},
+		{
+			Name:   "not create Certificates for a gateway with an invalid listener",
+			Issuer: acmeIssuer,
+			Object: buildGateway("gateway-name", gen.DefaultTestNamespace, map[string]string{
+				cmapi.IngressIssuerNameAnnotationKey: "issuer-name",
+			},
+				buildListener("https", "example.com", "", map[string]interface{}{"name": "example-com-tls"}),
+				buildListener("https-any", "", "", map[string]interface{}{"name": "example-com-tls"}),
+			),
+			IssuerLister: []runtime.Object{acmeIssuer},
+		},
 	}
 	testFn := func(test testT) func(t *testing.T) {
 		return func(t *testing.T) {
@@ -922,7 +1089,20 @@ func TestSync(t *testing.T) {
 			}
 			b.Start()
 
-			err := c.Sync(context.Background(), test.Ingress)
+			var err error
+			if test.Object != nil {
+				read := sourceForNetworkingIngress
+				if test.Object.GroupVersionKind() == gatewayGVK {
+					read = sourceForGateway
+				}
+				src, readErr := read(test.Object)
+				if readErr != nil {
+					t.Fatalf("failed to read test object: %v", readErr)
+				}
+				err = c.sync(context.Background(), src)
+			} else {
+				err = c.Sync(context.Background(), test.Ingress)
+			}
 			if err != nil && !test.Err {
 				t.Errorf("Expected no error, but got: %s", err)
 			}
@@ -1080,7 +1260,11 @@ func buildIngress(name, namespace string, annotations map[string]string) *extv1b
 }
 
 func buildOwnerReferences(name, namespace string) []metav1.OwnerReference {
+	return buildOwnerReferencesForGVK(name, namespace, ingressGVK)
+}
+
+func buildOwnerReferencesForGVK(name, namespace string, gvk schema.GroupVersionKind) []metav1.OwnerReference {
 	return []metav1.OwnerReference{
-		*metav1.NewControllerRef(buildIngress(name, namespace, nil), ingressGVK),
+		*metav1.NewControllerRef(buildIngress(name, namespace, nil), gvk),
 	}
 }
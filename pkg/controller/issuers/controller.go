@@ -27,10 +27,13 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	vaultinternal "github.com/jetstack/cert-manager/pkg/internal/vault"
 	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/issuer/ca"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 )
 
@@ -127,18 +130,35 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
+	tokenCacheKey := vaultinternal.TokenCacheKey(v1alpha2.IssuerKind, namespace, name)
 	issuer, err := c.issuerLister.Issuers(namespace).Get(name)
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			log.Error(err, "issuer in work queue no longer exists")
+			vaultinternal.DefaultTokenCache.Revoke(tokenCacheKey)
 			return nil
 		}
 
 		return err
 	}
 
+	// revoke any Vault token cached for the issuer if it no longer uses Vault
+	if issuer.Spec.Vault == nil {
+		vaultinternal.DefaultTokenCache.Revoke(tokenCacheKey)
+	}
+
 	ctx = logf.NewContext(ctx, logf.WithResource(log, issuer))
-	return c.Sync(ctx, issuer)
+	if err := c.Sync(ctx, issuer); err != nil {
+		return err
+	}
+
+	// re-sync CA issuers maintaining a CRL periodically, so the CRL is
+	// re-signed before it expires
+	if issuer.Spec.CA != nil && issuer.Spec.CA.CRL != nil {
+		c.queue.AddAfter(key, ca.CRLResyncPeriod(issuer.Spec.CA.CRL))
+	}
+
+	return nil
 }
 
 var keyFunc = controllerpkg.KeyFunc
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	kubeinformers "k8s.io/client-go/informers"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 	coretesting "k8s.io/client-go/testing"
@@ -49,7 +50,8 @@ func init() {
 }
 
 // Builder is a structure used to construct new Contexts for use during tests.
-// Currently, only KubeObjects and CertManagerObjects can be specified.
+// Currently, only KubeObjects, CertManagerObjects and DynamicObjects can be
+// specified.
 // These will be auto loaded into the constructed fake Clientsets.
 // Call ToContext() to construct a new context using the given values.
 type Builder struct {
@@ -57,6 +59,7 @@ type Builder struct {
 
 	KubeObjects        []runtime.Object
 	CertManagerObjects []runtime.Object
+	DynamicObjects     []runtime.Object
 	ExpectedActions    []Action
 	ExpectedEvents     []string
 	StringGenerator    StringGenerator
@@ -112,10 +115,12 @@ func (b *Builder) Init() {
 	b.requiredReactors = make(map[string]bool)
 	b.Client = kubefake.NewSimpleClientset(b.KubeObjects...)
 	b.CMClient = cmfake.NewSimpleClientset(b.CertManagerObjects...)
+	b.DynamicClient = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), b.DynamicObjects...)
 	b.Recorder = new(FakeRecorder)
 
 	b.FakeKubeClient().PrependReactor("create", "*", b.generateNameReactor)
 	b.FakeCMClient().PrependReactor("create", "*", b.generateNameReactor)
+	b.FakeDynamicClient().PrependReactor("create", "*", b.generateNameReactor)
 	b.KubeSharedInformerFactory = kubeinformers.NewSharedInformerFactory(b.Client, informerResyncPeriod)
 	b.SharedInformerFactory = informers.NewSharedInformerFactory(b.CMClient, informerResyncPeriod)
 	b.stopCh = make(chan struct{})
@@ -143,6 +148,10 @@ func (b *Builder) FakeCMClient() *cmfake.Clientset {
 	return b.Context.CMClient.(*cmfake.Clientset)
 }
 
+func (b *Builder) FakeDynamicClient() *dynamicfake.FakeDynamicClient {
+	return b.Context.DynamicClient.(*dynamicfake.FakeDynamicClient)
+}
+
 func (b *Builder) FakeCMInformerFactory() informers.SharedInformerFactory {
 	return b.Context.SharedInformerFactory
 }
@@ -205,6 +214,7 @@ func (b *Builder) AllEventsCalled() error {
 func (b *Builder) AllActionsExecuted() error {
 	firedActions := b.FakeCMClient().Actions()
 	firedActions = append(firedActions, b.FakeKubeClient().Actions()...)
+	firedActions = append(firedActions, b.FakeDynamicClient().Actions()...)
 
 	var unexpectedActions []coretesting.Action
 	var errs []error
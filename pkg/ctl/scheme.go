@@ -0,0 +1,44 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ctl contains shared code used by the cmctl command line tool.
+package ctl
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+
+	acmeinstall "github.com/jetstack/cert-manager/pkg/internal/apis/acme/install"
+	cminstall "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager/install"
+	metainstall "github.com/jetstack/cert-manager/pkg/internal/apis/meta/install"
+)
+
+// Define a Scheme that has all cert-manager API types registered, including
+// the internal API version and conversion functions for all external
+// versions.
+// This scheme should *only* be used by cmctl, so that it can convert
+// resources between API versions without a connection to a cluster.
+
+var (
+	// Scheme is a Kubernetes runtime.Scheme with all internal and external API
+	// versions for cert-manager types registered.
+	Scheme = runtime.NewScheme()
+)
+
+func init() {
+	cminstall.Install(Scheme)
+	acmeinstall.Install(Scheme)
+	metainstall.Install(Scheme)
+}
@@ -29,6 +29,7 @@ import (
 func TestPruneTypes(t *testing.T) {
 	crdfuzz.SchemaFuzzTestForCRDWithPath(t, api.Scheme, crdPath("certificates"), cmfuzzer.Funcs)
 	crdfuzz.SchemaFuzzTestForCRDWithPath(t, api.Scheme, crdPath("certificaterequests"), cmfuzzer.Funcs)
+	crdfuzz.SchemaFuzzTestForCRDWithPath(t, api.Scheme, crdPath("certificaterequestpolicies"), cmfuzzer.Funcs)
 	crdfuzz.SchemaFuzzTestForCRDWithPath(t, api.Scheme, crdPath("issuers"), cmfuzzer.Funcs)
 	crdfuzz.SchemaFuzzTestForCRDWithPath(t, api.Scheme, crdPath("clusterissuers"), cmfuzzer.Funcs)
 }
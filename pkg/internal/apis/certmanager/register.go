@@ -47,6 +47,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ClusterIssuerList{},
 		&CertificateRequest{},
 		&CertificateRequestList{},
+		&CertificateRequestPolicy{},
+		&CertificateRequestPolicyList{},
 	)
 	return nil
 }
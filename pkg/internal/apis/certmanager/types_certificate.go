@@ -46,8 +46,9 @@ type CertificateList struct {
 type KeyAlgorithm string
 
 const (
-	RSAKeyAlgorithm   KeyAlgorithm = "rsa"
-	ECDSAKeyAlgorithm KeyAlgorithm = "ecdsa"
+	RSAKeyAlgorithm     KeyAlgorithm = "rsa"
+	ECDSAKeyAlgorithm   KeyAlgorithm = "ecdsa"
+	Ed25519KeyAlgorithm KeyAlgorithm = "ed25519"
 )
 
 type KeyEncoding string
@@ -100,6 +101,12 @@ type CertificateSpec struct {
 	// SecretName is the name of the secret resource to store this secret in
 	SecretName string `json:"secretName"`
 
+	// SecretTemplate defines annotations and labels to be copied to the
+	// Certificate's Secret. Labels and annotations on the Secret will be
+	// changed as they appear on the SecretTemplate when added or removed.
+	// +optional
+	SecretTemplate *CertificateSecretTemplate `json:"secretTemplate,omitempty"`
+
 	// IssuerRef is a reference to the issuer for this certificate.
 	// If the 'kind' field is not set, or set to 'Issuer', an Issuer resource
 	// with the given name in the same namespace as the Certificate will be used.
@@ -120,12 +127,14 @@ type CertificateSpec struct {
 	// KeySize is the key bit size of the corresponding private key for this certificate.
 	// If provided, value must be between 2048 and 8192 inclusive when KeyAlgorithm is
 	// empty or is set to "rsa", and value must be one of (256, 384, 521) when
-	// KeyAlgorithm is set to "ecdsa".
+	// KeyAlgorithm is set to "ecdsa". KeySize must not be provided when
+	// KeyAlgorithm is set to "ed25519".
 	// +optional
 	KeySize int `json:"keySize,omitempty"`
 
 	// KeyAlgorithm is the private key algorithm of the corresponding private key
-	// for this certificate. If provided, allowed values are either "rsa" or "ecdsa"
+	// for this certificate. If provided, allowed values are "rsa", "ecdsa" or
+	// "ed25519".
 	// If KeyAlgorithm is specified and KeySize is not provided,
 	// key size of 256 will be used for "ecdsa" key algorithm and
 	// key size of 2048 will be used for "rsa" key algorithm.
@@ -136,7 +145,150 @@ type CertificateSpec struct {
 	// for this certificate's private key to be encoded in. If provided, allowed
 	// values are "pkcs1" and "pkcs8" standing for PKCS#1 and PKCS#8, respectively.
 	// If KeyEncoding is not specified, then PKCS#1 will be used by default.
+	// As PKCS#1 is not defined for Ed25519 keys, they are always encoded
+	// using PKCS#8.
 	KeyEncoding KeyEncoding `json:"keyEncoding,omitempty"`
+
+	// Options to control private keys used for the Certificate.
+	// +optional
+	PrivateKey *CertificatePrivateKey `json:"privateKey,omitempty"`
+
+	// Keystores configures additional keystore output formats stored in the
+	// `secretName` Secret resource.
+	// +optional
+	Keystores *CertificateKeystores `json:"keystores,omitempty"`
+
+	// AdditionalOutputFormats defines extra output formats of the private key
+	// and signed certificate chain to be written to this Certificate's target
+	// Secret. The additional entries are updated whenever the issued
+	// certificate changes, and removed when no longer listed.
+	AdditionalOutputFormats []CertificateAdditionalOutputFormat `json:"additionalOutputFormats,omitempty"`
+
+	// RevisionHistoryLimit is the maximum number of CertificateRequests that
+	// issued a certificate for this Certificate to retain, including the one
+	// that issued the current certificate. If set, each CertificateRequest is
+	// named after the revision it issues, so that the requests for earlier
+	// revisions are not replaced on renewal. Must be at least 1 if set.
+	// If not set, CertificateRequests are deleted once they are no longer
+	// required.
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+}
+
+// CertificateOutputFormatType specifies which additional output formats
+// should be written to the Certificate's target Secret.
+type CertificateOutputFormatType string
+
+const (
+	// CertificateOutputFormatDER writes the private key in DER format to the
+	// `key.der` entry of the target Secret.
+	CertificateOutputFormatDER CertificateOutputFormatType = "DER"
+
+	// CertificateOutputFormatCombinedPEM writes the PEM encoded private key
+	// followed by the full PEM encoded certificate chain to the
+	// `tls-combined.pem` entry of the target Secret.
+	CertificateOutputFormatCombinedPEM CertificateOutputFormatType = "CombinedPEM"
+)
+
+// CertificateAdditionalOutputFormat defines an additional output format of a
+// Certificate resource. These contain supplementary data formats of the
+// signed certificate chain and paired private key.
+type CertificateAdditionalOutputFormat struct {
+	// Type is the name of the format type that should be written to the
+	// Certificate's target Secret.
+	Type CertificateOutputFormatType `json:"type"`
+}
+
+// CertificateSecretTemplate defines the default labels and annotations
+// to be copied to the Kubernetes Secret resource named in `CertificateSpec.secretName`.
+type CertificateSecretTemplate struct {
+	// Annotations is a key value map to be copied to the target Kubernetes Secret.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels is a key value map to be copied to the target Kubernetes Secret.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// CertificatePrivateKey contains configuration options for private keys
+// used by the Certificate controller.
+// This allows control of how private keys are rotated.
+type CertificatePrivateKey struct {
+	// RotationPolicy controls how private keys should be regenerated when a
+	// re-issuance is being processed.
+	// If set to Never, a private key will only be generated if one does not
+	// already exist in the target `spec.secretName`. If one does exist but it
+	// does not have the correct algorithm or size, it will be regenerated.
+	// If set to Always, a new private key matching the specified requirements
+	// will be generated for every new CertificateRequest, and will only be
+	// stored in the target `spec.secretName` once the new certificate has been
+	// issued.
+	// Defaults to Never if not specified.
+	// +optional
+	RotationPolicy PrivateKeyRotationPolicy `json:"rotationPolicy,omitempty"`
+}
+
+// Denotes how private keys should be generated or sourced when a Certificate
+// is being issued.
+type PrivateKeyRotationPolicy string
+
+const (
+	// RotationPolicyNever means a private key will only be generated if one
+	// does not already exist in the target `spec.secretName`.
+	RotationPolicyNever PrivateKeyRotationPolicy = "Never"
+
+	// RotationPolicyAlways means a private key matching the specified
+	// requirements will be generated whenever a re-issuance occurs.
+	RotationPolicyAlways PrivateKeyRotationPolicy = "Always"
+)
+
+// CertificateKeystores configures additional keystore output formats to be
+// created in the Certificate's output Secret.
+type CertificateKeystores struct {
+	// JKS configures options for storing a JKS keystore in the
+	// `spec.secretName` Secret resource.
+	// +optional
+	JKS *JKSKeystore `json:"jks,omitempty"`
+
+	// PKCS12 configures options for storing a PKCS12 keystore in the
+	// `spec.secretName` Secret resource.
+	// +optional
+	PKCS12 *PKCS12Keystore `json:"pkcs12,omitempty"`
+}
+
+// JKSKeystore configures options for storing a JKS keystore in the
+// `spec.secretName` Secret resource.
+type JKSKeystore struct {
+	// Create enables JKS keystore creation for the Certificate.
+	// If true, a file named `keystore.jks` will be created in the target
+	// Secret resource, encrypted using the password stored in
+	// `passwordSecretRef`. If a CA certificate is available, a file named
+	// `truststore.jks` containing the CA will also be created.
+	// The keystore files will be updated whenever the issued certificate or
+	// the password changes.
+	Create bool `json:"create"`
+
+	// PasswordSecretRef is a reference to a key in a Secret resource
+	// containing the password used to encrypt the JKS keystore.
+	PasswordSecretRef cmmeta.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// PKCS12Keystore configures options for storing a PKCS12 keystore in the
+// `spec.secretName` Secret resource.
+type PKCS12Keystore struct {
+	// Create enables PKCS12 keystore creation for the Certificate.
+	// If true, a file named `keystore.p12` will be created in the target
+	// Secret resource, encrypted using the password stored in
+	// `passwordSecretRef`. If a CA certificate is available, a file named
+	// `truststore.p12` containing the CA will also be created.
+	// The keystore files will be updated whenever the issued certificate or
+	// the password changes.
+	Create bool `json:"create"`
+
+	// PasswordSecretRef is a reference to a key in a Secret resource
+	// containing the password used to encrypt the PKCS12 keystore.
+	PasswordSecretRef cmmeta.SecretKeySelector `json:"passwordSecretRef"`
 }
 
 // X509Subject Full X509 name specification
@@ -169,18 +321,114 @@ type CertificateStatus struct {
 	// +optional
 	Conditions []CertificateCondition `json:"conditions,omitempty"`
 
+	// LastFailureTime is the time the most recent failed attempt to issue a
+	// certificate for this resource failed.
 	// +optional
 	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
 
+	// FailedIssuanceAttempts is the number of consecutive failed attempts to
+	// issue a certificate for this resource. It is reset once a certificate
+	// has been issued, or when the spec of this resource changes.
+	// +optional
+	FailedIssuanceAttempts *int `json:"failedIssuanceAttempts,omitempty"`
+
+	// FailedIssuanceSpecHash identifies the spec of this resource that the
+	// failed issuance attempts were made for. It is the name of the
+	// CertificateRequests created for the spec, without any revision suffix.
+	// +optional
+	FailedIssuanceSpecHash string `json:"failedIssuanceSpecHash,omitempty"`
+
+	// NextRetryTime is the time after which issuance will be retried after
+	// the most recent failed attempt. The time between attempts doubles with
+	// every consecutive failure, up to a maximum of 32 hours.
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+
 	// The expiration time of the certificate stored in the secret named
 	// by this resource in spec.secretName.
 	// +optional
 	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// The time from which the certificate stored in the secret named by this
+	// resource in spec.secretName is valid.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// RenewalTime is the time at which the certificate stored in the secret
+	// named by this resource in spec.secretName will be renewed.
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+
+	// Revision is the revision of the certificate stored in the secret named
+	// by this resource in spec.secretName. It is incremented every time a new
+	// certificate is issued, and matches the
+	// `cert-manager.io/certificate-revision` annotation of the
+	// CertificateRequest that the certificate was issued by.
+	// +optional
+	Revision *int `json:"revision,omitempty"`
+
+	// CertificateRequestName is the name of the CertificateRequest that the
+	// certificate stored in the secret named by this resource in
+	// spec.secretName was issued by.
+	// +optional
+	CertificateRequestName string `json:"certificateRequestName,omitempty"`
+
+	// Revocation records the most recent revocation of a certificate stored
+	// in the secret named by this resource in spec.secretName.
+	// +optional
+	Revocation *CertificateRevocation `json:"revocation,omitempty"`
+
+	// SuggestedRenewalWindow is the window in which the ACME server that
+	// issued the certificate stored in the secret named by this resource in
+	// spec.secretName suggests it is renewed.
+	// +optional
+	SuggestedRenewalWindow *CertificateRenewalWindow `json:"suggestedRenewalWindow,omitempty"`
+}
+
+// CertificateRevocation records the revocation of a certificate.
+type CertificateRevocation struct {
+	// SerialNumber of the revoked certificate, as a hex encoded string.
+	SerialNumber string `json:"serialNumber"`
+
+	// Reason the certificate was revoked with, e.g. "keyCompromise".
+	Reason string `json:"reason"`
+
+	// RevocationTime is the time the certificate was revoked.
+	// +optional
+	RevocationTime *metav1.Time `json:"revocationTime,omitempty"`
+}
+
+// CertificateRenewalWindow is a renewal window suggested by an ACME server
+// using the ACME Renewal Information (ARI) extension.
+// If the ACME server does not support renewal information or could not be
+// queried, only the time of the next check is recorded.
+type CertificateRenewalWindow struct {
+	// SerialNumber of the certificate the window was suggested for, as a hex
+	// encoded string.
+	SerialNumber string `json:"serialNumber"`
+
+	// Start of the suggested renewal window.
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+
+	// End of the suggested renewal window.
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+
+	// ExplanationURL is a URL given by the ACME server explaining why the
+	// window was suggested, e.g. because of a mass revocation event.
+	// +optional
+	ExplanationURL string `json:"explanationURL,omitempty"`
+
+	// NextCheckTime is the time after which updated renewal information will
+	// be requested from the ACME server.
+	// +optional
+	NextCheckTime *metav1.Time `json:"nextCheckTime,omitempty"`
 }
 
 // CertificateCondition contains condition information for an Certificate.
 type CertificateCondition struct {
-	// Type of the condition, currently ('Ready').
+	// Type of the condition, currently ('Ready', 'Issuing').
 	Type CertificateConditionType `json:"type"`
 
 	// Status of the condition, one of ('True', 'False', 'Unknown').
@@ -213,4 +461,19 @@ const (
 	// - The target secret contains a private key valid for the certificate
 	// - The commonName and dnsNames attributes match those specified on the Certificate
 	CertificateConditionReady CertificateConditionType = "Ready"
+
+	// CertificateConditionIssuing indicates that a re-issuance of the
+	// certificate has been requested.
+	// Clients may set this condition with a status of 'True' to trigger a
+	// manual renewal of a Certificate, even if the current certificate is
+	// still valid. The existing certificate remains in place until the new
+	// certificate has been issued, at which point the controller will remove
+	// this condition.
+	CertificateConditionIssuing CertificateConditionType = "Issuing"
 )
+
+// CertificateIssuingReasonKeyCompromise is the reason of an Issuing
+// condition set because the private key of the current certificate has been
+// compromised. A new private key is always generated for the re-issuance,
+// regardless of the private key rotation policy.
+const CertificateIssuingReasonKeyCompromise = "KeyCompromise"
@@ -143,9 +143,36 @@ type VaultIssuer struct {
 	// Server is the vault connection address
 	Server string `json:"server"`
 
-	// Vault URL path to the certificate role
+	// Vault URL path to the certificate role.
+	// If signMode is Verbatim, this must be the path of a PKI secrets engine
+	// 'sign-verbatim' endpoint, e.g. 'pki/sign-verbatim' or
+	// 'pki/sign-verbatim/my-role'.
 	Path string `json:"path"`
 
+	// Namespace is the Vault Enterprise namespace that requests are made in.
+	// If set, it is sent in the X-Vault-Namespace header of all requests,
+	// including those used to authenticate with Vault.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SignMode selects the shape of the signing request sent to Vault.
+	// If set to Role, the subject and SANs of the CSR are passed as role
+	// parameters to a 'sign/<role>' endpoint. If set to Verbatim, only the CSR
+	// is passed to a 'sign-verbatim' endpoint and used as-is.
+	// Defaults to Role.
+	// +optional
+	SignMode VaultSignMode `json:"signMode,omitempty"`
+
+	// AllowedParameters is the list of additional Vault signing request
+	// parameters that CertificateRequests may set using
+	// 'vault.cert-manager.io/param.' annotations. These parameters are not
+	// covered by CertificateRequest policy, so only parameters the issuer's
+	// administrator has chosen to expose should be listed. Requests setting
+	// any other parameter are marked as invalid.
+	// If not set, no additional parameters are allowed.
+	// +optional
+	AllowedParameters []string `json:"allowedParameters,omitempty"`
+
 	// Base64 encoded CA bundle to validate Vault server certificate. Only used
 	// if the Server URL is using HTTPS protocol. This parameter is ignored for
 	// plain HTTP protocol connection. If not set the system root certificates
@@ -154,6 +181,19 @@ type VaultIssuer struct {
 	CABundle []byte `json:"caBundle,omitempty"`
 }
 
+// VaultSignMode is the shape of the signing request sent to Vault.
+type VaultSignMode string
+
+const (
+	// VaultSignModeRole signs certificates using a Vault PKI role, passing
+	// the subject and SANs of the CSR as role parameters.
+	VaultSignModeRole VaultSignMode = "Role"
+
+	// VaultSignModeVerbatim signs certificates using the Vault PKI
+	// sign-verbatim endpoint, which uses the CSR as-is.
+	VaultSignModeVerbatim VaultSignMode = "Verbatim"
+)
+
 // Vault authentication  can be configured:
 // - With a secret containing a token. Cert-manager is using this token as-is.
 // - With a secret containing a AppRole. This AppRole is used to authenticate to
@@ -173,6 +213,11 @@ type VaultAuth struct {
 	// authenticate with vault.
 	// +optional
 	Kubernetes *VaultKubernetesAuth `json:"kubernetes,omitempty"`
+
+	// ClientCertificate authenticates with Vault using the TLS certificate
+	// auth method, presenting the client certificate stored in a Secret.
+	// +optional
+	ClientCertificate *VaultClientCertificateAuth `json:"clientCertificate,omitempty"`
 }
 
 // Authenticate against Vault using an AppRole that is stored in a Secret.
@@ -204,10 +249,92 @@ type VaultKubernetesAuth struct {
 	Role string `json:"role"`
 }
 
+// VaultClientCertificateAuth configures authentication with Vault using the
+// TLS certificate auth method. The client key pair is presented when
+// connecting to Vault, and is read from the Secret every time a client is
+// created, so it can be the Secret of a cert-manager Certificate that is
+// rotated automatically.
+type VaultClientCertificateAuth struct {
+	// The Vault mountPath here is the mount path to use when authenticating with
+	// Vault. For example, setting a value to `/v1/auth/foo`, will use the path
+	// `/v1/auth/foo/login` to authenticate with Vault. If unspecified, the
+	// default value "/v1/auth/cert" will be used.
+	// +optional
+	Path string `json:"mountPath,omitempty"`
+
+	// SecretName is the name of a Secret containing the client certificate
+	// and private key in the `tls.crt` and `tls.key` keys. The Secret must be
+	// in the same namespace as the Issuer, or in the cluster resource
+	// namespace for a ClusterIssuer.
+	SecretName string `json:"secretName"`
+
+	// Name of the certificate role to authenticate against. If not set,
+	// Vault matches the client certificate against all roles of the auth
+	// method.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
 type CAIssuer struct {
 	// SecretName is the name of the secret used to sign Certificates issued
 	// by this Issuer.
 	SecretName string `json:"secretName"`
+
+	// The CRL distribution points is an X.509 v3 certificate extension which identifies
+	// the location of the CRL from which the revocation of this certificate can be checked.
+	// If not set, certificates will be issued without distribution points set.
+	// +optional
+	CRLDistributionPoints []string `json:"crlDistributionPoints,omitempty"`
+
+	// The OCSP server list is an X.509 v3 extension that defines a list of
+	// URLs of OCSP responders. The OCSP responders can be queried for the
+	// revocation status of an issued certificate. If not set, the
+	// certificate will be issued with no OCSP servers set. For example, an
+	// OCSP server URL could be "http://ocsp.int-x3.letsencrypt.org".
+	// +optional
+	OCSPServers []string `json:"ocspServers,omitempty"`
+
+	// CRL configures cert-manager to maintain a certificate revocation list
+	// (CRL) signed by this issuer, listing the certificates it has issued
+	// that have been revoked.
+	// If not set, no CRL will be maintained.
+	// +optional
+	CRL *CACRLConfig `json:"crl,omitempty"`
+}
+
+// CACRLConfig configures the certificate revocation list maintained for a CA
+// issuer.
+type CACRLConfig struct {
+	// SecretName is the name of the Secret the DER encoded CRL is stored in,
+	// under the 'ca.crl' key. The Secret is created in the same namespace
+	// as the Secret containing the signing key pair, and must not be the
+	// same Secret.
+	SecretName string `json:"secretName"`
+
+	// Duration is the validity period of each signed CRL. The CRL is
+	// re-signed once less than a third of this period remains.
+	// If not set, a duration of 24 hours is used.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RevokedCertificates is the list of certificates issued by this issuer
+	// that have been revoked.
+	// +optional
+	RevokedCertificates []CARevokedCertificate `json:"revokedCertificates,omitempty"`
+}
+
+// CARevokedCertificate identifies a certificate revoked by a CA issuer.
+type CARevokedCertificate struct {
+	// SerialNumber is the serial number of the revoked certificate, hex
+	// encoded as printed by 'openssl x509 -serial', optionally with colons
+	// separating each byte.
+	SerialNumber string `json:"serialNumber"`
+
+	// RevocationTime is the time the certificate was revoked.
+	// If not set, the time the revocation is first published in the CRL is
+	// used.
+	// +optional
+	RevocationTime *metav1.Time `json:"revocationTime,omitempty"`
 }
 
 // IssuerStatus contains status information about an Issuer
@@ -250,4 +377,10 @@ const (
 	// IssuerConditionReady represents the fact that a given Issuer condition
 	// is in ready state.
 	IssuerConditionReady IssuerConditionType = "Ready"
+
+	// IssuerConditionTermsOfServiceChanged is set to True on ACME issuers
+	// when the ACME server publishes terms of service which differ from the
+	// terms the issuer's account agreed to. It is cleared once the new terms
+	// are agreed to by setting spec.acme.agreeTermsOfService to their URL.
+	IssuerConditionTermsOfServiceChanged IssuerConditionType = "TermsOfServiceChanged"
 )
@@ -41,6 +41,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CACRLConfig)(nil), (*certmanager.CACRLConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CACRLConfig_To_certmanager_CACRLConfig(a.(*v1alpha2.CACRLConfig), b.(*certmanager.CACRLConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CACRLConfig)(nil), (*v1alpha2.CACRLConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CACRLConfig_To_v1alpha2_CACRLConfig(a.(*certmanager.CACRLConfig), b.(*v1alpha2.CACRLConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.CAIssuer)(nil), (*certmanager.CAIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CAIssuer_To_certmanager_CAIssuer(a.(*v1alpha2.CAIssuer), b.(*certmanager.CAIssuer), scope)
 	}); err != nil {
@@ -51,6 +61,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CARevokedCertificate)(nil), (*certmanager.CARevokedCertificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CARevokedCertificate_To_certmanager_CARevokedCertificate(a.(*v1alpha2.CARevokedCertificate), b.(*certmanager.CARevokedCertificate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CARevokedCertificate)(nil), (*v1alpha2.CARevokedCertificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CARevokedCertificate_To_v1alpha2_CARevokedCertificate(a.(*certmanager.CARevokedCertificate), b.(*v1alpha2.CARevokedCertificate), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.Certificate)(nil), (*certmanager.Certificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_Certificate_To_certmanager_Certificate(a.(*v1alpha2.Certificate), b.(*certmanager.Certificate), scope)
 	}); err != nil {
@@ -61,6 +81,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateAdditionalOutputFormat)(nil), (*certmanager.CertificateAdditionalOutputFormat)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateAdditionalOutputFormat_To_certmanager_CertificateAdditionalOutputFormat(a.(*v1alpha2.CertificateAdditionalOutputFormat), b.(*certmanager.CertificateAdditionalOutputFormat), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAdditionalOutputFormat)(nil), (*v1alpha2.CertificateAdditionalOutputFormat)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAdditionalOutputFormat_To_v1alpha2_CertificateAdditionalOutputFormat(a.(*certmanager.CertificateAdditionalOutputFormat), b.(*v1alpha2.CertificateAdditionalOutputFormat), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateCondition)(nil), (*certmanager.CertificateCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CertificateCondition_To_certmanager_CertificateCondition(a.(*v1alpha2.CertificateCondition), b.(*certmanager.CertificateCondition), scope)
 	}); err != nil {
@@ -71,6 +101,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateKeystores)(nil), (*certmanager.CertificateKeystores)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateKeystores_To_certmanager_CertificateKeystores(a.(*v1alpha2.CertificateKeystores), b.(*certmanager.CertificateKeystores), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateKeystores)(nil), (*v1alpha2.CertificateKeystores)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateKeystores_To_v1alpha2_CertificateKeystores(a.(*certmanager.CertificateKeystores), b.(*v1alpha2.CertificateKeystores), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateList)(nil), (*certmanager.CertificateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CertificateList_To_certmanager_CertificateList(a.(*v1alpha2.CertificateList), b.(*certmanager.CertificateList), scope)
 	}); err != nil {
@@ -81,6 +121,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificatePrivateKey)(nil), (*certmanager.CertificatePrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(a.(*v1alpha2.CertificatePrivateKey), b.(*certmanager.CertificatePrivateKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificatePrivateKey)(nil), (*v1alpha2.CertificatePrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificatePrivateKey_To_v1alpha2_CertificatePrivateKey(a.(*certmanager.CertificatePrivateKey), b.(*v1alpha2.CertificatePrivateKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateRenewalWindow)(nil), (*certmanager.CertificateRenewalWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(a.(*v1alpha2.CertificateRenewalWindow), b.(*certmanager.CertificateRenewalWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRenewalWindow)(nil), (*v1alpha2.CertificateRenewalWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRenewalWindow_To_v1alpha2_CertificateRenewalWindow(a.(*certmanager.CertificateRenewalWindow), b.(*v1alpha2.CertificateRenewalWindow), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CertificateRequest_To_certmanager_CertificateRequest(a.(*v1alpha2.CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -111,6 +171,66 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateRequestPolicy)(nil), (*certmanager.CertificateRequestPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateRequestPolicy_To_certmanager_CertificateRequestPolicy(a.(*v1alpha2.CertificateRequestPolicy), b.(*certmanager.CertificateRequestPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRequestPolicy)(nil), (*v1alpha2.CertificateRequestPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRequestPolicy_To_v1alpha2_CertificateRequestPolicy(a.(*certmanager.CertificateRequestPolicy), b.(*v1alpha2.CertificateRequestPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateRequestPolicyConstraints)(nil), (*certmanager.CertificateRequestPolicyConstraints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateRequestPolicyConstraints_To_certmanager_CertificateRequestPolicyConstraints(a.(*v1alpha2.CertificateRequestPolicyConstraints), b.(*certmanager.CertificateRequestPolicyConstraints), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRequestPolicyConstraints)(nil), (*v1alpha2.CertificateRequestPolicyConstraints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRequestPolicyConstraints_To_v1alpha2_CertificateRequestPolicyConstraints(a.(*certmanager.CertificateRequestPolicyConstraints), b.(*v1alpha2.CertificateRequestPolicyConstraints), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateRequestPolicyList)(nil), (*certmanager.CertificateRequestPolicyList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateRequestPolicyList_To_certmanager_CertificateRequestPolicyList(a.(*v1alpha2.CertificateRequestPolicyList), b.(*certmanager.CertificateRequestPolicyList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRequestPolicyList)(nil), (*v1alpha2.CertificateRequestPolicyList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRequestPolicyList_To_v1alpha2_CertificateRequestPolicyList(a.(*certmanager.CertificateRequestPolicyList), b.(*v1alpha2.CertificateRequestPolicyList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateRequestPolicyServiceAccount)(nil), (*certmanager.CertificateRequestPolicyServiceAccount)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateRequestPolicyServiceAccount_To_certmanager_CertificateRequestPolicyServiceAccount(a.(*v1alpha2.CertificateRequestPolicyServiceAccount), b.(*certmanager.CertificateRequestPolicyServiceAccount), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRequestPolicyServiceAccount)(nil), (*v1alpha2.CertificateRequestPolicyServiceAccount)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRequestPolicyServiceAccount_To_v1alpha2_CertificateRequestPolicyServiceAccount(a.(*certmanager.CertificateRequestPolicyServiceAccount), b.(*v1alpha2.CertificateRequestPolicyServiceAccount), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateRequestPolicySpec)(nil), (*certmanager.CertificateRequestPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateRequestPolicySpec_To_certmanager_CertificateRequestPolicySpec(a.(*v1alpha2.CertificateRequestPolicySpec), b.(*certmanager.CertificateRequestPolicySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRequestPolicySpec)(nil), (*v1alpha2.CertificateRequestPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRequestPolicySpec_To_v1alpha2_CertificateRequestPolicySpec(a.(*certmanager.CertificateRequestPolicySpec), b.(*v1alpha2.CertificateRequestPolicySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateRequestPolicySubjects)(nil), (*certmanager.CertificateRequestPolicySubjects)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateRequestPolicySubjects_To_certmanager_CertificateRequestPolicySubjects(a.(*v1alpha2.CertificateRequestPolicySubjects), b.(*certmanager.CertificateRequestPolicySubjects), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRequestPolicySubjects)(nil), (*v1alpha2.CertificateRequestPolicySubjects)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRequestPolicySubjects_To_v1alpha2_CertificateRequestPolicySubjects(a.(*certmanager.CertificateRequestPolicySubjects), b.(*v1alpha2.CertificateRequestPolicySubjects), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateRequestSpec)(nil), (*certmanager.CertificateRequestSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(a.(*v1alpha2.CertificateRequestSpec), b.(*certmanager.CertificateRequestSpec), scope)
 	}); err != nil {
@@ -131,6 +251,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateRevocation)(nil), (*certmanager.CertificateRevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateRevocation_To_certmanager_CertificateRevocation(a.(*v1alpha2.CertificateRevocation), b.(*certmanager.CertificateRevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRevocation)(nil), (*v1alpha2.CertificateRevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRevocation_To_v1alpha2_CertificateRevocation(a.(*certmanager.CertificateRevocation), b.(*v1alpha2.CertificateRevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateSecretTemplate)(nil), (*certmanager.CertificateSecretTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(a.(*v1alpha2.CertificateSecretTemplate), b.(*certmanager.CertificateSecretTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateSecretTemplate)(nil), (*v1alpha2.CertificateSecretTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateSecretTemplate_To_v1alpha2_CertificateSecretTemplate(a.(*certmanager.CertificateSecretTemplate), b.(*v1alpha2.CertificateSecretTemplate), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateSpec)(nil), (*certmanager.CertificateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CertificateSpec_To_certmanager_CertificateSpec(a.(*v1alpha2.CertificateSpec), b.(*certmanager.CertificateSpec), scope)
 	}); err != nil {
@@ -231,6 +371,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.JKSKeystore)(nil), (*certmanager.JKSKeystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_JKSKeystore_To_certmanager_JKSKeystore(a.(*v1alpha2.JKSKeystore), b.(*certmanager.JKSKeystore), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.JKSKeystore)(nil), (*v1alpha2.JKSKeystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_JKSKeystore_To_v1alpha2_JKSKeystore(a.(*certmanager.JKSKeystore), b.(*v1alpha2.JKSKeystore), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.PKCS12Keystore)(nil), (*certmanager.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_PKCS12Keystore_To_certmanager_PKCS12Keystore(a.(*v1alpha2.PKCS12Keystore), b.(*certmanager.PKCS12Keystore), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.PKCS12Keystore)(nil), (*v1alpha2.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_PKCS12Keystore_To_v1alpha2_PKCS12Keystore(a.(*certmanager.PKCS12Keystore), b.(*v1alpha2.PKCS12Keystore), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*v1alpha2.SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
@@ -261,6 +421,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.VaultClientCertificateAuth)(nil), (*certmanager.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(a.(*v1alpha2.VaultClientCertificateAuth), b.(*certmanager.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultClientCertificateAuth)(nil), (*v1alpha2.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultClientCertificateAuth_To_v1alpha2_VaultClientCertificateAuth(a.(*certmanager.VaultClientCertificateAuth), b.(*v1alpha2.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.VaultIssuer)(nil), (*certmanager.VaultIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VaultIssuer_To_certmanager_VaultIssuer(a.(*v1alpha2.VaultIssuer), b.(*certmanager.VaultIssuer), scope)
 	}); err != nil {
@@ -324,8 +494,35 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha2_CACRLConfig_To_certmanager_CACRLConfig(in *v1alpha2.CACRLConfig, out *certmanager.CACRLConfig, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	out.RevokedCertificates = *(*[]certmanager.CARevokedCertificate)(unsafe.Pointer(&in.RevokedCertificates))
+	return nil
+}
+
+// Convert_v1alpha2_CACRLConfig_To_certmanager_CACRLConfig is an autogenerated conversion function.
+func Convert_v1alpha2_CACRLConfig_To_certmanager_CACRLConfig(in *v1alpha2.CACRLConfig, out *certmanager.CACRLConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CACRLConfig_To_certmanager_CACRLConfig(in, out, s)
+}
+
+func autoConvert_certmanager_CACRLConfig_To_v1alpha2_CACRLConfig(in *certmanager.CACRLConfig, out *v1alpha2.CACRLConfig, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	out.RevokedCertificates = *(*[]v1alpha2.CARevokedCertificate)(unsafe.Pointer(&in.RevokedCertificates))
+	return nil
+}
+
+// Convert_certmanager_CACRLConfig_To_v1alpha2_CACRLConfig is an autogenerated conversion function.
+func Convert_certmanager_CACRLConfig_To_v1alpha2_CACRLConfig(in *certmanager.CACRLConfig, out *v1alpha2.CACRLConfig, s conversion.Scope) error {
+	return autoConvert_certmanager_CACRLConfig_To_v1alpha2_CACRLConfig(in, out, s)
+}
+
 func autoConvert_v1alpha2_CAIssuer_To_certmanager_CAIssuer(in *v1alpha2.CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.CRL = (*certmanager.CACRLConfig)(unsafe.Pointer(in.CRL))
 	return nil
 }
 
@@ -336,6 +533,9 @@ func Convert_v1alpha2_CAIssuer_To_certmanager_CAIssuer(in *v1alpha2.CAIssuer, ou
 
 func autoConvert_certmanager_CAIssuer_To_v1alpha2_CAIssuer(in *certmanager.CAIssuer, out *v1alpha2.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.CRL = (*v1alpha2.CACRLConfig)(unsafe.Pointer(in.CRL))
 	return nil
 }
 
@@ -344,6 +544,28 @@ func Convert_certmanager_CAIssuer_To_v1alpha2_CAIssuer(in *certmanager.CAIssuer,
 	return autoConvert_certmanager_CAIssuer_To_v1alpha2_CAIssuer(in, out, s)
 }
 
+func autoConvert_v1alpha2_CARevokedCertificate_To_certmanager_CARevokedCertificate(in *v1alpha2.CARevokedCertificate, out *certmanager.CARevokedCertificate, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.RevocationTime = (*v1.Time)(unsafe.Pointer(in.RevocationTime))
+	return nil
+}
+
+// Convert_v1alpha2_CARevokedCertificate_To_certmanager_CARevokedCertificate is an autogenerated conversion function.
+func Convert_v1alpha2_CARevokedCertificate_To_certmanager_CARevokedCertificate(in *v1alpha2.CARevokedCertificate, out *certmanager.CARevokedCertificate, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CARevokedCertificate_To_certmanager_CARevokedCertificate(in, out, s)
+}
+
+func autoConvert_certmanager_CARevokedCertificate_To_v1alpha2_CARevokedCertificate(in *certmanager.CARevokedCertificate, out *v1alpha2.CARevokedCertificate, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.RevocationTime = (*v1.Time)(unsafe.Pointer(in.RevocationTime))
+	return nil
+}
+
+// Convert_certmanager_CARevokedCertificate_To_v1alpha2_CARevokedCertificate is an autogenerated conversion function.
+func Convert_certmanager_CARevokedCertificate_To_v1alpha2_CARevokedCertificate(in *certmanager.CARevokedCertificate, out *v1alpha2.CARevokedCertificate, s conversion.Scope) error {
+	return autoConvert_certmanager_CARevokedCertificate_To_v1alpha2_CARevokedCertificate(in, out, s)
+}
+
 func autoConvert_v1alpha2_Certificate_To_certmanager_Certificate(in *v1alpha2.Certificate, out *certmanager.Certificate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_CertificateSpec_To_certmanager_CertificateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -376,6 +598,26 @@ func Convert_certmanager_Certificate_To_v1alpha2_Certificate(in *certmanager.Cer
 	return autoConvert_certmanager_Certificate_To_v1alpha2_Certificate(in, out, s)
 }
 
+func autoConvert_v1alpha2_CertificateAdditionalOutputFormat_To_certmanager_CertificateAdditionalOutputFormat(in *v1alpha2.CertificateAdditionalOutputFormat, out *certmanager.CertificateAdditionalOutputFormat, s conversion.Scope) error {
+	out.Type = certmanager.CertificateOutputFormatType(in.Type)
+	return nil
+}
+
+// Convert_v1alpha2_CertificateAdditionalOutputFormat_To_certmanager_CertificateAdditionalOutputFormat is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateAdditionalOutputFormat_To_certmanager_CertificateAdditionalOutputFormat(in *v1alpha2.CertificateAdditionalOutputFormat, out *certmanager.CertificateAdditionalOutputFormat, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateAdditionalOutputFormat_To_certmanager_CertificateAdditionalOutputFormat(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAdditionalOutputFormat_To_v1alpha2_CertificateAdditionalOutputFormat(in *certmanager.CertificateAdditionalOutputFormat, out *v1alpha2.CertificateAdditionalOutputFormat, s conversion.Scope) error {
+	out.Type = v1alpha2.CertificateOutputFormatType(in.Type)
+	return nil
+}
+
+// Convert_certmanager_CertificateAdditionalOutputFormat_To_v1alpha2_CertificateAdditionalOutputFormat is an autogenerated conversion function.
+func Convert_certmanager_CertificateAdditionalOutputFormat_To_v1alpha2_CertificateAdditionalOutputFormat(in *certmanager.CertificateAdditionalOutputFormat, out *v1alpha2.CertificateAdditionalOutputFormat, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAdditionalOutputFormat_To_v1alpha2_CertificateAdditionalOutputFormat(in, out, s)
+}
+
 func autoConvert_v1alpha2_CertificateCondition_To_certmanager_CertificateCondition(in *v1alpha2.CertificateCondition, out *certmanager.CertificateCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
@@ -404,6 +646,28 @@ func Convert_certmanager_CertificateCondition_To_v1alpha2_CertificateCondition(i
 	return autoConvert_certmanager_CertificateCondition_To_v1alpha2_CertificateCondition(in, out, s)
 }
 
+func autoConvert_v1alpha2_CertificateKeystores_To_certmanager_CertificateKeystores(in *v1alpha2.CertificateKeystores, out *certmanager.CertificateKeystores, s conversion.Scope) error {
+	out.JKS = (*certmanager.JKSKeystore)(unsafe.Pointer(in.JKS))
+	out.PKCS12 = (*certmanager.PKCS12Keystore)(unsafe.Pointer(in.PKCS12))
+	return nil
+}
+
+// Convert_v1alpha2_CertificateKeystores_To_certmanager_CertificateKeystores is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateKeystores_To_certmanager_CertificateKeystores(in *v1alpha2.CertificateKeystores, out *certmanager.CertificateKeystores, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateKeystores_To_certmanager_CertificateKeystores(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateKeystores_To_v1alpha2_CertificateKeystores(in *certmanager.CertificateKeystores, out *v1alpha2.CertificateKeystores, s conversion.Scope) error {
+	out.JKS = (*v1alpha2.JKSKeystore)(unsafe.Pointer(in.JKS))
+	out.PKCS12 = (*v1alpha2.PKCS12Keystore)(unsafe.Pointer(in.PKCS12))
+	return nil
+}
+
+// Convert_certmanager_CertificateKeystores_To_v1alpha2_CertificateKeystores is an autogenerated conversion function.
+func Convert_certmanager_CertificateKeystores_To_v1alpha2_CertificateKeystores(in *certmanager.CertificateKeystores, out *v1alpha2.CertificateKeystores, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateKeystores_To_v1alpha2_CertificateKeystores(in, out, s)
+}
+
 func autoConvert_v1alpha2_CertificateList_To_certmanager_CertificateList(in *v1alpha2.CertificateList, out *certmanager.CertificateList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	out.Items = *(*[]certmanager.Certificate)(unsafe.Pointer(&in.Items))
@@ -426,6 +690,54 @@ func Convert_certmanager_CertificateList_To_v1alpha2_CertificateList(in *certman
 	return autoConvert_certmanager_CertificateList_To_v1alpha2_CertificateList(in, out, s)
 }
 
+func autoConvert_v1alpha2_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *v1alpha2.CertificatePrivateKey, out *certmanager.CertificatePrivateKey, s conversion.Scope) error {
+	out.RotationPolicy = certmanager.PrivateKeyRotationPolicy(in.RotationPolicy)
+	return nil
+}
+
+// Convert_v1alpha2_CertificatePrivateKey_To_certmanager_CertificatePrivateKey is an autogenerated conversion function.
+func Convert_v1alpha2_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *v1alpha2.CertificatePrivateKey, out *certmanager.CertificatePrivateKey, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in, out, s)
+}
+
+func autoConvert_certmanager_CertificatePrivateKey_To_v1alpha2_CertificatePrivateKey(in *certmanager.CertificatePrivateKey, out *v1alpha2.CertificatePrivateKey, s conversion.Scope) error {
+	out.RotationPolicy = v1alpha2.PrivateKeyRotationPolicy(in.RotationPolicy)
+	return nil
+}
+
+// Convert_certmanager_CertificatePrivateKey_To_v1alpha2_CertificatePrivateKey is an autogenerated conversion function.
+func Convert_certmanager_CertificatePrivateKey_To_v1alpha2_CertificatePrivateKey(in *certmanager.CertificatePrivateKey, out *v1alpha2.CertificatePrivateKey, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificatePrivateKey_To_v1alpha2_CertificatePrivateKey(in, out, s)
+}
+
+func autoConvert_v1alpha2_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(in *v1alpha2.CertificateRenewalWindow, out *certmanager.CertificateRenewalWindow, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.Start = (*v1.Time)(unsafe.Pointer(in.Start))
+	out.End = (*v1.Time)(unsafe.Pointer(in.End))
+	out.ExplanationURL = in.ExplanationURL
+	out.NextCheckTime = (*v1.Time)(unsafe.Pointer(in.NextCheckTime))
+	return nil
+}
+
+// Convert_v1alpha2_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(in *v1alpha2.CertificateRenewalWindow, out *certmanager.CertificateRenewalWindow, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRenewalWindow_To_v1alpha2_CertificateRenewalWindow(in *certmanager.CertificateRenewalWindow, out *v1alpha2.CertificateRenewalWindow, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.Start = (*v1.Time)(unsafe.Pointer(in.Start))
+	out.End = (*v1.Time)(unsafe.Pointer(in.End))
+	out.ExplanationURL = in.ExplanationURL
+	out.NextCheckTime = (*v1.Time)(unsafe.Pointer(in.NextCheckTime))
+	return nil
+}
+
+// Convert_certmanager_CertificateRenewalWindow_To_v1alpha2_CertificateRenewalWindow is an autogenerated conversion function.
+func Convert_certmanager_CertificateRenewalWindow_To_v1alpha2_CertificateRenewalWindow(in *certmanager.CertificateRenewalWindow, out *v1alpha2.CertificateRenewalWindow, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRenewalWindow_To_v1alpha2_CertificateRenewalWindow(in, out, s)
+}
+
 func autoConvert_v1alpha2_CertificateRequest_To_certmanager_CertificateRequest(in *v1alpha2.CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -508,6 +820,168 @@ func Convert_certmanager_CertificateRequestList_To_v1alpha2_CertificateRequestLi
 	return autoConvert_certmanager_CertificateRequestList_To_v1alpha2_CertificateRequestList(in, out, s)
 }
 
+func autoConvert_v1alpha2_CertificateRequestPolicy_To_certmanager_CertificateRequestPolicy(in *v1alpha2.CertificateRequestPolicy, out *certmanager.CertificateRequestPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha2_CertificateRequestPolicySpec_To_certmanager_CertificateRequestPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_CertificateRequestPolicy_To_certmanager_CertificateRequestPolicy is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateRequestPolicy_To_certmanager_CertificateRequestPolicy(in *v1alpha2.CertificateRequestPolicy, out *certmanager.CertificateRequestPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateRequestPolicy_To_certmanager_CertificateRequestPolicy(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRequestPolicy_To_v1alpha2_CertificateRequestPolicy(in *certmanager.CertificateRequestPolicy, out *v1alpha2.CertificateRequestPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_CertificateRequestPolicySpec_To_v1alpha2_CertificateRequestPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateRequestPolicy_To_v1alpha2_CertificateRequestPolicy is an autogenerated conversion function.
+func Convert_certmanager_CertificateRequestPolicy_To_v1alpha2_CertificateRequestPolicy(in *certmanager.CertificateRequestPolicy, out *v1alpha2.CertificateRequestPolicy, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRequestPolicy_To_v1alpha2_CertificateRequestPolicy(in, out, s)
+}
+
+func autoConvert_v1alpha2_CertificateRequestPolicyConstraints_To_certmanager_CertificateRequestPolicyConstraints(in *v1alpha2.CertificateRequestPolicyConstraints, out *certmanager.CertificateRequestPolicyConstraints, s conversion.Scope) error {
+	out.AllowedDNSNames = *(*[]string)(unsafe.Pointer(&in.AllowedDNSNames))
+	out.AllowedURISANs = *(*[]string)(unsafe.Pointer(&in.AllowedURISANs))
+	out.AllowedIPRanges = *(*[]string)(unsafe.Pointer(&in.AllowedIPRanges))
+	out.MaxDuration = (*v1.Duration)(unsafe.Pointer(in.MaxDuration))
+	out.AllowIsCA = (*bool)(unsafe.Pointer(in.AllowIsCA))
+	out.AllowedUsages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.AllowedUsages))
+	out.AllowedKeyAlgorithms = *(*[]certmanager.KeyAlgorithm)(unsafe.Pointer(&in.AllowedKeyAlgorithms))
+	out.MinRSAKeySize = in.MinRSAKeySize
+	out.MinECDSAKeySize = in.MinECDSAKeySize
+	return nil
+}
+
+// Convert_v1alpha2_CertificateRequestPolicyConstraints_To_certmanager_CertificateRequestPolicyConstraints is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateRequestPolicyConstraints_To_certmanager_CertificateRequestPolicyConstraints(in *v1alpha2.CertificateRequestPolicyConstraints, out *certmanager.CertificateRequestPolicyConstraints, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateRequestPolicyConstraints_To_certmanager_CertificateRequestPolicyConstraints(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRequestPolicyConstraints_To_v1alpha2_CertificateRequestPolicyConstraints(in *certmanager.CertificateRequestPolicyConstraints, out *v1alpha2.CertificateRequestPolicyConstraints, s conversion.Scope) error {
+	out.AllowedDNSNames = *(*[]string)(unsafe.Pointer(&in.AllowedDNSNames))
+	out.AllowedURISANs = *(*[]string)(unsafe.Pointer(&in.AllowedURISANs))
+	out.AllowedIPRanges = *(*[]string)(unsafe.Pointer(&in.AllowedIPRanges))
+	out.MaxDuration = (*v1.Duration)(unsafe.Pointer(in.MaxDuration))
+	out.AllowIsCA = (*bool)(unsafe.Pointer(in.AllowIsCA))
+	out.AllowedUsages = *(*[]v1alpha2.KeyUsage)(unsafe.Pointer(&in.AllowedUsages))
+	out.AllowedKeyAlgorithms = *(*[]v1alpha2.KeyAlgorithm)(unsafe.Pointer(&in.AllowedKeyAlgorithms))
+	out.MinRSAKeySize = in.MinRSAKeySize
+	out.MinECDSAKeySize = in.MinECDSAKeySize
+	return nil
+}
+
+// Convert_certmanager_CertificateRequestPolicyConstraints_To_v1alpha2_CertificateRequestPolicyConstraints is an autogenerated conversion function.
+func Convert_certmanager_CertificateRequestPolicyConstraints_To_v1alpha2_CertificateRequestPolicyConstraints(in *certmanager.CertificateRequestPolicyConstraints, out *v1alpha2.CertificateRequestPolicyConstraints, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRequestPolicyConstraints_To_v1alpha2_CertificateRequestPolicyConstraints(in, out, s)
+}
+
+func autoConvert_v1alpha2_CertificateRequestPolicyList_To_certmanager_CertificateRequestPolicyList(in *v1alpha2.CertificateRequestPolicyList, out *certmanager.CertificateRequestPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]certmanager.CertificateRequestPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha2_CertificateRequestPolicyList_To_certmanager_CertificateRequestPolicyList is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateRequestPolicyList_To_certmanager_CertificateRequestPolicyList(in *v1alpha2.CertificateRequestPolicyList, out *certmanager.CertificateRequestPolicyList, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateRequestPolicyList_To_certmanager_CertificateRequestPolicyList(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRequestPolicyList_To_v1alpha2_CertificateRequestPolicyList(in *certmanager.CertificateRequestPolicyList, out *v1alpha2.CertificateRequestPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]v1alpha2.CertificateRequestPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_certmanager_CertificateRequestPolicyList_To_v1alpha2_CertificateRequestPolicyList is an autogenerated conversion function.
+func Convert_certmanager_CertificateRequestPolicyList_To_v1alpha2_CertificateRequestPolicyList(in *certmanager.CertificateRequestPolicyList, out *v1alpha2.CertificateRequestPolicyList, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRequestPolicyList_To_v1alpha2_CertificateRequestPolicyList(in, out, s)
+}
+
+func autoConvert_v1alpha2_CertificateRequestPolicyServiceAccount_To_certmanager_CertificateRequestPolicyServiceAccount(in *v1alpha2.CertificateRequestPolicyServiceAccount, out *certmanager.CertificateRequestPolicyServiceAccount, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_v1alpha2_CertificateRequestPolicyServiceAccount_To_certmanager_CertificateRequestPolicyServiceAccount is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateRequestPolicyServiceAccount_To_certmanager_CertificateRequestPolicyServiceAccount(in *v1alpha2.CertificateRequestPolicyServiceAccount, out *certmanager.CertificateRequestPolicyServiceAccount, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateRequestPolicyServiceAccount_To_certmanager_CertificateRequestPolicyServiceAccount(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRequestPolicyServiceAccount_To_v1alpha2_CertificateRequestPolicyServiceAccount(in *certmanager.CertificateRequestPolicyServiceAccount, out *v1alpha2.CertificateRequestPolicyServiceAccount, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_certmanager_CertificateRequestPolicyServiceAccount_To_v1alpha2_CertificateRequestPolicyServiceAccount is an autogenerated conversion function.
+func Convert_certmanager_CertificateRequestPolicyServiceAccount_To_v1alpha2_CertificateRequestPolicyServiceAccount(in *certmanager.CertificateRequestPolicyServiceAccount, out *v1alpha2.CertificateRequestPolicyServiceAccount, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRequestPolicyServiceAccount_To_v1alpha2_CertificateRequestPolicyServiceAccount(in, out, s)
+}
+
+func autoConvert_v1alpha2_CertificateRequestPolicySpec_To_certmanager_CertificateRequestPolicySpec(in *v1alpha2.CertificateRequestPolicySpec, out *certmanager.CertificateRequestPolicySpec, s conversion.Scope) error {
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.IssuerRef, &out.IssuerRef, 0); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha2_CertificateRequestPolicySubjects_To_certmanager_CertificateRequestPolicySubjects(&in.Subjects, &out.Subjects, s); err != nil {
+		return err
+	}
+	out.Constraints = (*certmanager.CertificateRequestPolicyConstraints)(unsafe.Pointer(in.Constraints))
+	return nil
+}
+
+// Convert_v1alpha2_CertificateRequestPolicySpec_To_certmanager_CertificateRequestPolicySpec is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateRequestPolicySpec_To_certmanager_CertificateRequestPolicySpec(in *v1alpha2.CertificateRequestPolicySpec, out *certmanager.CertificateRequestPolicySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateRequestPolicySpec_To_certmanager_CertificateRequestPolicySpec(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRequestPolicySpec_To_v1alpha2_CertificateRequestPolicySpec(in *certmanager.CertificateRequestPolicySpec, out *v1alpha2.CertificateRequestPolicySpec, s conversion.Scope) error {
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.IssuerRef, &out.IssuerRef, 0); err != nil {
+		return err
+	}
+	if err := Convert_certmanager_CertificateRequestPolicySubjects_To_v1alpha2_CertificateRequestPolicySubjects(&in.Subjects, &out.Subjects, s); err != nil {
+		return err
+	}
+	out.Constraints = (*v1alpha2.CertificateRequestPolicyConstraints)(unsafe.Pointer(in.Constraints))
+	return nil
+}
+
+// Convert_certmanager_CertificateRequestPolicySpec_To_v1alpha2_CertificateRequestPolicySpec is an autogenerated conversion function.
+func Convert_certmanager_CertificateRequestPolicySpec_To_v1alpha2_CertificateRequestPolicySpec(in *certmanager.CertificateRequestPolicySpec, out *v1alpha2.CertificateRequestPolicySpec, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRequestPolicySpec_To_v1alpha2_CertificateRequestPolicySpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_CertificateRequestPolicySubjects_To_certmanager_CertificateRequestPolicySubjects(in *v1alpha2.CertificateRequestPolicySubjects, out *certmanager.CertificateRequestPolicySubjects, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.ServiceAccounts = *(*[]certmanager.CertificateRequestPolicyServiceAccount)(unsafe.Pointer(&in.ServiceAccounts))
+	return nil
+}
+
+// Convert_v1alpha2_CertificateRequestPolicySubjects_To_certmanager_CertificateRequestPolicySubjects is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateRequestPolicySubjects_To_certmanager_CertificateRequestPolicySubjects(in *v1alpha2.CertificateRequestPolicySubjects, out *certmanager.CertificateRequestPolicySubjects, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateRequestPolicySubjects_To_certmanager_CertificateRequestPolicySubjects(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRequestPolicySubjects_To_v1alpha2_CertificateRequestPolicySubjects(in *certmanager.CertificateRequestPolicySubjects, out *v1alpha2.CertificateRequestPolicySubjects, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.ServiceAccounts = *(*[]v1alpha2.CertificateRequestPolicyServiceAccount)(unsafe.Pointer(&in.ServiceAccounts))
+	return nil
+}
+
+// Convert_certmanager_CertificateRequestPolicySubjects_To_v1alpha2_CertificateRequestPolicySubjects is an autogenerated conversion function.
+func Convert_certmanager_CertificateRequestPolicySubjects_To_v1alpha2_CertificateRequestPolicySubjects(in *certmanager.CertificateRequestPolicySubjects, out *v1alpha2.CertificateRequestPolicySubjects, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRequestPolicySubjects_To_v1alpha2_CertificateRequestPolicySubjects(in, out, s)
+}
+
 func autoConvert_v1alpha2_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(in *v1alpha2.CertificateRequestSpec, out *certmanager.CertificateRequestSpec, s conversion.Scope) error {
 	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
 	// TODO: Inefficient conversion - can we improve it?
@@ -517,6 +991,9 @@ func autoConvert_v1alpha2_CertificateRequestSpec_To_certmanager_CertificateReque
 	out.CSRPEM = *(*[]byte)(unsafe.Pointer(&in.CSRPEM))
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.Username = in.Username
+	out.UID = in.UID
+	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
 	return nil
 }
 
@@ -534,6 +1011,9 @@ func autoConvert_certmanager_CertificateRequestSpec_To_v1alpha2_CertificateReque
 	out.CSRPEM = *(*[]byte)(unsafe.Pointer(&in.CSRPEM))
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]v1alpha2.KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.Username = in.Username
+	out.UID = in.UID
+	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
 	return nil
 }
 
@@ -568,6 +1048,52 @@ func Convert_certmanager_CertificateRequestStatus_To_v1alpha2_CertificateRequest
 	return autoConvert_certmanager_CertificateRequestStatus_To_v1alpha2_CertificateRequestStatus(in, out, s)
 }
 
+func autoConvert_v1alpha2_CertificateRevocation_To_certmanager_CertificateRevocation(in *v1alpha2.CertificateRevocation, out *certmanager.CertificateRevocation, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.Reason = in.Reason
+	out.RevocationTime = (*v1.Time)(unsafe.Pointer(in.RevocationTime))
+	return nil
+}
+
+// Convert_v1alpha2_CertificateRevocation_To_certmanager_CertificateRevocation is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateRevocation_To_certmanager_CertificateRevocation(in *v1alpha2.CertificateRevocation, out *certmanager.CertificateRevocation, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateRevocation_To_certmanager_CertificateRevocation(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRevocation_To_v1alpha2_CertificateRevocation(in *certmanager.CertificateRevocation, out *v1alpha2.CertificateRevocation, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.Reason = in.Reason
+	out.RevocationTime = (*v1.Time)(unsafe.Pointer(in.RevocationTime))
+	return nil
+}
+
+// Convert_certmanager_CertificateRevocation_To_v1alpha2_CertificateRevocation is an autogenerated conversion function.
+func Convert_certmanager_CertificateRevocation_To_v1alpha2_CertificateRevocation(in *certmanager.CertificateRevocation, out *v1alpha2.CertificateRevocation, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRevocation_To_v1alpha2_CertificateRevocation(in, out, s)
+}
+
+func autoConvert_v1alpha2_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *v1alpha2.CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1alpha2_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *v1alpha2.CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateSecretTemplate_To_v1alpha2_CertificateSecretTemplate(in *certmanager.CertificateSecretTemplate, out *v1alpha2.CertificateSecretTemplate, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_certmanager_CertificateSecretTemplate_To_v1alpha2_CertificateSecretTemplate is an autogenerated conversion function.
+func Convert_certmanager_CertificateSecretTemplate_To_v1alpha2_CertificateSecretTemplate(in *certmanager.CertificateSecretTemplate, out *v1alpha2.CertificateSecretTemplate, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateSecretTemplate_To_v1alpha2_CertificateSecretTemplate(in, out, s)
+}
+
 func autoConvert_v1alpha2_CertificateSpec_To_certmanager_CertificateSpec(in *v1alpha2.CertificateSpec, out *certmanager.CertificateSpec, s conversion.Scope) error {
 	out.Subject = (*certmanager.X509Subject)(unsafe.Pointer(in.Subject))
 	out.CommonName = in.CommonName
@@ -578,6 +1104,7 @@ func autoConvert_v1alpha2_CertificateSpec_To_certmanager_CertificateSpec(in *v1a
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URISANs = *(*[]string)(unsafe.Pointer(&in.URISANs))
 	out.SecretName = in.SecretName
+	out.SecretTemplate = (*certmanager.CertificateSecretTemplate)(unsafe.Pointer(in.SecretTemplate))
 	// TODO: Inefficient conversion - can we improve it?
 	if err := s.Convert(&in.IssuerRef, &out.IssuerRef, 0); err != nil {
 		return err
@@ -587,6 +1114,10 @@ func autoConvert_v1alpha2_CertificateSpec_To_certmanager_CertificateSpec(in *v1a
 	out.KeySize = in.KeySize
 	out.KeyAlgorithm = certmanager.KeyAlgorithm(in.KeyAlgorithm)
 	out.KeyEncoding = certmanager.KeyEncoding(in.KeyEncoding)
+	out.PrivateKey = (*certmanager.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
+	out.Keystores = (*certmanager.CertificateKeystores)(unsafe.Pointer(in.Keystores))
+	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	return nil
 }
 
@@ -605,6 +1136,7 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha2_CertificateSpec(in *cer
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URISANs = *(*[]string)(unsafe.Pointer(&in.URISANs))
 	out.SecretName = in.SecretName
+	out.SecretTemplate = (*v1alpha2.CertificateSecretTemplate)(unsafe.Pointer(in.SecretTemplate))
 	// TODO: Inefficient conversion - can we improve it?
 	if err := s.Convert(&in.IssuerRef, &out.IssuerRef, 0); err != nil {
 		return err
@@ -614,6 +1146,10 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha2_CertificateSpec(in *cer
 	out.KeySize = in.KeySize
 	out.KeyAlgorithm = v1alpha2.KeyAlgorithm(in.KeyAlgorithm)
 	out.KeyEncoding = v1alpha2.KeyEncoding(in.KeyEncoding)
+	out.PrivateKey = (*v1alpha2.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
+	out.Keystores = (*v1alpha2.CertificateKeystores)(unsafe.Pointer(in.Keystores))
+	out.AdditionalOutputFormats = *(*[]v1alpha2.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	return nil
 }
 
@@ -625,7 +1161,16 @@ func Convert_certmanager_CertificateSpec_To_v1alpha2_CertificateSpec(in *certman
 func autoConvert_v1alpha2_CertificateStatus_To_certmanager_CertificateStatus(in *v1alpha2.CertificateStatus, out *certmanager.CertificateStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.CertificateCondition)(unsafe.Pointer(&in.Conditions))
 	out.LastFailureTime = (*v1.Time)(unsafe.Pointer(in.LastFailureTime))
+	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.FailedIssuanceSpecHash = in.FailedIssuanceSpecHash
+	out.NextRetryTime = (*v1.Time)(unsafe.Pointer(in.NextRetryTime))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
+	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
+	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.Revision = (*int)(unsafe.Pointer(in.Revision))
+	out.CertificateRequestName = in.CertificateRequestName
+	out.Revocation = (*certmanager.CertificateRevocation)(unsafe.Pointer(in.Revocation))
+	out.SuggestedRenewalWindow = (*certmanager.CertificateRenewalWindow)(unsafe.Pointer(in.SuggestedRenewalWindow))
 	return nil
 }
 
@@ -637,7 +1182,16 @@ func Convert_v1alpha2_CertificateStatus_To_certmanager_CertificateStatus(in *v1a
 func autoConvert_certmanager_CertificateStatus_To_v1alpha2_CertificateStatus(in *certmanager.CertificateStatus, out *v1alpha2.CertificateStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]v1alpha2.CertificateCondition)(unsafe.Pointer(&in.Conditions))
 	out.LastFailureTime = (*v1.Time)(unsafe.Pointer(in.LastFailureTime))
+	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.FailedIssuanceSpecHash = in.FailedIssuanceSpecHash
+	out.NextRetryTime = (*v1.Time)(unsafe.Pointer(in.NextRetryTime))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
+	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
+	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.Revision = (*int)(unsafe.Pointer(in.Revision))
+	out.CertificateRequestName = in.CertificateRequestName
+	out.Revocation = (*v1alpha2.CertificateRevocation)(unsafe.Pointer(in.Revocation))
+	out.SuggestedRenewalWindow = (*v1alpha2.CertificateRenewalWindow)(unsafe.Pointer(in.SuggestedRenewalWindow))
 	return nil
 }
 
@@ -856,6 +1410,62 @@ func Convert_certmanager_IssuerStatus_To_v1alpha2_IssuerStatus(in *certmanager.I
 	return autoConvert_certmanager_IssuerStatus_To_v1alpha2_IssuerStatus(in, out, s)
 }
 
+func autoConvert_v1alpha2_JKSKeystore_To_certmanager_JKSKeystore(in *v1alpha2.JKSKeystore, out *certmanager.JKSKeystore, s conversion.Scope) error {
+	out.Create = in.Create
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.PasswordSecretRef, &out.PasswordSecretRef, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_JKSKeystore_To_certmanager_JKSKeystore is an autogenerated conversion function.
+func Convert_v1alpha2_JKSKeystore_To_certmanager_JKSKeystore(in *v1alpha2.JKSKeystore, out *certmanager.JKSKeystore, s conversion.Scope) error {
+	return autoConvert_v1alpha2_JKSKeystore_To_certmanager_JKSKeystore(in, out, s)
+}
+
+func autoConvert_certmanager_JKSKeystore_To_v1alpha2_JKSKeystore(in *certmanager.JKSKeystore, out *v1alpha2.JKSKeystore, s conversion.Scope) error {
+	out.Create = in.Create
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.PasswordSecretRef, &out.PasswordSecretRef, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_JKSKeystore_To_v1alpha2_JKSKeystore is an autogenerated conversion function.
+func Convert_certmanager_JKSKeystore_To_v1alpha2_JKSKeystore(in *certmanager.JKSKeystore, out *v1alpha2.JKSKeystore, s conversion.Scope) error {
+	return autoConvert_certmanager_JKSKeystore_To_v1alpha2_JKSKeystore(in, out, s)
+}
+
+func autoConvert_v1alpha2_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1alpha2.PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
+	out.Create = in.Create
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.PasswordSecretRef, &out.PasswordSecretRef, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_PKCS12Keystore_To_certmanager_PKCS12Keystore is an autogenerated conversion function.
+func Convert_v1alpha2_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1alpha2.PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
+	return autoConvert_v1alpha2_PKCS12Keystore_To_certmanager_PKCS12Keystore(in, out, s)
+}
+
+func autoConvert_certmanager_PKCS12Keystore_To_v1alpha2_PKCS12Keystore(in *certmanager.PKCS12Keystore, out *v1alpha2.PKCS12Keystore, s conversion.Scope) error {
+	out.Create = in.Create
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.PasswordSecretRef, &out.PasswordSecretRef, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_PKCS12Keystore_To_v1alpha2_PKCS12Keystore is an autogenerated conversion function.
+func Convert_certmanager_PKCS12Keystore_To_v1alpha2_PKCS12Keystore(in *certmanager.PKCS12Keystore, out *v1alpha2.PKCS12Keystore, s conversion.Scope) error {
+	return autoConvert_certmanager_PKCS12Keystore_To_v1alpha2_PKCS12Keystore(in, out, s)
+}
+
 func autoConvert_v1alpha2_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1alpha2.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	return nil
 }
@@ -908,6 +1518,7 @@ func autoConvert_v1alpha2_VaultAuth_To_certmanager_VaultAuth(in *v1alpha2.VaultA
 	out.TokenSecretRef = (*meta.SecretKeySelector)(unsafe.Pointer(in.TokenSecretRef))
 	out.AppRole = (*certmanager.VaultAppRole)(unsafe.Pointer(in.AppRole))
 	out.Kubernetes = (*certmanager.VaultKubernetesAuth)(unsafe.Pointer(in.Kubernetes))
+	out.ClientCertificate = (*certmanager.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -920,6 +1531,7 @@ func autoConvert_certmanager_VaultAuth_To_v1alpha2_VaultAuth(in *certmanager.Vau
 	out.TokenSecretRef = (*metav1.SecretKeySelector)(unsafe.Pointer(in.TokenSecretRef))
 	out.AppRole = (*v1alpha2.VaultAppRole)(unsafe.Pointer(in.AppRole))
 	out.Kubernetes = (*v1alpha2.VaultKubernetesAuth)(unsafe.Pointer(in.Kubernetes))
+	out.ClientCertificate = (*v1alpha2.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -928,12 +1540,39 @@ func Convert_certmanager_VaultAuth_To_v1alpha2_VaultAuth(in *certmanager.VaultAu
 	return autoConvert_certmanager_VaultAuth_To_v1alpha2_VaultAuth(in, out, s)
 }
 
+func autoConvert_v1alpha2_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1alpha2.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha2_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_v1alpha2_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1alpha2.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha2_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultClientCertificateAuth_To_v1alpha2_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1alpha2.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_certmanager_VaultClientCertificateAuth_To_v1alpha2_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultClientCertificateAuth_To_v1alpha2_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1alpha2.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultClientCertificateAuth_To_v1alpha2_VaultClientCertificateAuth(in, out, s)
+}
+
 func autoConvert_v1alpha2_VaultIssuer_To_certmanager_VaultIssuer(in *v1alpha2.VaultIssuer, out *certmanager.VaultIssuer, s conversion.Scope) error {
 	if err := Convert_v1alpha2_VaultAuth_To_certmanager_VaultAuth(&in.Auth, &out.Auth, s); err != nil {
 		return err
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.Namespace = in.Namespace
+	out.SignMode = certmanager.VaultSignMode(in.SignMode)
+	out.AllowedParameters = *(*[]string)(unsafe.Pointer(&in.AllowedParameters))
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	return nil
 }
@@ -949,6 +1588,9 @@ func autoConvert_certmanager_VaultIssuer_To_v1alpha2_VaultIssuer(in *certmanager
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.Namespace = in.Namespace
+	out.SignMode = v1alpha2.VaultSignMode(in.SignMode)
+	out.AllowedParameters = *(*[]string)(unsafe.Pointer(&in.AllowedParameters))
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	return nil
 }
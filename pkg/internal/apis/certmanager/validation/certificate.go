@@ -19,7 +19,10 @@ package validation
 import (
 	"fmt"
 	"net"
+	"strings"
 
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -65,8 +68,12 @@ func ValidateCertificateSpec(crt *cmapi.CertificateSpec, fldPath *field.Path) fi
 		if crt.KeySize > 0 && crt.KeySize != 256 && crt.KeySize != 384 && crt.KeySize != 521 {
 			el = append(el, field.NotSupported(fldPath.Child("keySize"), crt.KeySize, []string{"256", "384", "521"}))
 		}
+	case cmapi.Ed25519KeyAlgorithm:
+		if crt.KeySize > 0 {
+			el = append(el, field.Invalid(fldPath.Child("keySize"), crt.KeySize, "must not be specified for ed25519 keyAlgorithm"))
+		}
 	default:
-		el = append(el, field.Invalid(fldPath.Child("keyAlgorithm"), crt.KeyAlgorithm, "must be either empty or one of rsa or ecdsa"))
+		el = append(el, field.Invalid(fldPath.Child("keyAlgorithm"), crt.KeyAlgorithm, "must be either empty or one of rsa, ecdsa or ed25519"))
 	}
 
 	if crt.Duration != nil || crt.RenewBefore != nil {
@@ -80,6 +87,84 @@ func ValidateCertificateSpec(crt *cmapi.CertificateSpec, fldPath *field.Path) fi
 	default:
 		el = append(el, field.Invalid(fldPath.Child("keyEncoding"), crt.KeyEncoding, "must be either empty or one of pkcs1 or pkcs8"))
 	}
+	if crt.PrivateKey != nil {
+		el = append(el, validatePrivateKey(crt.PrivateKey, fldPath.Child("privateKey"))...)
+	}
+	if crt.Keystores != nil {
+		el = append(el, validateKeystores(crt.Keystores, fldPath.Child("keystores"))...)
+	}
+	if crt.SecretTemplate != nil {
+		el = append(el, validateSecretTemplate(crt.SecretTemplate, fldPath.Child("secretTemplate"))...)
+	}
+	if len(crt.AdditionalOutputFormats) > 0 {
+		el = append(el, validateAdditionalOutputFormats(crt.AdditionalOutputFormats, fldPath.Child("additionalOutputFormats"))...)
+	}
+	if crt.RevisionHistoryLimit != nil && *crt.RevisionHistoryLimit < 1 {
+		el = append(el, field.Invalid(fldPath.Child("revisionHistoryLimit"), *crt.RevisionHistoryLimit, "must not be less than 1"))
+	}
+	return el
+}
+
+func validateAdditionalOutputFormats(formats []cmapi.CertificateAdditionalOutputFormat, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	seen := make(map[cmapi.CertificateOutputFormatType]bool)
+	for i, f := range formats {
+		fldPath := fldPath.Index(i).Child("type")
+		switch f.Type {
+		case cmapi.CertificateOutputFormatDER, cmapi.CertificateOutputFormatCombinedPEM:
+		default:
+			el = append(el, field.NotSupported(fldPath, f.Type, []string{string(cmapi.CertificateOutputFormatDER), string(cmapi.CertificateOutputFormatCombinedPEM)}))
+			continue
+		}
+		if seen[f.Type] {
+			el = append(el, field.Duplicate(fldPath, f.Type))
+		}
+		seen[f.Type] = true
+	}
+	return el
+}
+
+func validatePrivateKey(pk *cmapi.CertificatePrivateKey, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	switch pk.RotationPolicy {
+	case cmapi.PrivateKeyRotationPolicy(""), cmapi.RotationPolicyNever, cmapi.RotationPolicyAlways:
+	default:
+		el = append(el, field.Invalid(fldPath.Child("rotationPolicy"), pk.RotationPolicy, "must be either empty or one of Never or Always"))
+	}
+	return el
+}
+
+func validateSecretTemplate(tmpl *cmapi.CertificateSecretTemplate, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	el = append(el, metav1validation.ValidateLabels(tmpl.Labels, fldPath.Child("labels"))...)
+	el = append(el, apivalidation.ValidateAnnotations(tmpl.Annotations, fldPath.Child("annotations"))...)
+	for k := range tmpl.Annotations {
+		if strings.HasPrefix(k, "cert-manager.io/") {
+			el = append(el, field.Invalid(fldPath.Child("annotations"), k, "cert-manager.io/* annotations are reserved for use by cert-manager"))
+		}
+	}
+	return el
+}
+
+func validateKeystores(ks *cmapi.CertificateKeystores, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if ks.JKS != nil && ks.JKS.Create {
+		el = append(el, validateKeystorePasswordSecretRef(ks.JKS.PasswordSecretRef, fldPath.Child("jks", "passwordSecretRef"))...)
+	}
+	if ks.PKCS12 != nil && ks.PKCS12.Create {
+		el = append(el, validateKeystorePasswordSecretRef(ks.PKCS12.PasswordSecretRef, fldPath.Child("pkcs12", "passwordSecretRef"))...)
+	}
+	return el
+}
+
+func validateKeystorePasswordSecretRef(ref cmmeta.SecretKeySelector, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if ref.Name == "" {
+		el = append(el, field.Required(fldPath.Child("name"), "must be specified"))
+	}
+	if ref.Key == "" {
+		el = append(el, field.Required(fldPath.Child("key"), "must be specified"))
+	}
 	return el
 }
 
@@ -41,6 +41,10 @@ func strPtr(s string) *string {
 	return &s
 }
 
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
 func TestValidateCertificate(t *testing.T) {
 	fldPath := field.NewPath("spec")
 	scenarios := map[string]struct {
@@ -297,6 +301,30 @@ func TestValidateCertificate(t *testing.T) {
 				field.NotSupported(fldPath.Child("keySize"), 100, []string{"256", "384", "521"}),
 			},
 		},
+		"valid certificate with ed25519 keyAlgorithm": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName:   "testcn",
+					SecretName:   "abc",
+					IssuerRef:    validIssuerRef,
+					KeyAlgorithm: cmapi.Ed25519KeyAlgorithm,
+				},
+			},
+		},
+		"certificate with ed25519 keyAlgorithm specified and a keysize": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName:   "testcn",
+					SecretName:   "abc",
+					IssuerRef:    validIssuerRef,
+					KeyAlgorithm: cmapi.Ed25519KeyAlgorithm,
+					KeySize:      256,
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("keySize"), 256, "must not be specified for ed25519 keyAlgorithm"),
+			},
+		},
 		"certificate with invalid keyAlgorithm": {
 			cfg: &cmapi.Certificate{
 				Spec: cmapi.CertificateSpec{
@@ -307,7 +335,7 @@ func TestValidateCertificate(t *testing.T) {
 				},
 			},
 			errs: []*field.Error{
-				field.Invalid(fldPath.Child("keyAlgorithm"), cmapi.KeyAlgorithm("blah"), "must be either empty or one of rsa or ecdsa"),
+				field.Invalid(fldPath.Child("keyAlgorithm"), cmapi.KeyAlgorithm("blah"), "must be either empty or one of rsa, ecdsa or ed25519"),
 			},
 		},
 		"valid certificate with ipAddresses": {
@@ -424,6 +452,189 @@ func TestValidateCertificate(t *testing.T) {
 				},
 			},
 		},
+		"valid certificate with privateKey rotationPolicy Always": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					PrivateKey: &cmapi.CertificatePrivateKey{
+						RotationPolicy: cmapi.RotationPolicyAlways,
+					},
+				},
+			},
+		},
+		"valid certificate with empty privateKey options": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					PrivateKey: &cmapi.CertificatePrivateKey{},
+				},
+			},
+		},
+		"valid certificate with secretTemplate": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					SecretTemplate: &cmapi.CertificateSecretTemplate{
+						Labels:      map[string]string{"app.example.com/name": "test"},
+						Annotations: map[string]string{"reflector.v1.k8s.emberstack.com/reflection-allowed": "true"},
+					},
+				},
+			},
+		},
+		"invalid certificate with invalid secretTemplate labels and reserved annotations": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					SecretTemplate: &cmapi.CertificateSecretTemplate{
+						Labels:      map[string]string{"app": "not a valid value"},
+						Annotations: map[string]string{"cert-manager.io/issuer-name": "test"},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("secretTemplate", "labels"), "not a valid value", "a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')"),
+				field.Invalid(fldPath.Child("secretTemplate", "annotations"), "cert-manager.io/issuer-name", "cert-manager.io/* annotations are reserved for use by cert-manager"),
+			},
+		},
+		"valid certificate with additional output formats": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{
+						{Type: cmapi.CertificateOutputFormatDER},
+						{Type: cmapi.CertificateOutputFormatCombinedPEM},
+					},
+				},
+			},
+		},
+		"invalid certificate with unknown and duplicate additional output formats": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{
+						{Type: cmapi.CertificateOutputFormatDER},
+						{Type: "PKCS7"},
+						{Type: cmapi.CertificateOutputFormatDER},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("additionalOutputFormats").Index(1).Child("type"), cmapi.CertificateOutputFormatType("PKCS7"), []string{"DER", "CombinedPEM"}),
+				field.Duplicate(fldPath.Child("additionalOutputFormats").Index(2).Child("type"), cmapi.CertificateOutputFormatDER),
+			},
+		},
+		"valid certificate with jks and pkcs12 keystores": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					Keystores: &cmapi.CertificateKeystores{
+						JKS: &cmapi.JKSKeystore{
+							Create: true,
+							PasswordSecretRef: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{Name: "jks-password"},
+								Key:                  "password",
+							},
+						},
+						PKCS12: &cmapi.PKCS12Keystore{
+							Create: true,
+							PasswordSecretRef: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{Name: "p12-password"},
+								Key:                  "password",
+							},
+						},
+					},
+				},
+			},
+		},
+		"valid certificate with disabled keystore and no password": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					Keystores: &cmapi.CertificateKeystores{
+						JKS: &cmapi.JKSKeystore{},
+					},
+				},
+			},
+		},
+		"invalid certificate with enabled keystores and missing password secret refs": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					Keystores: &cmapi.CertificateKeystores{
+						JKS: &cmapi.JKSKeystore{
+							Create: true,
+							PasswordSecretRef: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{Name: "jks-password"},
+							},
+						},
+						PKCS12: &cmapi.PKCS12Keystore{
+							Create: true,
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("keystores", "jks", "passwordSecretRef", "key"), "must be specified"),
+				field.Required(fldPath.Child("keystores", "pkcs12", "passwordSecretRef", "name"), "must be specified"),
+				field.Required(fldPath.Child("keystores", "pkcs12", "passwordSecretRef", "key"), "must be specified"),
+			},
+		},
+		"invalid certificate with unknown privateKey rotationPolicy": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					PrivateKey: &cmapi.CertificatePrivateKey{
+						RotationPolicy: "Sometimes",
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("privateKey", "rotationPolicy"), cmapi.PrivateKeyRotationPolicy("Sometimes"), "must be either empty or one of Never or Always"),
+			},
+		},
+		"valid certificate with revisionHistoryLimit": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName:           "testcn",
+					SecretName:           "abc",
+					IssuerRef:            validIssuerRef,
+					RevisionHistoryLimit: int32Ptr(3),
+				},
+			},
+		},
+		"invalid certificate with revisionHistoryLimit less than 1": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName:           "testcn",
+					SecretName:           "abc",
+					IssuerRef:            validIssuerRef,
+					RevisionHistoryLimit: int32Ptr(0),
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("revisionHistoryLimit"), int32(0), "must not be less than 1"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -18,6 +18,7 @@ package validation
 
 import (
 	"fmt"
+	"reflect"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -29,9 +30,68 @@ import (
 func ValidateCertificateRequest(obj runtime.Object) field.ErrorList {
 	cr := obj.(*cmapi.CertificateRequest)
 	allErrs := ValidateCertificateRequestSpec(&cr.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validateCertificateRequestApprovalConditions(cr.Status.Conditions, field.NewPath("status", "conditions"))...)
 	return allErrs
 }
 
+func ValidateCertificateRequestUpdate(oldObj, newObj runtime.Object) field.ErrorList {
+	old, ok := oldObj.(*cmapi.CertificateRequest)
+	new := newObj.(*cmapi.CertificateRequest)
+	// if oldObj is not set, the Update operation is always valid.
+	if !ok || old == nil {
+		return nil
+	}
+
+	return validateCertificateRequestApprovalUpdate(old.Status.Conditions, new.Status.Conditions, field.NewPath("status", "conditions"))
+}
+
+// validateCertificateRequestApprovalConditions ensures a CertificateRequest is
+// not both approved and denied.
+func validateCertificateRequestApprovalConditions(conds []cmapi.CertificateRequestCondition, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	_, approved := getCertificateRequestCondition(conds, cmapi.CertificateRequestConditionApproved)
+	_, denied := getCertificateRequestCondition(conds, cmapi.CertificateRequestConditionDenied)
+	if approved >= 0 && denied >= 0 {
+		el = append(el, field.Forbidden(fldPath.Index(denied), fmt.Sprintf("%q and %q conditions may not both be set",
+			cmapi.CertificateRequestConditionApproved, cmapi.CertificateRequestConditionDenied)))
+	}
+	return el
+}
+
+// validateCertificateRequestApprovalUpdate ensures the Approved and Denied
+// conditions of a CertificateRequest are not removed or modified once set.
+func validateCertificateRequestApprovalUpdate(old, new []cmapi.CertificateRequestCondition, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for _, t := range []cmapi.CertificateRequestConditionType{cmapi.CertificateRequestConditionApproved, cmapi.CertificateRequestConditionDenied} {
+		oldCond, _ := getCertificateRequestCondition(old, t)
+		if oldCond == nil {
+			continue
+		}
+
+		newCond, i := getCertificateRequestCondition(new, t)
+		if newCond == nil {
+			el = append(el, field.Forbidden(fldPath, fmt.Sprintf("%q condition may not be removed once set", t)))
+			continue
+		}
+
+		if !reflect.DeepEqual(oldCond, newCond) {
+			el = append(el, field.Forbidden(fldPath.Index(i), fmt.Sprintf("%q condition is immutable once set", t)))
+		}
+	}
+	return el
+}
+
+// getCertificateRequestCondition returns the condition of the given type and
+// its index, or nil and -1 if it is not present.
+func getCertificateRequestCondition(conds []cmapi.CertificateRequestCondition, t cmapi.CertificateRequestConditionType) (*cmapi.CertificateRequestCondition, int) {
+	for i := range conds {
+		if conds[i].Type == t {
+			return &conds[i], i
+		}
+	}
+	return nil, -1
+}
+
 func ValidateCertificateRequestSpec(crSpec *cmapi.CertificateRequestSpec, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
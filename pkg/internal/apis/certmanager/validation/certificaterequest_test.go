@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
+	cmmeta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
+)
+
+func TestValidateCertificateRequestUpdate(t *testing.T) {
+	fldPath := field.NewPath("status", "conditions")
+	approved := cmapi.CertificateRequestCondition{
+		Type:    cmapi.CertificateRequestConditionApproved,
+		Status:  cmmeta.ConditionTrue,
+		Reason:  "Approved",
+		Message: "approved by policy",
+	}
+	denied := cmapi.CertificateRequestCondition{
+		Type:    cmapi.CertificateRequestConditionDenied,
+		Status:  cmmeta.ConditionTrue,
+		Reason:  "Denied",
+		Message: "denied by policy",
+	}
+	ready := cmapi.CertificateRequestCondition{
+		Type:   cmapi.CertificateRequestConditionReady,
+		Status: cmmeta.ConditionFalse,
+		Reason: cmapi.CertificateRequestReasonPending,
+	}
+
+	scenarios := map[string]struct {
+		old, new []cmapi.CertificateRequestCondition
+		errs     []*field.Error
+	}{
+		"setting the Approved condition is allowed": {
+			old: []cmapi.CertificateRequestCondition{ready},
+			new: []cmapi.CertificateRequestCondition{ready, approved},
+		},
+		"updating other conditions of an approved request is allowed": {
+			old: []cmapi.CertificateRequestCondition{approved, ready},
+			new: []cmapi.CertificateRequestCondition{approved, {
+				Type:   cmapi.CertificateRequestConditionReady,
+				Status: cmmeta.ConditionTrue,
+				Reason: cmapi.CertificateRequestReasonIssued,
+			}},
+		},
+		"removing the Approved condition is forbidden": {
+			old: []cmapi.CertificateRequestCondition{approved},
+			new: []cmapi.CertificateRequestCondition{ready},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, `"Approved" condition may not be removed once set`),
+			},
+		},
+		"modifying the Denied condition is forbidden": {
+			old: []cmapi.CertificateRequestCondition{ready, denied},
+			new: []cmapi.CertificateRequestCondition{ready, {
+				Type:    cmapi.CertificateRequestConditionDenied,
+				Status:  cmmeta.ConditionFalse,
+				Reason:  "Denied",
+				Message: "denied by policy",
+			}},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Index(1), `"Denied" condition is immutable once set`),
+			},
+		},
+		"replacing the Denied condition with the Approved condition is forbidden": {
+			old: []cmapi.CertificateRequestCondition{denied},
+			new: []cmapi.CertificateRequestCondition{approved},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, `"Denied" condition may not be removed once set`),
+			},
+		},
+		"adding the Approved condition to a denied request is forbidden": {
+			old: []cmapi.CertificateRequestCondition{denied},
+			new: []cmapi.CertificateRequestCondition{denied, approved},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Index(0), `"Approved" and "Denied" conditions may not both be set`),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			old := &cmapi.CertificateRequest{Status: cmapi.CertificateRequestStatus{Conditions: s.old}}
+			new := &cmapi.CertificateRequest{Status: cmapi.CertificateRequestStatus{Conditions: s.new}}
+			// only the status is under test, so ignore errors for the spec
+			errs := validateCertificateRequestApprovalConditions(new.Status.Conditions, fldPath)
+			errs = append(errs, ValidateCertificateRequestUpdate(old, new)...)
+			if len(errs) != len(s.errs) {
+				t.Errorf("Expected %v but got %v", s.errs, errs)
+				return
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
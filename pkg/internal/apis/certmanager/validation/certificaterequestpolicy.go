@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"net"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/jetstack/cert-manager/pkg/api/util"
+	cmapiv1alpha2 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmapi "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
+)
+
+func ValidateCertificateRequestPolicy(obj runtime.Object) field.ErrorList {
+	crp := obj.(*cmapi.CertificateRequestPolicy)
+	return ValidateCertificateRequestPolicySpec(&crp.Spec, field.NewPath("spec"))
+}
+
+func ValidateCertificateRequestPolicySpec(spec *cmapi.CertificateRequestPolicySpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	el = append(el, validateIssuerRef(spec.IssuerRef, fldPath)...)
+	el = append(el, validatePolicySubjects(spec.Subjects, fldPath.Child("subjects"))...)
+	if spec.Constraints != nil {
+		el = append(el, validatePolicyConstraints(spec.Constraints, fldPath.Child("constraints"))...)
+	}
+
+	return el
+}
+
+func validatePolicySubjects(subjects cmapi.CertificateRequestPolicySubjects, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if len(subjects.Namespaces) == 0 && len(subjects.ServiceAccounts) == 0 {
+		el = append(el, field.Required(fldPath, "at least one namespace or serviceAccount must be specified"))
+	}
+
+	for i, ns := range subjects.Namespaces {
+		if ns == "" {
+			el = append(el, field.Required(fldPath.Child("namespaces").Index(i), "must be specified"))
+		}
+	}
+
+	for i, sa := range subjects.ServiceAccounts {
+		saPath := fldPath.Child("serviceAccounts").Index(i)
+		if sa.Name == "" {
+			el = append(el, field.Required(saPath.Child("name"), "must be specified"))
+		}
+		if sa.Namespace == "" {
+			el = append(el, field.Required(saPath.Child("namespace"), "must be specified"))
+		}
+	}
+
+	return el
+}
+
+func validatePolicyConstraints(constraints *cmapi.CertificateRequestPolicyConstraints, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	for i, pattern := range constraints.AllowedDNSNames {
+		if pattern == "" {
+			el = append(el, field.Required(fldPath.Child("allowedDNSNames").Index(i), "must be specified"))
+		}
+	}
+
+	for i, pattern := range constraints.AllowedURISANs {
+		if pattern == "" {
+			el = append(el, field.Required(fldPath.Child("allowedURISANs").Index(i), "must be specified"))
+		}
+	}
+
+	for i, cidr := range constraints.AllowedIPRanges {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("allowedIPRanges").Index(i), cidr, "invalid CIDR range"))
+		}
+	}
+
+	if constraints.MaxDuration != nil && constraints.MaxDuration.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("maxDuration"), constraints.MaxDuration.Duration, "must be greater than zero"))
+	}
+
+	for i, u := range constraints.AllowedUsages {
+		_, kok := util.KeyUsageType(cmapiv1alpha2.KeyUsage(u))
+		_, ekok := util.ExtKeyUsageType(cmapiv1alpha2.KeyUsage(u))
+		if !kok && !ekok {
+			el = append(el, field.Invalid(fldPath.Child("allowedUsages").Index(i), u, "unknown keyusage"))
+		}
+	}
+
+	for i, alg := range constraints.AllowedKeyAlgorithms {
+		switch alg {
+		case cmapi.RSAKeyAlgorithm, cmapi.ECDSAKeyAlgorithm, cmapi.Ed25519KeyAlgorithm:
+		default:
+			el = append(el, field.Invalid(fldPath.Child("allowedKeyAlgorithms").Index(i), alg, "must be one of rsa, ecdsa or ed25519"))
+		}
+	}
+
+	if constraints.MinRSAKeySize < 0 {
+		el = append(el, field.Invalid(fldPath.Child("minRSAKeySize"), constraints.MinRSAKeySize, "must not be negative"))
+	}
+	if constraints.MinECDSAKeySize < 0 {
+		el = append(el, field.Invalid(fldPath.Child("minECDSAKeySize"), constraints.MinECDSAKeySize, "must not be negative"))
+	}
+
+	return el
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
+	cmmeta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
+)
+
+func TestValidateCertificateRequestPolicy(t *testing.T) {
+	fldPath := field.NewPath("spec")
+	validSubjects := cmapi.CertificateRequestPolicySubjects{
+		Namespaces: []string{"team-a"},
+	}
+
+	scenarios := map[string]struct {
+		cfg  *cmapi.CertificateRequestPolicy
+		errs []*field.Error
+	}{
+		"valid policy with namespace subjects": {
+			cfg: &cmapi.CertificateRequestPolicy{
+				Spec: cmapi.CertificateRequestPolicySpec{
+					IssuerRef: validIssuerRef,
+					Subjects:  validSubjects,
+				},
+			},
+		},
+		"valid policy with serviceAccount subjects and constraints": {
+			cfg: &cmapi.CertificateRequestPolicy{
+				Spec: cmapi.CertificateRequestPolicySpec{
+					IssuerRef: validIssuerRef,
+					Subjects: cmapi.CertificateRequestPolicySubjects{
+						ServiceAccounts: []cmapi.CertificateRequestPolicyServiceAccount{
+							{Name: "app", Namespace: "team-a"},
+						},
+					},
+					Constraints: &cmapi.CertificateRequestPolicyConstraints{
+						AllowedDNSNames:      []string{"*.team-a.example.com"},
+						AllowedURISANs:       []string{"spiffe://cluster.local/ns/team-a/*"},
+						AllowedIPRanges:      []string{"10.0.0.0/8", "fd00::/8"},
+						MaxDuration:          &metav1.Duration{Duration: 24 * time.Hour},
+						AllowedUsages:        []cmapi.KeyUsage{cmapi.UsageServerAuth},
+						AllowedKeyAlgorithms: []cmapi.KeyAlgorithm{cmapi.ECDSAKeyAlgorithm},
+						MinRSAKeySize:        2048,
+						MinECDSAKeySize:      256,
+					},
+				},
+			},
+		},
+		"missing issuerRef name": {
+			cfg: &cmapi.CertificateRequestPolicy{
+				Spec: cmapi.CertificateRequestPolicySpec{
+					Subjects: validSubjects,
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("issuerRef", "name"), "must be specified"),
+			},
+		},
+		"invalid issuerRef kind": {
+			cfg: &cmapi.CertificateRequestPolicy{
+				Spec: cmapi.CertificateRequestPolicySpec{
+					IssuerRef: cmmeta.ObjectReference{Name: "abc", Kind: "AWSPCAIssuer"},
+					Subjects:  validSubjects,
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("issuerRef", "kind"), "AWSPCAIssuer", "must be one of Issuer or ClusterIssuer"),
+			},
+		},
+		"missing subjects": {
+			cfg: &cmapi.CertificateRequestPolicy{
+				Spec: cmapi.CertificateRequestPolicySpec{
+					IssuerRef: validIssuerRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("subjects"), "at least one namespace or serviceAccount must be specified"),
+			},
+		},
+		"incomplete subjects": {
+			cfg: &cmapi.CertificateRequestPolicy{
+				Spec: cmapi.CertificateRequestPolicySpec{
+					IssuerRef: validIssuerRef,
+					Subjects: cmapi.CertificateRequestPolicySubjects{
+						Namespaces: []string{""},
+						ServiceAccounts: []cmapi.CertificateRequestPolicyServiceAccount{
+							{Name: "app"},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("subjects", "namespaces").Index(0), "must be specified"),
+				field.Required(fldPath.Child("subjects", "serviceAccounts").Index(0).Child("namespace"), "must be specified"),
+			},
+		},
+		"invalid constraints": {
+			cfg: &cmapi.CertificateRequestPolicy{
+				Spec: cmapi.CertificateRequestPolicySpec{
+					IssuerRef: validIssuerRef,
+					Subjects:  validSubjects,
+					Constraints: &cmapi.CertificateRequestPolicyConstraints{
+						AllowedDNSNames:      []string{""},
+						AllowedIPRanges:      []string{"10.0.0.1"},
+						MaxDuration:          &metav1.Duration{},
+						AllowedUsages:        []cmapi.KeyUsage{"nonexistent"},
+						AllowedKeyAlgorithms: []cmapi.KeyAlgorithm{"dsa"},
+						MinRSAKeySize:        -1,
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("constraints", "allowedDNSNames").Index(0), "must be specified"),
+				field.Invalid(fldPath.Child("constraints", "allowedIPRanges").Index(0), "10.0.0.1", "invalid CIDR range"),
+				field.Invalid(fldPath.Child("constraints", "maxDuration"), metav1.Duration{}.Duration, "must be greater than zero"),
+				field.Invalid(fldPath.Child("constraints", "allowedUsages").Index(0), cmapi.KeyUsage("nonexistent"), "unknown keyusage"),
+				field.Invalid(fldPath.Child("constraints", "allowedKeyAlgorithms").Index(0), cmapi.KeyAlgorithm("dsa"), "must be one of rsa, ecdsa or ed25519"),
+				field.Invalid(fldPath.Child("constraints", "minRSAKeySize"), -1, "must not be negative"),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateCertificateRequestPolicy(s.cfg)
+			if len(errs) != len(s.errs) {
+				t.Errorf("Expected %v but got %v", s.errs, errs)
+				return
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
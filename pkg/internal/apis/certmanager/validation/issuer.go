@@ -19,16 +19,20 @@ package validation
 import (
 	"crypto/x509"
 	"fmt"
+	"net/url"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapiv1alpha2 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmacme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
 	"github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
 	"github.com/jetstack/cert-manager/pkg/internal/apis/certmanager/validation/util"
 	cmmeta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
 
 // Validation functions for cert-manager v1alpha2 Issuer types
@@ -121,6 +125,24 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) field
 		el = append(el, ValidateACMEIssuerChallengeSolverConfig(&sol, fldPath.Child("solvers").Index(i))...)
 	}
 
+	switch iss.RevocationPolicy {
+	case cmacme.ACMERevocationPolicy(""), cmacme.ACMERevocationPolicyNever, cmacme.ACMERevocationPolicyOnDelete:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("revocationPolicy"), iss.RevocationPolicy, []string{string(cmacme.ACMERevocationPolicyNever), string(cmacme.ACMERevocationPolicyOnDelete)}))
+	}
+
+	switch iss.AccountDeactivationPolicy {
+	case cmacme.ACMEAccountDeactivationPolicy(""), cmacme.ACMEAccountDeactivationPolicyNever, cmacme.ACMEAccountDeactivationPolicyOnDelete:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("accountDeactivationPolicy"), iss.AccountDeactivationPolicy, []string{string(cmacme.ACMEAccountDeactivationPolicyNever), string(cmacme.ACMEAccountDeactivationPolicyOnDelete)}))
+	}
+
+	for i, email := range iss.AdditionalEmails {
+		if len(email) == 0 {
+			el = append(el, field.Required(fldPath.Child("additionalEmails").Index(i), "email address must not be empty"))
+		}
+	}
+
 	return el
 }
 
@@ -140,6 +162,14 @@ func ValidateACMEIssuerChallengeSolverConfig(sol *cmacme.ACMEChallengeSolver, fl
 			el = append(el, ValidateACMEChallengeSolverDNS01(sol.DNS01, fldPath.Child("dns01"))...)
 		}
 	}
+	if sol.TLSALPN01 != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath, "may not specify more than one solver type in a single solver"))
+		} else {
+			numProviders++
+			el = append(el, ValidateACMEIssuerChallengeSolverTLSALPN01Config(sol.TLSALPN01, fldPath.Child("tlsalpn01"))...)
+		}
+	}
 	if numProviders == 0 {
 		el = append(el, field.Required(fldPath, "no solver type configured"))
 	}
@@ -155,6 +185,14 @@ func ValidateACMEIssuerChallengeSolverHTTP01Config(http01 *cmacme.ACMEChallengeS
 		numDefined++
 		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01IngressConfig(http01.Ingress, fldPath.Child("ingress"))...)
 	}
+	if http01.GatewayHTTPRoute != nil {
+		if numDefined > 0 {
+			el = append(el, field.Forbidden(fldPath, "only one of 'ingress' or 'gatewayHTTPRoute' should be specified"))
+		} else {
+			numDefined++
+			el = append(el, ValidateACMEIssuerChallengeSolverHTTP01GatewayHTTPRouteConfig(http01.GatewayHTTPRoute, fldPath.Child("gatewayHTTPRoute"))...)
+		}
+	}
 	if numDefined == 0 {
 		el = append(el, field.Required(fldPath, "no HTTP01 solver type configured"))
 	}
@@ -177,11 +215,90 @@ func ValidateACMEIssuerChallengeSolverHTTP01IngressConfig(ingress *cmacme.ACMECh
 	return el
 }
 
+func ValidateACMEIssuerChallengeSolverHTTP01GatewayHTTPRouteConfig(route *cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	switch route.ServiceType {
+	case "", corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort:
+	default:
+		el = append(el, field.Invalid(fldPath.Child("serviceType"), route.ServiceType, `must be empty, "ClusterIP" or "NodePort"`))
+	}
+
+	if len(route.ParentRefs) == 0 {
+		el = append(el, field.Required(fldPath.Child("parentRefs"), "at least one parent reference must be specified"))
+	}
+	for i, ref := range route.ParentRefs {
+		if len(ref.Name) == 0 {
+			el = append(el, field.Required(fldPath.Child("parentRefs").Index(i).Child("name"), ""))
+		}
+	}
+
+	return el
+}
+
+func ValidateACMEIssuerChallengeSolverTLSALPN01Config(tlsalpn01 *cmacme.ACMEChallengeSolverTLSALPN01, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	switch tlsalpn01.ServiceType {
+	case "", corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort:
+	default:
+		el = append(el, field.Invalid(fldPath.Child("serviceType"), tlsalpn01.ServiceType, `must be empty, "ClusterIP" or "NodePort"`))
+	}
+
+	return el
+}
+
 func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	if len(iss.SecretName) == 0 {
 		el = append(el, field.Required(fldPath.Child("secretName"), ""))
 	}
+	el = append(el, validateAbsoluteURLs(iss.CRLDistributionPoints, fldPath.Child("crlDistributionPoints"))...)
+	el = append(el, validateAbsoluteURLs(iss.OCSPServers, fldPath.Child("ocspServers"))...)
+	if iss.CRL != nil {
+		el = append(el, validateCACRLConfig(iss.CRL, iss.SecretName, fldPath.Child("crl"))...)
+	}
+	return el
+}
+
+func validateCACRLConfig(crl *certmanager.CACRLConfig, caSecretName string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(crl.SecretName) == 0 {
+		el = append(el, field.Required(fldPath.Child("secretName"), ""))
+	} else if crl.SecretName == caSecretName {
+		el = append(el, field.Invalid(fldPath.Child("secretName"), crl.SecretName, "must not be the secret containing the signing key pair"))
+	}
+
+	duration := apiutil.DefaultCRLDuration(crl.Duration)
+	if duration < cmapiv1alpha2.MinimumCRLDuration {
+		el = append(el, field.Invalid(fldPath.Child("duration"), duration, fmt.Sprintf("CRL duration must be greater than %s", cmapiv1alpha2.MinimumCRLDuration)))
+	}
+
+	seen := make(map[string]bool)
+	for i, rc := range crl.RevokedCertificates {
+		serialPath := fldPath.Child("revokedCertificates").Index(i).Child("serialNumber")
+		n, err := pki.ParseSerialNumber(rc.SerialNumber)
+		if err != nil {
+			el = append(el, field.Invalid(serialPath, rc.SerialNumber, err.Error()))
+			continue
+		}
+		if seen[n.String()] {
+			el = append(el, field.Duplicate(serialPath, rc.SerialNumber))
+		}
+		seen[n.String()] = true
+	}
+
+	return el
+}
+
+func validateAbsoluteURLs(urls []string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for i, u := range urls {
+		parsed, err := url.Parse(u)
+		if err != nil || !parsed.IsAbs() {
+			el = append(el, field.Invalid(fldPath.Index(i), u, "must be an absolute URL"))
+		}
+	}
 	return el
 }
 
@@ -198,6 +315,16 @@ func ValidateVaultIssuerConfig(iss *certmanager.VaultIssuer, fldPath *field.Path
 		el = append(el, field.Required(fldPath.Child("path"), ""))
 	}
 
+	switch iss.SignMode {
+	case certmanager.VaultSignMode(""), certmanager.VaultSignModeRole:
+	case certmanager.VaultSignModeVerbatim:
+		if len(iss.Path) > 0 && !isVaultSignVerbatimPath(iss.Path) {
+			el = append(el, field.Invalid(fldPath.Child("path"), iss.Path, "must be a sign-verbatim endpoint when signMode is Verbatim"))
+		}
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("signMode"), iss.SignMode, []string{string(certmanager.VaultSignModeRole), string(certmanager.VaultSignModeVerbatim)}))
+	}
+
 	// check if caBundle is valid
 	certs := iss.CABundle
 	if len(certs) > 0 {
@@ -208,10 +335,25 @@ func ValidateVaultIssuerConfig(iss *certmanager.VaultIssuer, fldPath *field.Path
 		}
 	}
 
+	if iss.Auth.ClientCertificate != nil && len(iss.Auth.ClientCertificate.SecretName) == 0 {
+		el = append(el, field.Required(fldPath.Child("auth", "clientCertificate", "secretName"), ""))
+	}
+
 	return el
 	// TODO: add validation for Vault authentication types
 }
 
+// isVaultSignVerbatimPath returns true if the given Vault path refers to a PKI
+// secrets engine sign-verbatim endpoint, with or without a role.
+func isVaultSignVerbatimPath(p string) bool {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	n := len(segments)
+	if n >= 2 && segments[n-1] == "sign-verbatim" {
+		return true
+	}
+	return n >= 3 && segments[n-2] == "sign-verbatim"
+}
+
 func ValidateVenafiIssuerConfig(iss *certmanager.VenafiIssuer, fldPath *field.Path) field.ErrorList {
 	//TODO: make extended validation fro fake\tpp\cloud modes
 	return nil
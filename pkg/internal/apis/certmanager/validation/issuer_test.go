@@ -19,8 +19,10 @@ package validation
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	cmacme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
@@ -83,6 +85,57 @@ func TestValidateVaultIssuerConfig(t *testing.T) {
 				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
 			},
 		},
+		"vault issuer with client certificate auth": {
+			spec: &cmapi.VaultIssuer{
+				Server: "something",
+				Path:   "a/b/c",
+				Auth: cmapi.VaultAuth{
+					ClientCertificate: &cmapi.VaultClientCertificateAuth{
+						SecretName: "vault-client-tls",
+					},
+				},
+			},
+		},
+		"vault issuer with client certificate auth missing secretName": {
+			spec: &cmapi.VaultIssuer{
+				Server: "something",
+				Path:   "a/b/c",
+				Auth: cmapi.VaultAuth{
+					ClientCertificate: &cmapi.VaultClientCertificateAuth{},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("auth", "clientCertificate", "secretName"), ""),
+			},
+		},
+		"vault issuer with verbatim sign mode": {
+			spec: &cmapi.VaultIssuer{
+				Server:    "something",
+				Path:      "pki/sign-verbatim/my-role",
+				Namespace: "team-a",
+				SignMode:  cmapi.VaultSignModeVerbatim,
+			},
+		},
+		"vault issuer with verbatim sign mode and role path": {
+			spec: &cmapi.VaultIssuer{
+				Server:   "something",
+				Path:     "pki/sign/my-role",
+				SignMode: cmapi.VaultSignModeVerbatim,
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("path"), "pki/sign/my-role", "must be a sign-verbatim endpoint when signMode is Verbatim"),
+			},
+		},
+		"vault issuer with unknown sign mode": {
+			spec: &cmapi.VaultIssuer{
+				Server:   "something",
+				Path:     "a/b/c",
+				SignMode: "Unknown",
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("signMode"), cmapi.VaultSignMode("Unknown"), []string{"Role", "Verbatim"}),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -101,6 +154,96 @@ func TestValidateVaultIssuerConfig(t *testing.T) {
 	}
 }
 
+func TestValidateCAIssuerConfig(t *testing.T) {
+	fldPath := field.NewPath("")
+	scenarios := map[string]struct {
+		spec *cmapi.CAIssuer
+		errs []*field.Error
+	}{
+		"valid ca issuer": {
+			spec: &cmapi.CAIssuer{
+				SecretName:            "ca-key-pair",
+				CRLDistributionPoints: []string{"http://crl.example.com/ca.crl", "ldap:///CN=ca,DC=example,DC=com"},
+				OCSPServers:           []string{"http://ocsp.example.com"},
+			},
+		},
+		"ca issuer with missing secretName": {
+			spec: &cmapi.CAIssuer{},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("secretName"), ""),
+			},
+		},
+		"ca issuer with relative URLs": {
+			spec: &cmapi.CAIssuer{
+				SecretName:            "ca-key-pair",
+				CRLDistributionPoints: []string{"http://crl.example.com/ca.crl", "crl.example.com/ca.crl"},
+				OCSPServers:           []string{"/ocsp"},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("crlDistributionPoints").Index(1), "crl.example.com/ca.crl", "must be an absolute URL"),
+				field.Invalid(fldPath.Child("ocspServers").Index(0), "/ocsp", "must be an absolute URL"),
+			},
+		},
+		"ca issuer with a valid crl": {
+			spec: &cmapi.CAIssuer{
+				SecretName: "ca-key-pair",
+				CRL: &cmapi.CACRLConfig{
+					SecretName: "ca-crl",
+					Duration:   &metav1.Duration{Duration: time.Hour * 12},
+					RevokedCertificates: []cmapi.CARevokedCertificate{
+						{SerialNumber: "1A2B"},
+						{SerialNumber: "01:1a:2c"},
+					},
+				},
+			},
+		},
+		"ca issuer with an invalid crl": {
+			spec: &cmapi.CAIssuer{
+				SecretName: "ca-key-pair",
+				CRL: &cmapi.CACRLConfig{
+					SecretName: "ca-key-pair",
+					Duration:   &metav1.Duration{Duration: time.Minute},
+					RevokedCertificates: []cmapi.CARevokedCertificate{
+						{SerialNumber: "1a2b"},
+						{SerialNumber: "not-hex"},
+						{SerialNumber: "1A:2B"},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("crl", "secretName"), "ca-key-pair", "must not be the secret containing the signing key pair"),
+				field.Invalid(fldPath.Child("crl", "duration"), time.Minute, "CRL duration must be greater than 1h0m0s"),
+				field.Invalid(fldPath.Child("crl", "revokedCertificates").Index(1).Child("serialNumber"), "not-hex", `invalid serial number "not-hex": must be hex encoded`),
+				field.Duplicate(fldPath.Child("crl", "revokedCertificates").Index(2).Child("serialNumber"), "1A:2B"),
+			},
+		},
+		"ca issuer with a crl missing secretName": {
+			spec: &cmapi.CAIssuer{
+				SecretName: "ca-key-pair",
+				CRL:        &cmapi.CACRLConfig{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("crl", "secretName"), ""),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateCAIssuerConfig(s.spec, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Errorf("Expected %v but got %v", s.errs, errs)
+				return
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateACMEIssuerConfig(t *testing.T) {
 	fldPath := field.NewPath("")
 	scenarios := map[string]struct {
@@ -117,6 +260,51 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 				field.Required(fldPath.Child("server"), "acme server URL is a required field"),
 			},
 		},
+		"acme issuer with a valid revocation policy": {
+			spec: &cmacme.ACMEIssuer{
+				Server:           "valid-server",
+				PrivateKey:       validSecretKeyRef,
+				RevocationPolicy: cmacme.ACMERevocationPolicyOnDelete,
+			},
+		},
+		"acme issuer with an unknown revocation policy": {
+			spec: &cmacme.ACMEIssuer{
+				Server:           "valid-server",
+				PrivateKey:       validSecretKeyRef,
+				RevocationPolicy: cmacme.ACMERevocationPolicy("Always"),
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("revocationPolicy"), cmacme.ACMERevocationPolicy("Always"), []string{"Never", "OnDelete"}),
+			},
+		},
+		"acme issuer with a valid account deactivation policy": {
+			spec: &cmacme.ACMEIssuer{
+				Server:                    "valid-server",
+				PrivateKey:                validSecretKeyRef,
+				AccountDeactivationPolicy: cmacme.ACMEAccountDeactivationPolicyOnDelete,
+			},
+		},
+		"acme issuer with an unknown account deactivation policy": {
+			spec: &cmacme.ACMEIssuer{
+				Server:                    "valid-server",
+				PrivateKey:                validSecretKeyRef,
+				AccountDeactivationPolicy: cmacme.ACMEAccountDeactivationPolicy("Always"),
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("accountDeactivationPolicy"), cmacme.ACMEAccountDeactivationPolicy("Always"), []string{"Never", "OnDelete"}),
+			},
+		},
+		"acme issuer with an empty additional email": {
+			spec: &cmacme.ACMEIssuer{
+				Email:            "valid-email",
+				AdditionalEmails: []string{"other-email", ""},
+				Server:           "valid-server",
+				PrivateKey:       validSecretKeyRef,
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("additionalEmails").Index(1), "email address must not be empty"),
+			},
+		},
 		"acme solver without any config": {
 			spec: &cmacme.ACMEIssuer{
 				Email:      "valid-email",
@@ -436,6 +624,44 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 				},
 			},
 		},
+		"gatewayHTTPRoute field specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
+					ParentRefs: []cmacme.ACMEChallengeSolverHTTP01GatewayParentRef{{Name: "gateway"}},
+				},
+			},
+		},
+		"both ingress and gatewayHTTPRoute specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
+					ParentRefs: []cmacme.ACMEChallengeSolverHTTP01GatewayParentRef{{Name: "gateway"}},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "only one of 'ingress' or 'gatewayHTTPRoute' should be specified"),
+			},
+		},
+		"gatewayHTTPRoute without parentRefs": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("gatewayHTTPRoute", "parentRefs"), "at least one parent reference must be specified"),
+			},
+		},
+		"gatewayHTTPRoute with invalid parentRef and serviceType": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
+					ServiceType: corev1.ServiceType("InvalidServiceType"),
+					ParentRefs:  []cmacme.ACMEChallengeSolverHTTP01GatewayParentRef{{Kind: "Gateway"}},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("gatewayHTTPRoute", "serviceType"), corev1.ServiceType("InvalidServiceType"), `must be empty, "ClusterIP" or "NodePort"`),
+				field.Required(fldPath.Child("gatewayHTTPRoute", "parentRefs").Index(0).Child("name"), ""),
+			},
+		},
 		"acme issuer with invalid http01 service config": {
 			cfg: &cmacme.ACMEChallengeSolverHTTP01{
 				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
@@ -464,6 +690,46 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 	}
 }
 
+func TestValidateACMEIssuerTLSALPN01Config(t *testing.T) {
+	fldPath := field.NewPath("")
+	scenarios := map[string]struct {
+		cfg  *cmacme.ACMEChallengeSolverTLSALPN01
+		errs []*field.Error
+	}{
+		"no fields specified": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{},
+		},
+		"valid serviceType ClusterIP": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				ServiceType: corev1.ServiceType("ClusterIP"),
+			},
+		},
+		"invalid serviceType": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				ServiceType: corev1.ServiceType("LoadBalancer"),
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("serviceType"), corev1.ServiceType("LoadBalancer"), `must be empty, "ClusterIP" or "NodePort"`),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateACMEIssuerChallengeSolverTLSALPN01Config(s.cfg, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Errorf("Expected %v but got %v", s.errs, errs)
+				return
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 	fldPath := field.NewPath("test")
 	scenarios := map[string]struct {
@@ -28,6 +28,12 @@ func AddToValidationRegistry(reg *validation.Registry) error {
 	if err := reg.AddValidateFunc(&cmapi.CertificateRequest{}, ValidateCertificateRequest); err != nil {
 		return err
 	}
+	if err := reg.AddValidateUpdateFunc(&cmapi.CertificateRequest{}, ValidateCertificateRequestUpdate); err != nil {
+		return err
+	}
+	if err := reg.AddValidateFunc(&cmapi.CertificateRequestPolicy{}, ValidateCertificateRequestPolicy); err != nil {
+		return err
+	}
 	if err := reg.AddValidateFunc(&cmapi.ClusterIssuer{}, ValidateClusterIssuer); err != nil {
 		return err
 	}
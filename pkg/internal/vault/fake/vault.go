@@ -27,12 +27,12 @@ import (
 
 type Vault struct {
 	NewFn  func(string, corelisters.SecretLister, v1alpha2.GenericIssuer) (*Vault, error)
-	SignFn func([]byte, time.Duration) ([]byte, []byte, error)
+	SignFn func([]byte, time.Duration, map[string]string) ([]byte, []byte, error)
 }
 
 func New() *Vault {
 	v := &Vault{
-		SignFn: func([]byte, time.Duration) ([]byte, []byte, error) {
+		SignFn: func([]byte, time.Duration, map[string]string) ([]byte, []byte, error) {
 			return nil, nil, nil
 		},
 	}
@@ -44,12 +44,12 @@ func New() *Vault {
 	return v
 }
 
-func (v *Vault) Sign(csrPEM []byte, duration time.Duration) ([]byte, []byte, error) {
-	return v.SignFn(csrPEM, duration)
+func (v *Vault) Sign(csrPEM []byte, duration time.Duration, params map[string]string) ([]byte, []byte, error) {
+	return v.SignFn(csrPEM, duration, params)
 }
 
 func (v *Vault) WithSign(certPEM, caPEM []byte, err error) *Vault {
-	v.SignFn = func([]byte, time.Duration) ([]byte, []byte, error) {
+	v.SignFn = func([]byte, time.Duration, map[string]string) ([]byte, []byte, error) {
 		return certPEM, caPEM, err
 	}
 	return v
@@ -0,0 +1,317 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/metrics"
+)
+
+const (
+	// tokenRenewDivisor controls when cached tokens are refreshed. Tokens are
+	// renewed, or replaced by logging in again, once less than 1/3 of their
+	// TTL remains.
+	tokenRenewDivisor = 3
+
+	tokenCacheOperationHit        = "hit"
+	tokenCacheOperationLogin      = "login"
+	tokenCacheOperationRenew      = "renew"
+	tokenCacheOperationRevoke     = "revoke"
+	tokenCacheOperationInvalidate = "invalidate"
+)
+
+// DefaultTokenCache is the token cache shared by all Vault clients created
+// with New.
+var DefaultTokenCache = NewTokenCache(clock.RealClock{})
+
+// authToken is a Vault token obtained by logging in, along with its lease.
+type authToken struct {
+	id        string
+	ttl       time.Duration
+	renewable bool
+}
+
+type loginFunc func(client Client) (*authToken, error)
+
+// TokenCache caches Vault tokens obtained by logging in with the auth method
+// of an Issuer, so they can be reused across signing requests instead of
+// logging in, and creating a new lease, for every CertificateRequest.
+// Renewable tokens are renewed before their TTL runs out, and tokens are
+// revoked when the auth configuration of their Issuer changes or the Issuer
+// is deleted. Tokens rejected by Vault before then are invalidated.
+type TokenCache struct {
+	clock clock.Clock
+
+	lock    sync.Mutex
+	entries map[string]*tokenCacheEntry
+}
+
+type tokenCacheEntry struct {
+	// lock is held while logging in, so that concurrent signing requests
+	// for the same issuer share a single login
+	lock sync.Mutex
+
+	token *authToken
+	// configHash is the hash of the issuer's Vault configuration used to
+	// obtain the token
+	configHash string
+	// expires is the time the token expires, or zero if it does not expire
+	expires time.Time
+	// client is the client used to obtain the token, which is used to
+	// revoke it if the issuer's Vault configuration changes
+	client Client
+}
+
+// NewTokenCache returns an empty TokenCache.
+func NewTokenCache(c clock.Clock) *TokenCache {
+	return &TokenCache{
+		clock:   c,
+		entries: make(map[string]*tokenCacheEntry),
+	}
+}
+
+// TokenCacheKey returns the key of tokens cached for the issuer with the
+// given kind, namespace and name. The namespace is empty for ClusterIssuers.
+func TokenCacheKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+func issuerTokenCacheKey(issuer v1alpha2.GenericIssuer) string {
+	kind := v1alpha2.IssuerKind
+	if _, ok := issuer.(*v1alpha2.ClusterIssuer); ok {
+		kind = v1alpha2.ClusterIssuerKind
+	}
+	meta := issuer.GetObjectMeta()
+	return TokenCacheKey(kind, meta.Namespace, meta.Name)
+}
+
+// authConfigHash returns a hash of the parts of the Vault issuer
+// configuration that are used to obtain a token, along with the
+// resourceVersion of the Secret holding the credentials used to log in, so
+// that a new token is obtained when the credentials are rotated.
+func authConfigHash(spec *v1alpha2.VaultIssuer, secretResourceVersion string) string {
+	data, _ := json.Marshal(struct {
+		Server                string
+		Namespace             string
+		CABundle              []byte
+		Auth                  v1alpha2.VaultAuth
+		SecretResourceVersion string
+	}{spec.Server, spec.Namespace, spec.CABundle, spec.Auth, secretResourceVersion})
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// Token returns a cached token for the given key if it is still valid,
+// renewing it if it is close to expiry. Otherwise, a new token is obtained
+// with the login function and cached. If the configHash differs from the
+// hash of the cached token, the cached token is revoked first.
+func (c *TokenCache) Token(client Client, key, configHash string, login loginFunc) (string, error) {
+	e := c.entry(key)
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.token != nil && e.configHash != configHash {
+		c.revoke(e)
+	}
+
+	now := c.clock.Now()
+	if e.token != nil && !e.needsRefresh(now) {
+		metrics.Default.VaultTokenCacheOperationCount.WithLabelValues(tokenCacheOperationHit).Inc()
+		return e.token.id, nil
+	}
+
+	if e.token != nil && e.token.renewable && !e.expired(now) {
+		token, err := renewToken(client, e.token.id)
+		if err == nil {
+			metrics.Default.VaultTokenCacheOperationCount.WithLabelValues(tokenCacheOperationRenew).Inc()
+			e.set(token, configHash, client, now)
+			return token.id, nil
+		}
+		// fall back to logging in again if the token cannot be renewed
+	}
+
+	token, err := login(client)
+	if err != nil {
+		return "", err
+	}
+	metrics.Default.VaultTokenCacheOperationCount.WithLabelValues(tokenCacheOperationLogin).Inc()
+	e.set(token, configHash, client, now)
+
+	return token.id, nil
+}
+
+// Revoke revokes and removes the cached token for the given key, if any.
+func (c *TokenCache) Revoke(key string) {
+	c.lock.Lock()
+	e, ok := c.entries[key]
+	delete(c.entries, key)
+	c.lock.Unlock()
+
+	if !ok {
+		return
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	c.revoke(e)
+}
+
+// Invalidate removes the cached token for the given key, if any, without
+// revoking it. It is used when Vault rejects the token, e.g. because it was
+// revoked or its policies changed, so that a new token is obtained by logging
+// in again on the next request.
+func (c *TokenCache) Invalidate(key string) {
+	c.lock.Lock()
+	e, ok := c.entries[key]
+	c.lock.Unlock()
+
+	if !ok {
+		return
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.token == nil {
+		return
+	}
+	metrics.Default.VaultTokenCacheOperationCount.WithLabelValues(tokenCacheOperationInvalidate).Inc()
+	e.token = nil
+	e.client = nil
+}
+
+func (c *TokenCache) entry(key string) *tokenCacheEntry {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		e = &tokenCacheEntry{}
+		c.entries[key] = e
+	}
+	return e
+}
+
+// revoke revokes the token of the entry and clears it. The token is cleared
+// even if revocation fails, as it will expire eventually. The entry lock
+// must be held.
+func (c *TokenCache) revoke(e *tokenCacheEntry) {
+	if e.token == nil {
+		return
+	}
+
+	if !e.expired(c.clock.Now()) {
+		if err := revokeToken(e.client, e.token.id); err == nil {
+			metrics.Default.VaultTokenCacheOperationCount.WithLabelValues(tokenCacheOperationRevoke).Inc()
+		}
+	}
+
+	e.token = nil
+	e.client = nil
+}
+
+func (e *tokenCacheEntry) set(token *authToken, configHash string, client Client, now time.Time) {
+	e.token = token
+	e.configHash = configHash
+	e.client = client
+	e.expires = time.Time{}
+	if token.ttl > 0 {
+		e.expires = now.Add(token.ttl)
+	}
+}
+
+// needsRefresh returns true if less than 1/tokenRenewDivisor of the token's
+// TTL remains.
+func (e *tokenCacheEntry) needsRefresh(now time.Time) bool {
+	if e.expires.IsZero() {
+		return false
+	}
+	return !now.Before(e.expires.Add(-e.token.ttl / tokenRenewDivisor))
+}
+
+func (e *tokenCacheEntry) expired(now time.Time) bool {
+	if e.expires.IsZero() {
+		return false
+	}
+	return !now.Before(e.expires)
+}
+
+func renewToken(client Client, token string) (*authToken, error) {
+	client.SetToken(token)
+
+	request := client.NewRequest("POST", "/v1/auth/token/renew-self")
+	if err := request.SetJSONBody(map[string]string{}); err != nil {
+		return nil, fmt.Errorf("error encoding Vault parameters: %s", err.Error())
+	}
+
+	resp, err := client.RawRequest(request)
+	if err != nil {
+		return nil, fmt.Errorf("error renewing Vault token: %s", err.Error())
+	}
+
+	defer resp.Body.Close()
+
+	vaultResult := vault.Secret{}
+	if err := resp.DecodeJSON(&vaultResult); err != nil {
+		return nil, fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+	}
+
+	return authTokenFromSecret(&vaultResult)
+}
+
+func revokeToken(client Client, token string) error {
+	client.SetToken(token)
+
+	resp, err := client.RawRequest(client.NewRequest("POST", "/v1/auth/token/revoke-self"))
+	if err != nil {
+		return fmt.Errorf("error revoking Vault token: %s", err.Error())
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+func authTokenFromSecret(secret *vault.Secret) (*authToken, error) {
+	id, err := secret.TokenID()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read token: %s", err.Error())
+	}
+
+	if id == "" {
+		return nil, errors.New("no token returned")
+	}
+
+	ttl, err := secret.TokenTTL()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read token TTL: %s", err.Error())
+	}
+
+	renewable, err := secret.TokenIsRenewable()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read token renewability: %s", err.Error())
+	}
+
+	return &authToken{id: id, ttl: ttl, renewable: renewable}, nil
+}
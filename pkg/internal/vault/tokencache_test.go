@@ -0,0 +1,333 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+// tokenCacheClient is a Vault client that records the requests made to it,
+// responding to token renewals with renewResponse.
+type tokenCacheClient struct {
+	token    string
+	requests []string
+
+	renewResponse string
+	renewErr      error
+}
+
+func (c *tokenCacheClient) NewRequest(method, requestPath string) *vault.Request {
+	return &vault.Request{Method: method, URL: &url.URL{Path: requestPath}}
+}
+
+func (c *tokenCacheClient) RawRequest(r *vault.Request) (*vault.Response, error) {
+	c.requests = append(c.requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, c.token))
+
+	body := "{}"
+	if r.URL.Path == "/v1/auth/token/renew-self" {
+		if c.renewErr != nil {
+			return nil, c.renewErr
+		}
+		body = c.renewResponse
+	}
+
+	return &vault.Response{
+		Response: &http.Response{
+			Body: ioutil.NopCloser(strings.NewReader(body)),
+		},
+	}, nil
+}
+
+func (c *tokenCacheClient) SetToken(v string) {
+	c.token = v
+}
+
+func (c *tokenCacheClient) Token() string {
+	return c.token
+}
+
+func (c *tokenCacheClient) Sys() *vault.Sys {
+	return nil
+}
+
+// testLogin returns a login function returning tokens numbered by the number
+// of times it has been called.
+func testLogin(ttl time.Duration, renewable bool, calls *int) loginFunc {
+	return func(Client) (*authToken, error) {
+		*calls++
+		return &authToken{id: fmt.Sprintf("token-%d", *calls), ttl: ttl, renewable: renewable}, nil
+	}
+}
+
+func TestTokenCache(t *testing.T) {
+	const (
+		key  = "Issuer/ns/vault"
+		hash = "hash"
+	)
+
+	renewResponse := `{"auth":{"client_token":"token-1","lease_duration":3600,"renewable":true}}`
+
+	type step struct {
+		// advance is the time to advance the clock by before the step
+		advance time.Duration
+		// hash is the config hash to request the token with, defaulting to
+		// hash
+		hash string
+		// revoke revokes the cached token instead of requesting a token
+		revoke bool
+
+		expectedToken string
+	}
+
+	tests := map[string]struct {
+		ttl       time.Duration
+		renewable bool
+		renewErr  error
+		steps     []step
+
+		expectedLogins   int
+		expectedRequests []string
+	}{
+		"a cached token should be reused while it is valid": {
+			ttl: time.Hour,
+			steps: []step{
+				{expectedToken: "token-1"},
+				{advance: 30 * time.Minute, expectedToken: "token-1"},
+			},
+			expectedLogins: 1,
+		},
+		"a token without a TTL should be reused": {
+			steps: []step{
+				{expectedToken: "token-1"},
+				{advance: 24 * time.Hour, expectedToken: "token-1"},
+			},
+			expectedLogins: 1,
+		},
+		"a token that is not renewable should be replaced close to expiry": {
+			ttl: time.Hour,
+			steps: []step{
+				{expectedToken: "token-1"},
+				{advance: 45 * time.Minute, expectedToken: "token-2"},
+			},
+			expectedLogins: 2,
+		},
+		"a renewable token should be renewed close to expiry": {
+			ttl:       time.Hour,
+			renewable: true,
+			steps: []step{
+				{expectedToken: "token-1"},
+				{advance: 45 * time.Minute, expectedToken: "token-1"},
+				// the renewed token is valid for another hour
+				{advance: 30 * time.Minute, expectedToken: "token-1"},
+			},
+			expectedLogins:   1,
+			expectedRequests: []string{"POST /v1/auth/token/renew-self token-1"},
+		},
+		"a renewable token that fails to renew should be replaced": {
+			ttl:       time.Hour,
+			renewable: true,
+			renewErr:  errors.New("renew failed"),
+			steps: []step{
+				{expectedToken: "token-1"},
+				{advance: 45 * time.Minute, expectedToken: "token-2"},
+			},
+			expectedLogins:   2,
+			expectedRequests: []string{"POST /v1/auth/token/renew-self token-1"},
+		},
+		"an expired token should be replaced without renewing it": {
+			ttl:       time.Hour,
+			renewable: true,
+			steps: []step{
+				{expectedToken: "token-1"},
+				{advance: 2 * time.Hour, expectedToken: "token-2"},
+			},
+			expectedLogins: 2,
+		},
+		"a token should be revoked and replaced if the config changes": {
+			ttl: time.Hour,
+			steps: []step{
+				{expectedToken: "token-1"},
+				{hash: "other", expectedToken: "token-2"},
+			},
+			expectedLogins:   2,
+			expectedRequests: []string{"POST /v1/auth/token/revoke-self token-1"},
+		},
+		"a revoked token should be replaced": {
+			ttl: time.Hour,
+			steps: []step{
+				{expectedToken: "token-1"},
+				{revoke: true},
+				{revoke: true},
+				{expectedToken: "token-2"},
+			},
+			expectedLogins:   2,
+			expectedRequests: []string{"POST /v1/auth/token/revoke-self token-1"},
+		},
+		"an expired token should not be revoked": {
+			ttl: time.Hour,
+			steps: []step{
+				{expectedToken: "token-1"},
+				{advance: 2 * time.Hour, revoke: true},
+			},
+			expectedLogins: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeClock := clock.NewFakeClock(time.Now())
+			cache := NewTokenCache(fakeClock)
+			client := &tokenCacheClient{renewResponse: renewResponse, renewErr: test.renewErr}
+
+			var logins int
+			login := testLogin(test.ttl, test.renewable, &logins)
+
+			for i, s := range test.steps {
+				fakeClock.Step(s.advance)
+
+				if s.revoke {
+					cache.Revoke(key)
+					continue
+				}
+
+				h := s.hash
+				if h == "" {
+					h = hash
+				}
+
+				token, err := cache.Token(client, key, h, login)
+				if err != nil {
+					t.Fatalf("step %d: unexpected error: %s", i, err)
+				}
+
+				if token != s.expectedToken {
+					t.Errorf("step %d: got unexpected token, exp=%s got=%s", i, s.expectedToken, token)
+				}
+			}
+
+			if logins != test.expectedLogins {
+				t.Errorf("got unexpected number of logins, exp=%d got=%d", test.expectedLogins, logins)
+			}
+
+			if !reflect.DeepEqual(client.requests, test.expectedRequests) {
+				t.Errorf("got unexpected requests, exp=%v got=%v", test.expectedRequests, client.requests)
+			}
+		})
+	}
+}
+
+func TestTokenCacheLoginError(t *testing.T) {
+	cache := NewTokenCache(clock.NewFakeClock(time.Now()))
+	client := &tokenCacheClient{}
+
+	_, err := cache.Token(client, "key", "hash", func(Client) (*authToken, error) {
+		return nil, errors.New("login failed")
+	})
+	if err == nil || err.Error() != "login failed" {
+		t.Fatalf("unexpected error, exp=login failed got=%v", err)
+	}
+
+	var logins int
+	token, err := cache.Token(client, "key", "hash", testLogin(time.Hour, false, &logins))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token != "token-1" || logins != 1 {
+		t.Errorf("expected a new login after a failed login, got token=%s logins=%d", token, logins)
+	}
+}
+
+func TestTokenCacheInvalidate(t *testing.T) {
+	cache := NewTokenCache(clock.NewFakeClock(time.Now()))
+	client := &tokenCacheClient{}
+
+	// invalidating a key without a cached token should do nothing
+	cache.Invalidate("key")
+
+	var logins int
+	for i, expectedToken := range []string{"token-1", "token-2"} {
+		// a token without a TTL is never refreshed unless it is invalidated
+		token, err := cache.Token(client, "key", "hash", testLogin(0, false, &logins))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if token != expectedToken {
+			t.Errorf("got unexpected token %d, exp=%s got=%s", i, expectedToken, token)
+		}
+		cache.Invalidate("key")
+	}
+
+	if logins != 2 {
+		t.Errorf("expected a new login after the token was invalidated, got %d logins", logins)
+	}
+	if len(client.requests) != 0 {
+		t.Errorf("expected invalidated tokens not to be revoked, got requests %v", client.requests)
+	}
+}
+
+func TestIssuerTokenCacheKey(t *testing.T) {
+	issuer := gen.Issuer("vault")
+	issuer.Namespace = "ns"
+	if key := issuerTokenCacheKey(issuer); key != "Issuer/ns/vault" {
+		t.Errorf("got unexpected key for Issuer: %s", key)
+	}
+
+	clusterIssuer := gen.ClusterIssuer("vault")
+	if key := issuerTokenCacheKey(clusterIssuer); key != "ClusterIssuer//vault" {
+		t.Errorf("got unexpected key for ClusterIssuer: %s", key)
+	}
+}
+
+func TestAuthConfigHash(t *testing.T) {
+	spec := &v1alpha2.VaultIssuer{
+		Server: "https://vault.example.com",
+		Path:   "pki/sign/role",
+		Auth: v1alpha2.VaultAuth{
+			AppRole: &v1alpha2.VaultAppRole{RoleId: "role"},
+		},
+	}
+
+	changedPath := spec.DeepCopy()
+	changedPath.Path = "pki/sign/other"
+	if authConfigHash(spec, "1") != authConfigHash(changedPath, "1") {
+		t.Errorf("expected the hash not to depend on the signing path")
+	}
+
+	changedAuth := spec.DeepCopy()
+	changedAuth.Auth.AppRole.RoleId = "other"
+	if authConfigHash(spec, "1") == authConfigHash(changedAuth, "1") {
+		t.Errorf("expected the hash to change with the auth configuration")
+	}
+
+	if authConfigHash(spec, "1") == authConfigHash(spec, "2") {
+		t.Errorf("expected the hash to change with the resourceVersion of the auth Secret")
+	}
+}
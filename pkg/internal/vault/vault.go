@@ -17,17 +17,19 @@ limitations under the License.
 package vault
 
 import (
+	"crypto/tls"
 	"crypto/x509"
-	"errors"
 	"fmt"
 	"net/http"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	vault "github.com/hashicorp/vault/api"
 	"github.com/hashicorp/vault/sdk/helper/certutil"
+	corev1 "k8s.io/api/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
@@ -40,7 +42,7 @@ type VaultClientBuilder func(namespace string, secretsLister corelisters.SecretL
 	issuer v1alpha2.GenericIssuer) (Interface, error)
 
 type Interface interface {
-	Sign(csrPEM []byte, duration time.Duration) (certPEM []byte, caPEM []byte, err error)
+	Sign(csrPEM []byte, duration time.Duration, params map[string]string) (certPEM []byte, caPEM []byte, err error)
 	Sys() *vault.Sys
 }
 
@@ -57,6 +59,11 @@ type Vault struct {
 	issuer        v1alpha2.GenericIssuer
 	namespace     string
 
+	// tokenCache caches tokens obtained by logging in, keyed by
+	// tokenCacheKey. If nil, a new token is requested for every client.
+	tokenCache    *TokenCache
+	tokenCacheKey string
+
 	client Client
 }
 
@@ -66,6 +73,8 @@ func New(namespace string, secretsLister corelisters.SecretLister,
 		secretsLister: secretsLister,
 		namespace:     namespace,
 		issuer:        issuer,
+		tokenCache:    DefaultTokenCache,
+		tokenCacheKey: issuerTokenCacheKey(issuer),
 	}
 
 	cfg, err := v.newConfig()
@@ -78,6 +87,12 @@ func New(namespace string, secretsLister corelisters.SecretLister,
 		return nil, fmt.Errorf("error initializing Vault client: %s", err.Error())
 	}
 
+	// Set the Vault Enterprise namespace before authenticating, as auth
+	// methods are also mounted per namespace.
+	if ns := issuer.GetSpec().Vault.Namespace; ns != "" {
+		client.SetNamespace(ns)
+	}
+
 	if err := v.setToken(client); err != nil {
 		return nil, err
 	}
@@ -87,34 +102,50 @@ func New(namespace string, secretsLister corelisters.SecretLister,
 	return v, nil
 }
 
-func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []byte, err error) {
+// reservedSignParameters are the Vault signing request parameters that are
+// derived from the CSR and requested duration, and cannot be overridden by
+// additional parameters.
+var reservedSignParameters = map[string]bool{
+	"csr":         true,
+	"ttl":         true,
+	"common_name": true,
+	"alt_names":   true,
+	"ip_sans":     true,
+	"uri_sans":    true,
+}
+
+// Sign sends the CSR to Vault to be signed with the given duration. Any
+// additional params are merged into the signing request, and may override
+// defaults such as 'exclude_cn_from_sans'.
+func (v *Vault) Sign(csrPEM []byte, duration time.Duration, params map[string]string) (cert []byte, ca []byte, err error) {
 	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to decode CSR for signing: %s", err)
 	}
 
-	parameters := map[string]string{
-		"common_name": csr.Subject.CommonName,
-		"alt_names":   strings.Join(csr.DNSNames, ","),
-		"ip_sans":     strings.Join(pki.IPAddressesToString(csr.IPAddresses), ","),
-		"uri_sans":    strings.Join(pki.URLsToString(csr.URIs), ","),
-		"ttl":         duration.String(),
-		"csr":         string(csrPEM),
-
-		"exclude_cn_from_sans": "true",
+	parameters, err := v.signParameters(csr, csrPEM, duration, params)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	url := path.Join("/v1", v.issuer.GetSpec().Vault.Path)
 
-	request := v.client.NewRequest("POST", url)
-
-	if err := request.SetJSONBody(parameters); err != nil {
-		return nil, nil, fmt.Errorf("failed to build vault request: %s", err)
+	resp, err := v.signRequest(url, parameters)
+	// a cached token may be rejected before its TTL runs out, e.g. because
+	// it was revoked or its policies changed, so the request is retried once
+	// with a new token
+	if err != nil && v.usesCachedToken() && isPermissionDenied(resp, err) {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		v.tokenCache.Invalidate(v.tokenCacheKey)
+		if err := v.setToken(v.client); err != nil {
+			return nil, nil, err
+		}
+		resp, err = v.signRequest(url, parameters)
 	}
-
-	resp, err := v.client.RawRequest(request)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to sign certificate by vault: %s", err)
+		return nil, nil, err
 	}
 
 	defer resp.Body.Close()
@@ -143,6 +174,114 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 	return []byte(bundle.ToPEMBundle()), caPem, nil
 }
 
+// signRequest sends a signing request with the given parameters to the given
+// Vault path.
+func (v *Vault) signRequest(url string, parameters map[string]string) (*vault.Response, error) {
+	request := v.client.NewRequest("POST", url)
+
+	if err := request.SetJSONBody(parameters); err != nil {
+		return nil, fmt.Errorf("failed to build vault request: %s", err)
+	}
+
+	resp, err := v.client.RawRequest(request)
+	if err != nil {
+		return resp, fmt.Errorf("failed to sign certificate by vault: %s", err)
+	}
+
+	return resp, nil
+}
+
+// usesCachedToken returns true if the client's token was obtained from the
+// token cache.
+func (v *Vault) usesCachedToken() bool {
+	return v.tokenCache != nil && v.issuer.GetSpec().Vault.Auth.TokenSecretRef == nil
+}
+
+// isPermissionDenied returns true if a Vault request failed because the
+// token it was made with was not permitted to make it.
+func isPermissionDenied(resp *vault.Response, err error) bool {
+	if resp != nil && resp.Response != nil && resp.StatusCode == http.StatusForbidden {
+		return true
+	}
+	return strings.Contains(err.Error(), "permission denied")
+}
+
+// signParameters builds the parameters of the signing request for the
+// issuer's sign mode.
+func (v *Vault) signParameters(csr *x509.CertificateRequest, csrPEM []byte, duration time.Duration, params map[string]string) (map[string]string, error) {
+	parameters := map[string]string{
+		"ttl": duration.String(),
+		"csr": string(csrPEM),
+	}
+
+	// sign-verbatim uses the subject and SANs of the CSR as-is, so they are
+	// only passed as role parameters.
+	if v.issuer.GetSpec().Vault.SignMode != v1alpha2.VaultSignModeVerbatim {
+		parameters["common_name"] = csr.Subject.CommonName
+		parameters["alt_names"] = strings.Join(csr.DNSNames, ",")
+		parameters["ip_sans"] = strings.Join(pki.IPAddressesToString(csr.IPAddresses), ",")
+		parameters["uri_sans"] = strings.Join(pki.URLsToString(csr.URIs), ",")
+		parameters["exclude_cn_from_sans"] = "true"
+	}
+
+	for k, val := range params {
+		if reservedSignParameters[k] {
+			return nil, fmt.Errorf("vault parameter %q is set by cert-manager and cannot be overridden", k)
+		}
+		parameters[k] = val
+	}
+
+	return parameters, nil
+}
+
+// ParametersFromAnnotations returns the additional Vault signing request
+// parameters set using annotations with the VaultParameterAnnotationPrefix.
+func ParametersFromAnnotations(annotations map[string]string) map[string]string {
+	var params map[string]string
+	for k, val := range annotations {
+		if !strings.HasPrefix(k, v1alpha2.VaultParameterAnnotationPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(k, v1alpha2.VaultParameterAnnotationPrefix)
+		if name == "" {
+			continue
+		}
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[name] = val
+	}
+	return params
+}
+
+// ValidateParameters returns an error if any of the additional signing
+// request params cannot be set on requests to the given issuer, either
+// because the parameter is set by cert-manager or because it is not listed in
+// the issuer's allowed parameters.
+func ValidateParameters(issuer v1alpha2.GenericIssuer, params map[string]string) error {
+	allowed := make(map[string]bool)
+	for _, name := range issuer.GetSpec().Vault.AllowedParameters {
+		allowed[name] = true
+	}
+
+	names := make([]string, 0, len(params))
+	for k := range params {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, k := range names {
+		if reservedSignParameters[k] {
+			return fmt.Errorf("vault parameter %q is set by cert-manager and cannot be overridden", k)
+		}
+		if !allowed[k] {
+			return fmt.Errorf("vault parameter %q is not in the issuer's allowed parameters", k)
+		}
+	}
+
+	return nil
+}
+
 func (v *Vault) setToken(client Client) error {
 	tokenRef := v.issuer.GetSpec().Vault.Auth.TokenSecretRef
 	if tokenRef != nil {
@@ -155,9 +294,15 @@ func (v *Vault) setToken(client Client) error {
 		return nil
 	}
 
-	appRole := v.issuer.GetSpec().Vault.Auth.AppRole
-	if appRole != nil {
-		token, err := v.requestTokenWithAppRoleRef(client, appRole)
+	login, err := v.loginFunc()
+	if err != nil {
+		return err
+	}
+
+	// Tokens obtained by logging in are cached per issuer if a cache is
+	// configured, to avoid a login round-trip for every signing request.
+	if v.tokenCache != nil {
+		token, err := v.tokenCache.Token(client, v.tokenCacheKey, authConfigHash(v.issuer.GetSpec().Vault, v.authSecretResourceVersion()), login)
 		if err != nil {
 			return err
 		}
@@ -166,35 +311,105 @@ func (v *Vault) setToken(client Client) error {
 		return nil
 	}
 
-	kubernetesAuth := v.issuer.GetSpec().Vault.Auth.Kubernetes
-	if kubernetesAuth != nil {
-		token, err := v.requestTokenWithKubernetesAuth(client, kubernetesAuth)
-		if err != nil {
-			return fmt.Errorf("error reading Kubernetes service account token from %s: %s", kubernetesAuth.SecretRef.Name, err.Error())
-		}
-		client.SetToken(token)
-		return nil
+	token, err := login(client)
+	if err != nil {
+		return err
 	}
+	client.SetToken(token.id)
 
-	return fmt.Errorf("error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set")
+	return nil
+}
+
+// authSecretResourceVersion returns the resourceVersion of the Secret holding
+// the credentials used by the login based auth method configured on the
+// issuer. An empty string is returned if the Secret cannot be read, in which
+// case logging in fails with the error.
+func (v *Vault) authSecretResourceVersion() string {
+	auth := v.issuer.GetSpec().Vault.Auth
+
+	var name string
+	switch {
+	case auth.AppRole != nil:
+		name = auth.AppRole.SecretRef.Name
+	case auth.Kubernetes != nil:
+		name = auth.Kubernetes.SecretRef.Name
+	case auth.ClientCertificate != nil:
+		name = auth.ClientCertificate.SecretName
+	default:
+		return ""
+	}
+
+	secret, err := v.secretsLister.Secrets(v.namespace).Get(name)
+	if err != nil {
+		return ""
+	}
+
+	return secret.ResourceVersion
+}
+
+// loginFunc returns a function that logs in to Vault using the login based
+// auth method configured on the issuer.
+func (v *Vault) loginFunc() (loginFunc, error) {
+	appRole := v.issuer.GetSpec().Vault.Auth.AppRole
+	if appRole != nil {
+		return func(client Client) (*authToken, error) {
+			return v.requestTokenWithAppRoleRef(client, appRole)
+		}, nil
+	}
+
+	kubernetesAuth := v.issuer.GetSpec().Vault.Auth.Kubernetes
+	if kubernetesAuth != nil {
+		return func(client Client) (*authToken, error) {
+			token, err := v.requestTokenWithKubernetesAuth(client, kubernetesAuth)
+			if err != nil {
+				return nil, fmt.Errorf("error reading Kubernetes service account token from %s: %s", kubernetesAuth.SecretRef.Name, err.Error())
+			}
+			return token, nil
+		}, nil
+	}
+
+	clientCertificate := v.issuer.GetSpec().Vault.Auth.ClientCertificate
+	if clientCertificate != nil {
+		return func(client Client) (*authToken, error) {
+			token, err := v.requestTokenWithClientCertificate(client, clientCertificate)
+			if err != nil {
+				return nil, fmt.Errorf("error logging in to Vault with client certificate from %s: %s", clientCertificate.SecretName, err.Error())
+			}
+			return token, nil
+		}, nil
+	}
+
+	return nil, fmt.Errorf("error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes auth role or clientCertificate not set")
 }
 
 func (v *Vault) newConfig() (*vault.Config, error) {
 	cfg := vault.DefaultConfig()
 	cfg.Address = v.issuer.GetSpec().Vault.Server
 
+	tlsConfig := cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig
+
 	certs := v.issuer.GetSpec().Vault.CABundle
-	if len(certs) == 0 {
-		return cfg, nil
-	}
+	if len(certs) > 0 {
+		caCertPool := x509.NewCertPool()
+		ok := caCertPool.AppendCertsFromPEM(certs)
+		if ok == false {
+			return nil, fmt.Errorf("error loading Vault CA bundle")
+		}
 
-	caCertPool := x509.NewCertPool()
-	ok := caCertPool.AppendCertsFromPEM(certs)
-	if ok == false {
-		return nil, fmt.Errorf("error loading Vault CA bundle")
+		tlsConfig.RootCAs = caCertPool
 	}
 
-	cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig.RootCAs = caCertPool
+	// The client certificate is read every time a client is created, so
+	// that a rotated key pair is used as soon as its Secret is updated.
+	clientCertificate := v.issuer.GetSpec().Vault.Auth.ClientCertificate
+	if clientCertificate != nil {
+		cert, err := v.clientCertificateRef(clientCertificate.SecretName)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
 
 	return cfg, nil
 }
@@ -241,10 +456,10 @@ func (v *Vault) appRoleRef(appRole *v1alpha2.VaultAppRole) (roleId, secretId str
 	return roleId, secretId, nil
 }
 
-func (v *Vault) requestTokenWithAppRoleRef(client Client, appRole *v1alpha2.VaultAppRole) (string, error) {
+func (v *Vault) requestTokenWithAppRoleRef(client Client, appRole *v1alpha2.VaultAppRole) (*authToken, error) {
 	roleId, secretId, err := v.appRoleRef(appRole)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	parameters := map[string]string{
@@ -263,37 +478,28 @@ func (v *Vault) requestTokenWithAppRoleRef(client Client, appRole *v1alpha2.Vaul
 
 	err = request.SetJSONBody(parameters)
 	if err != nil {
-		return "", fmt.Errorf("error encoding Vault parameters: %s", err.Error())
+		return nil, fmt.Errorf("error encoding Vault parameters: %s", err.Error())
 	}
 
 	resp, err := client.RawRequest(request)
 	if err != nil {
-		return "", fmt.Errorf("error logging in to Vault server: %s", err.Error())
+		return nil, fmt.Errorf("error logging in to Vault server: %s", err.Error())
 	}
 
 	defer resp.Body.Close()
 
 	vaultResult := vault.Secret{}
 	if err := resp.DecodeJSON(&vaultResult); err != nil {
-		return "", fmt.Errorf("unable to decode JSON payload: %s", err.Error())
-	}
-
-	token, err := vaultResult.TokenID()
-	if err != nil {
-		return "", fmt.Errorf("unable to read token: %s", err.Error())
-	}
-
-	if token == "" {
-		return "", errors.New("no token returned")
+		return nil, fmt.Errorf("unable to decode JSON payload: %s", err.Error())
 	}
 
-	return token, nil
+	return authTokenFromSecret(&vaultResult)
 }
 
-func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1alpha2.VaultKubernetesAuth) (string, error) {
+func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1alpha2.VaultKubernetesAuth) (*authToken, error) {
 	secret, err := v.secretsLister.Secrets(v.namespace).Get(kubernetesAuth.SecretRef.Name)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	key := kubernetesAuth.SecretRef.Key
@@ -303,7 +509,7 @@ func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1
 
 	keyBytes, ok := secret.Data[key]
 	if !ok {
-		return "", fmt.Errorf("no data for %q in secret '%s/%s'", key, v.namespace, kubernetesAuth.SecretRef.Name)
+		return nil, fmt.Errorf("no data for %q in secret '%s/%s'", key, v.namespace, kubernetesAuth.SecretRef.Name)
 	}
 
 	jwt := string(keyBytes)
@@ -322,27 +528,82 @@ func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1
 	request := client.NewRequest("POST", url)
 	err = request.SetJSONBody(parameters)
 	if err != nil {
-		return "", fmt.Errorf("error encoding Vault parameters: %s", err.Error())
+		return nil, fmt.Errorf("error encoding Vault parameters: %s", err.Error())
 	}
 
 	resp, err := client.RawRequest(request)
 	if err != nil {
-		return "", fmt.Errorf("error calling Vault server: %s", err.Error())
+		return nil, fmt.Errorf("error calling Vault server: %s", err.Error())
 	}
 
 	defer resp.Body.Close()
 	vaultResult := vault.Secret{}
 	err = resp.DecodeJSON(&vaultResult)
 	if err != nil {
-		return "", fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+		return nil, fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+	}
+
+	return authTokenFromSecret(&vaultResult)
+}
+
+func (v *Vault) clientCertificateRef(name string) (tls.Certificate, error) {
+	secret, err := v.secretsLister.Secrets(v.namespace).Get(name)
+	if err != nil {
+		return tls.Certificate{}, err
 	}
 
-	token, err := vaultResult.TokenID()
+	certPEM, ok := secret.Data[corev1.TLSCertKey]
+	if !ok {
+		return tls.Certificate{}, fmt.Errorf("no data for %q in secret '%s/%s'", corev1.TLSCertKey, v.namespace, name)
+	}
+
+	keyPEM, ok := secret.Data[corev1.TLSPrivateKeyKey]
+	if !ok {
+		return tls.Certificate{}, fmt.Errorf("no data for %q in secret '%s/%s'", corev1.TLSPrivateKeyKey, v.namespace, name)
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
 	if err != nil {
-		return "", fmt.Errorf("unable to read token: %s", err.Error())
+		return tls.Certificate{}, fmt.Errorf("error loading client certificate from secret '%s/%s': %s", v.namespace, name, err.Error())
 	}
 
-	return token, nil
+	return cert, nil
+}
+
+// requestTokenWithClientCertificate logs in using the TLS certificate auth
+// method. The client certificate itself is presented during the TLS
+// handshake, as configured by newConfig.
+func (v *Vault) requestTokenWithClientCertificate(client Client, clientCertificate *v1alpha2.VaultClientCertificateAuth) (*authToken, error) {
+	parameters := map[string]string{}
+	if clientCertificate.Name != "" {
+		parameters["name"] = clientCertificate.Name
+	}
+
+	mountPath := clientCertificate.Path
+	if mountPath == "" {
+		mountPath = v1alpha2.DefaultVaultClientCertificateAuthMountPath
+	}
+
+	url := filepath.Join(mountPath, "login")
+	request := client.NewRequest("POST", url)
+	err := request.SetJSONBody(parameters)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding Vault parameters: %s", err.Error())
+	}
+
+	resp, err := client.RawRequest(request)
+	if err != nil {
+		return nil, fmt.Errorf("error calling Vault server: %s", err.Error())
+	}
+
+	defer resp.Body.Close()
+	vaultResult := vault.Secret{}
+	err = resp.DecodeJSON(&vaultResult)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+	}
+
+	return authTokenFromSecret(&vaultResult)
 }
 
 func (v *Vault) Sys() *vault.Sys {
@@ -28,7 +28,9 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -37,6 +39,7 @@ import (
 	"github.com/hashicorp/vault/sdk/helper/certutil"
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
@@ -166,7 +169,7 @@ func TestSign(t *testing.T) {
 			client:        test.fakeClient,
 		}
 
-		cert, _, err := v.Sign(test.csrPEM, time.Minute)
+		cert, _, err := v.Sign(test.csrPEM, time.Minute, nil)
 		if ((test.expectedErr == nil) != (err == nil)) &&
 			test.expectedErr != nil &&
 			test.expectedErr.Error() != err.Error() {
@@ -181,6 +184,282 @@ func TestSign(t *testing.T) {
 	}
 }
 
+// permissionDeniedClient is a Vault client that logs in with the AppRole auth
+// method, returning tokens numbered by the number of logins, and rejects
+// signing requests made with the first token, or with any token if denyAll is
+// set.
+type permissionDeniedClient struct {
+	tokenCacheClient
+
+	signResponse []byte
+	denyAll      bool
+	logins       int
+}
+
+func (c *permissionDeniedClient) RawRequest(r *vault.Request) (*vault.Response, error) {
+	c.requests = append(c.requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, c.token))
+
+	body := c.signResponse
+	switch {
+	case r.URL.Path == "/v1/auth/approle/login":
+		c.logins++
+		body = []byte(fmt.Sprintf(`{"auth":{"client_token":"token-%d","lease_duration":3600}}`, c.logins))
+	case c.denyAll || c.token == "token-1":
+		return &vault.Response{
+			Response: &http.Response{
+				StatusCode: http.StatusForbidden,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"errors":["permission denied"]}`)),
+			},
+		}, errors.New("Code: 403. Errors:\n\n* permission denied")
+	}
+
+	return &vault.Response{
+		Response: &http.Response{
+			Body: ioutil.NopCloser(bytes.NewReader(body)),
+		},
+	}, nil
+}
+
+func TestSignRetriesWithNewToken(t *testing.T) {
+	privatekey := generateRSAPrivateKey(t)
+	csrPEM := generateCSR(t, privatekey)
+
+	bundleData, err := jsonutil.EncodeJSON(&certutil.Secret{
+		Data: map[string]interface{}{
+			"certificate": testCertBundle,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to encode bundle for testing: %s", err)
+	}
+
+	appRoleSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"my-role-key": []byte("my-secret-role-token"),
+		},
+	}
+
+	tests := map[string]struct {
+		denyAll bool
+
+		expectedErr    bool
+		expectedLogins int
+	}{
+		"a rejected cached token should be replaced by logging in again": {
+			expectedLogins: 2,
+		},
+		"the request should only be retried once": {
+			denyAll:        true,
+			expectedErr:    true,
+			expectedLogins: 2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(v1alpha2.VaultIssuer{
+					Path: "pki/sign/role",
+					Auth: v1alpha2.VaultAuth{
+						AppRole: &v1alpha2.VaultAppRole{
+							Path:   "approle",
+							RoleId: "my-role-id",
+							SecretRef: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "secret-ref-name",
+								},
+								Key: "my-role-key",
+							},
+						},
+					},
+				}),
+			)
+			client := &permissionDeniedClient{
+				signResponse: bundleData,
+				denyAll:      test.denyAll,
+			}
+
+			v := &Vault{
+				namespace: "test-namespace",
+				secretsLister: listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+					listers.SetFakeSecretNamespaceListerGet(appRoleSecret, nil),
+				),
+				issuer:        issuer,
+				tokenCache:    NewTokenCache(clock.NewFakeClock(time.Now())),
+				tokenCacheKey: issuerTokenCacheKey(issuer),
+				client:        client,
+			}
+			if err := v.setToken(client); err != nil {
+				t.Fatalf("unexpected error setting token: %s", err)
+			}
+
+			cert, _, err := v.Sign(csrPEM, time.Minute, nil)
+			if (err != nil) != test.expectedErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+			if !test.expectedErr && string(cert) != testCertBundle {
+				t.Errorf("unexpected certificate in response bundle, exp=%s got=%s", testCertBundle, cert)
+			}
+			if client.logins != test.expectedLogins {
+				t.Errorf("unexpected number of logins, exp=%d got=%d", test.expectedLogins, client.logins)
+			}
+		})
+	}
+}
+
+func TestSignParameters(t *testing.T) {
+	privatekey := generateRSAPrivateKey(t)
+	csrPEM := generateCSR(t, privatekey)
+	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		signMode       v1alpha2.VaultSignMode
+		params         map[string]string
+		expectedParams map[string]string
+		expectedErr    bool
+	}{
+		"role sign mode passes the CSR subject as role parameters": {
+			expectedParams: map[string]string{
+				"common_name":          "test",
+				"alt_names":            "",
+				"ip_sans":              "",
+				"uri_sans":             "",
+				"ttl":                  "1m0s",
+				"csr":                  string(csrPEM),
+				"exclude_cn_from_sans": "true",
+			},
+		},
+		"role sign mode with additional parameters": {
+			signMode: v1alpha2.VaultSignModeRole,
+			params: map[string]string{
+				"other_sans":           "1.3.6.1.4.1.311.20.2.3;utf8:devops@example.com",
+				"format":               "pem_bundle",
+				"exclude_cn_from_sans": "false",
+			},
+			expectedParams: map[string]string{
+				"common_name":          "test",
+				"alt_names":            "",
+				"ip_sans":              "",
+				"uri_sans":             "",
+				"ttl":                  "1m0s",
+				"csr":                  string(csrPEM),
+				"exclude_cn_from_sans": "false",
+				"other_sans":           "1.3.6.1.4.1.311.20.2.3;utf8:devops@example.com",
+				"format":               "pem_bundle",
+			},
+		},
+		"verbatim sign mode only passes the CSR": {
+			signMode: v1alpha2.VaultSignModeVerbatim,
+			params: map[string]string{
+				"private_key_format": "pkcs8",
+			},
+			expectedParams: map[string]string{
+				"ttl":                "1m0s",
+				"csr":                string(csrPEM),
+				"private_key_format": "pkcs8",
+			},
+		},
+		"reserved parameters cannot be overridden": {
+			params: map[string]string{
+				"common_name": "other",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := &Vault{
+				issuer: gen.Issuer("vault-issuer",
+					gen.SetIssuerVault(v1alpha2.VaultIssuer{SignMode: test.signMode}),
+				),
+			}
+
+			params, err := v.signParameters(csr, csrPEM, time.Minute, test.params)
+			if (err != nil) != test.expectedErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+			if !reflect.DeepEqual(params, test.expectedParams) {
+				t.Errorf("unexpected parameters, exp=%v got=%v", test.expectedParams, params)
+			}
+		})
+	}
+}
+
+func TestParametersFromAnnotations(t *testing.T) {
+	params := ParametersFromAnnotations(map[string]string{
+		"vault.cert-manager.io/param.other_sans": "1.2.3;utf8:abc",
+		"vault.cert-manager.io/param.format":     "pem",
+		"vault.cert-manager.io/param.":           "ignored",
+		"cert-manager.io/issuer-name":            "ignored",
+	})
+
+	expected := map[string]string{
+		"other_sans": "1.2.3;utf8:abc",
+		"format":     "pem",
+	}
+	if !reflect.DeepEqual(params, expected) {
+		t.Errorf("unexpected parameters, exp=%v got=%v", expected, params)
+	}
+
+	if params := ParametersFromAnnotations(nil); params != nil {
+		t.Errorf("expected no parameters, got=%v", params)
+	}
+}
+
+func TestValidateParameters(t *testing.T) {
+	tests := map[string]struct {
+		allowedParameters []string
+		params            map[string]string
+		expectedErr       bool
+	}{
+		"no parameters are always valid": {},
+		"parameters in the allowed parameters are valid": {
+			allowedParameters: []string{"other_sans", "format"},
+			params: map[string]string{
+				"other_sans": "1.2.3;utf8:abc",
+			},
+		},
+		"parameters are not allowed unless listed": {
+			params: map[string]string{
+				"other_sans": "1.2.3;utf8:abc",
+			},
+			expectedErr: true,
+		},
+		"parameters not in the allowed parameters are invalid": {
+			allowedParameters: []string{"format"},
+			params: map[string]string{
+				"format":     "pem",
+				"other_sans": "1.2.3;utf8:abc",
+			},
+			expectedErr: true,
+		},
+		"reserved parameters are invalid even if allowed": {
+			allowedParameters: []string{"ttl"},
+			params: map[string]string{
+				"ttl": "8760h",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(v1alpha2.VaultIssuer{AllowedParameters: test.allowedParameters}),
+			)
+
+			err := ValidateParameters(issuer, test.params)
+			if (err != nil) != test.expectedErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
 type testSetTokenT struct {
 	expectedToken string
 	expectedErr   error
@@ -220,7 +499,7 @@ func TestSetToken(t *testing.T) {
 			fakeClient:    vaultfake.NewFakeClient(),
 			expectedToken: "",
 			expectedErr: errors.New(
-				"error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set",
+				"error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes auth role or clientCertificate not set",
 			),
 		},
 
@@ -431,6 +710,48 @@ func TestSetToken(t *testing.T) {
 			expectedErr:   nil,
 		},
 
+		"if client certificate auth set, log in using the cert auth method": {
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(v1alpha2.VaultIssuer{
+					CABundle: []byte(testCertBundle),
+					Auth: v1alpha2.VaultAuth{
+						ClientCertificate: &v1alpha2.VaultClientCertificateAuth{
+							SecretName: "vault-client-tls",
+							Name:       "cert-manager",
+						},
+					},
+				}),
+			),
+			fakeLister: listers.FakeSecretListerFrom(listers.NewFakeSecretLister()),
+			fakeClient: vaultfake.NewFakeClient().WithRawRequest(&vault.Response{
+				Response: &http.Response{
+					Body: ioutil.NopCloser(
+						strings.NewReader(
+							`{"request_id":"","lease_id":"","lease_duration":0,"renewable":false,"data":null,"warnings":null,"auth":{"client_token":"my-cert-token"}}`),
+					),
+				},
+			}, nil),
+			expectedToken: "my-cert-token",
+			expectedErr:   nil,
+		},
+
+		"if client certificate auth set but errors with a raw request should error": {
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(v1alpha2.VaultIssuer{
+					CABundle: []byte(testCertBundle),
+					Auth: v1alpha2.VaultAuth{
+						ClientCertificate: &v1alpha2.VaultClientCertificateAuth{
+							SecretName: "vault-client-tls",
+						},
+					},
+				}),
+			),
+			fakeLister:    listers.FakeSecretListerFrom(listers.NewFakeSecretLister()),
+			fakeClient:    vaultfake.NewFakeClient().WithRawRequest(nil, errors.New("raw request error")),
+			expectedToken: "",
+			expectedErr:   errors.New("error logging in to Vault with client certificate from vault-client-tls: error calling Vault server: raw request error"),
+		},
+
 		"if app role secret ref and token secret set, take preference on token secret": {
 			issuer: gen.Issuer("vault-issuer",
 				gen.SetIssuerVault(v1alpha2.VaultIssuer{
@@ -688,11 +1009,69 @@ func TestTokenRef(t *testing.T) {
 type testNewConfigT struct {
 	expectedErr error
 	issuer      *v1alpha2.Issuer
+	fakeLister  *listers.FakeSecretLister
 	checkFunc   func(cfg *vault.Config) error
 }
 
+func generateClientCertificateSecret(t *testing.T) *corev1.Secret {
+	pk := generateRSAPrivateKey(t)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "vault-client"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatalf("failed to create client certificate: %s", err)
+	}
+
+	return &corev1.Secret{
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+			corev1.TLSPrivateKeyKey: pki.EncodePKCS1PrivateKey(pk),
+		},
+	}
+}
+
 func TestNewConfig(t *testing.T) {
+	clientCertSecret := generateClientCertificateSecret(t)
+	clientCertIssuer := gen.Issuer("vault-issuer",
+		gen.SetIssuerVault(v1alpha2.VaultIssuer{
+			Auth: v1alpha2.VaultAuth{
+				ClientCertificate: &v1alpha2.VaultClientCertificateAuth{
+					SecretName: "vault-client-tls",
+				},
+			},
+		}),
+	)
+
 	tests := map[string]testNewConfigT{
+		"a client certificate should be added to the config": {
+			issuer: clientCertIssuer,
+			fakeLister: listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+				listers.SetFakeSecretNamespaceListerGet(clientCertSecret, nil),
+			),
+			checkFunc: func(cfg *vault.Config) error {
+				certs := cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig.Certificates
+				if len(certs) != 1 {
+					return fmt.Errorf("expected 1 client certificate in config, got %d", len(certs))
+				}
+				return nil
+			},
+		},
+
+		"a client certificate secret without a private key should error": {
+			issuer: clientCertIssuer,
+			fakeLister: listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+				listers.SetFakeSecretNamespaceListerGet(&corev1.Secret{
+					Data: map[string][]byte{corev1.TLSCertKey: clientCertSecret.Data[corev1.TLSCertKey]},
+				}, nil),
+			),
+			expectedErr: errors.New(`no data for "tls.key" in secret 'test-namespace/vault-client-tls'`),
+		},
+
 		"no CA bundle set in issuer should return nil": {
 			issuer: gen.Issuer("vault-issuer",
 				gen.SetIssuerVault(v1alpha2.VaultIssuer{
@@ -743,7 +1122,7 @@ func TestNewConfig(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			v := &Vault{
 				namespace:     "test-namespace",
-				secretsLister: nil,
+				secretsLister: test.fakeLister,
 				issuer:        test.issuer,
 			}
 
@@ -882,9 +1261,14 @@ func TestRequestTokenWithAppRoleRef(t *testing.T) {
 					test.expectedErr, err)
 			}
 
-			if test.expectedToken != token {
+			var tokenID string
+			if token != nil {
+				tokenID = token.id
+			}
+
+			if test.expectedToken != tokenID {
 				t.Errorf("got unexpected token, exp=%s got=%s",
-					test.expectedToken, token)
+					test.expectedToken, tokenID)
 			}
 		})
 	}
@@ -0,0 +1,115 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+
+	acmeapi "golang.org/x/crypto/acme"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/jetstack/cert-manager/pkg/acme"
+	"github.com/jetstack/cert-manager/pkg/acme/client"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/errors"
+)
+
+const (
+	errorAccountDeactivationFailed = "ErrDeactivateACMEAccount"
+	successAccountDeactivated      = "ACMEAccountDeactivated"
+
+	messageAccountDeactivationFailed = "Failed to deactivate ACME account: "
+	messageAccountDeactivated        = "The ACME account was deactivated with the ACME server"
+)
+
+var _ issuer.Finalizer = &Acme{}
+
+// FinalizerName is the name of the finalizer used to deactivate the ACME
+// account of the issuer when it is deleted.
+func (a *Acme) FinalizerName() string {
+	return cmacme.ACMEAccountDeactivationFinalizer
+}
+
+// NeedsFinalizer returns true if the issuer has the OnDelete account
+// deactivation policy.
+func (a *Acme) NeedsFinalizer() bool {
+	return a.issuer.GetSpec().ACME.AccountDeactivationPolicy == cmacme.ACMEAccountDeactivationPolicyOnDelete
+}
+
+// Finalize deactivates the ACME account of the issuer. If the account
+// private key no longer exists, or the account is not registered, there is
+// nothing to deactivate.
+func (a *Acme) Finalize(ctx context.Context) error {
+	log := logf.FromContext(ctx)
+
+	if a.issuer.GetStatus().ACMEStatus().URI == "" {
+		log.Info("not deactivating ACME account as no account is registered")
+		return nil
+	}
+
+	ns := a.issuer.GetObjectMeta().Namespace
+	if ns == "" {
+		ns = a.IssuerOptions.ClusterResourceNamespace
+	}
+
+	pk, err := a.helper.ReadPrivateKey(a.issuer.GetSpec().ACME.PrivateKey, ns)
+	if apierrors.IsNotFound(err) || errors.IsInvalidData(err) {
+		log.Error(err, "not deactivating ACME account as its private key cannot be read")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cl, err := acme.ClientWithKey(a.issuer, pk)
+	if err != nil {
+		return err
+	}
+
+	return a.deactivateAccount(ctx, cl)
+}
+
+func (a *Acme) deactivateAccount(ctx context.Context, cl client.Interface) error {
+	log := logf.FromContext(ctx)
+
+	err := cl.DeactivateReg(ctx)
+	if err == acmeapi.ErrNoAccount {
+		log.Info("not deactivating ACME account as it no longer exists")
+		return nil
+	}
+	if err != nil {
+		s := messageAccountDeactivationFailed + err.Error()
+		log.Error(err, "failed to deactivate ACME account")
+		a.Recorder.Event(a.issuer, v1.EventTypeWarning, errorAccountDeactivationFailed, s)
+
+		// If the status code is 400 (BadRequest), we will *not* retry
+		// deactivating the account so the issuer can still be deleted.
+		if acmeErr, ok := err.(*acmeapi.Error); ok && acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
+			return nil
+		}
+
+		return err
+	}
+
+	log.Info("deactivated ACME account")
+	a.Recorder.Event(a.issuer, v1.EventTypeNormal, successAccountDeactivated, messageAccountDeactivated)
+
+	return nil
+}
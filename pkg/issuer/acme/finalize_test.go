@@ -0,0 +1,119 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	acmeapi "golang.org/x/crypto/acme"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/jetstack/cert-manager/pkg/acme/client"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestNeedsFinalizer(t *testing.T) {
+	tests := map[string]struct {
+		policy   cmacme.ACMEAccountDeactivationPolicy
+		expected bool
+	}{
+		"no policy should not need a finalizer": {},
+		"Never policy should not need a finalizer": {
+			policy: cmacme.ACMEAccountDeactivationPolicyNever,
+		},
+		"OnDelete policy should need a finalizer": {
+			policy:   cmacme.ACMEAccountDeactivationPolicyOnDelete,
+			expected: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := &Acme{
+				issuer: gen.Issuer("test", gen.SetIssuerACME(cmacme.ACMEIssuer{
+					AccountDeactivationPolicy: test.policy,
+				})),
+			}
+			if got := a.NeedsFinalizer(); got != test.expected {
+				t.Errorf("unexpected result, exp=%t got=%t", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestDeactivateAccount(t *testing.T) {
+	tests := map[string]struct {
+		deactivateErr error
+		expectedEvent string
+		expectedErr   bool
+	}{
+		"a successful deactivation should fire an event": {
+			expectedEvent: "Normal ACMEAccountDeactivated The ACME account was deactivated with the ACME server",
+		},
+		"an account that no longer exists should be treated as deactivated": {
+			deactivateErr: acmeapi.ErrNoAccount,
+		},
+		"an ACME client error should fire an event and not be retried": {
+			deactivateErr: &acmeapi.Error{StatusCode: 403, Detail: "forbidden"},
+			expectedEvent: "Warning ErrDeactivateACMEAccount Failed to deactivate ACME account: 403 : forbidden",
+		},
+		"any other error should be returned": {
+			deactivateErr: errors.New("server unavailable"),
+			expectedEvent: "Warning ErrDeactivateACMEAccount Failed to deactivate ACME account: server unavailable",
+			expectedErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			a := &Acme{
+				Context: &controller.Context{Recorder: recorder},
+				issuer:  gen.Issuer("test", gen.SetIssuerACME(cmacme.ACMEIssuer{})),
+			}
+
+			var called bool
+			cl := &client.FakeACME{
+				FakeDeactivateReg: func(context.Context) error {
+					called = true
+					return test.deactivateErr
+				},
+			}
+
+			err := a.deactivateAccount(context.Background(), cl)
+			if (err != nil) != test.expectedErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+			if !called {
+				t.Errorf("expected DeactivateReg to be called")
+			}
+
+			var event string
+			select {
+			case event = <-recorder.Events:
+			default:
+			}
+			if event != test.expectedEvent {
+				t.Errorf("unexpected event, exp=%q got=%q", test.expectedEvent, event)
+			}
+		})
+	}
+}
@@ -42,6 +42,9 @@ const (
 	HTTP01Timeout = time.Minute * 15
 	// acmeSolverListenPort is the port acmesolver should listen on
 	acmeSolverListenPort = 8089
+	// acmeSolverTLSListenPort is the port acmesolver should listen on when
+	// solving tls-alpn-01 challenges
+	acmeSolverTLSListenPort = 8443
 
 	domainLabelKey               = "acme.cert-manager.io/http-domain"
 	tokenLabelKey                = "acme.cert-manager.io/http-token"
@@ -94,6 +97,26 @@ func httpDomainCfgForChallenge(ch *cmacme.Challenge) (*cmacme.ACMEChallengeSolve
 	return nil, fmt.Errorf("no HTTP01 ingress configuration found on challenge")
 }
 
+func tlsALPNCfgForChallenge(ch *cmacme.Challenge) (*cmacme.ACMEChallengeSolverTLSALPN01, error) {
+	if ch.Spec.Solver != nil {
+		if ch.Spec.Solver.TLSALPN01 == nil {
+			return nil, fmt.Errorf("challenge's 'solver' field is specified but no TLSALPN01 config provided. " +
+				"Ensure solvers[].tlsalpn01 is specified on your issuer resource")
+		}
+		return ch.Spec.Solver.TLSALPN01, nil
+	}
+	return nil, fmt.Errorf("no TLSALPN01 configuration found on challenge")
+}
+
+// solverPort returns the name and number of the port the acmesolver should
+// listen on to solve the given challenge.
+func solverPort(ch *cmacme.Challenge) (string, int) {
+	if ch.Spec.Type == cmacme.ACMEChallengeTypeTLSALPN01 {
+		return "https", acmeSolverTLSListenPort
+	}
+	return "http", acmeSolverListenPort
+}
+
 // Present will realise the resources required to solve the given HTTP01
 // challenge validation in the apiserver. If those resources already exist, it
 // will return nil (i.e. this function is idempotent).
@@ -105,6 +128,10 @@ func (s *Solver) Present(ctx context.Context, issuer v1alpha2.GenericIssuer, ch
 	if svcErr != nil {
 		return utilerrors.NewAggregate([]error{podErr, svcErr})
 	}
+	if usesGatewayHTTPRoute(ch) {
+		_, routeErr := s.ensureGatewayHTTPRoute(ctx, ch, svc.Name)
+		return utilerrors.NewAggregate([]error{podErr, svcErr, routeErr})
+	}
 	_, ingressErr := s.ensureIngress(ctx, ch, svc.Name)
 	return utilerrors.NewAggregate([]error{podErr, svcErr, ingressErr})
 }
@@ -148,13 +175,17 @@ func (s *Solver) Check(ctx context.Context, issuer v1alpha2.GenericIssuer, ch *c
 	return nil
 }
 
-// CleanUp will ensure the created service, ingress and pod are clean/deleted of any
-// cert-manager created data.
+// CleanUp will ensure the created service, ingress or HTTPRoute and pod are
+// clean/deleted of any cert-manager created data.
 func (s *Solver) CleanUp(ctx context.Context, issuer v1alpha2.GenericIssuer, ch *cmacme.Challenge) error {
 	var errs []error
 	errs = append(errs, s.cleanupPods(ctx, ch))
 	errs = append(errs, s.cleanupServices(ctx, ch))
-	errs = append(errs, s.cleanupIngresses(ctx, ch))
+	if usesGatewayHTTPRoute(ch) {
+		errs = append(errs, s.cleanupGatewayHTTPRoutes(ctx, ch))
+	} else {
+		errs = append(errs, s.cleanupIngresses(ctx, ch))
+	}
 	return utilerrors.NewAggregate(errs)
 }
 
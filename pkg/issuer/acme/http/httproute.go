@@ -0,0 +1,242 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+var (
+	httpRouteGVR = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"}
+	httpRouteGVK = httpRouteGVR.GroupVersion().WithKind("HTTPRoute")
+)
+
+func gatewayHTTPRouteCfgForChallenge(ch *cmacme.Challenge) (*cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, error) {
+	if ch.Spec.Solver != nil {
+		if ch.Spec.Solver.HTTP01 == nil || ch.Spec.Solver.HTTP01.GatewayHTTPRoute == nil {
+			return nil, fmt.Errorf("challenge's 'solver' field is specified but no HTTP01 gatewayHTTPRoute config provided. " +
+				"Ensure solvers[].http01.gatewayHTTPRoute is specified on your issuer resource")
+		}
+		return ch.Spec.Solver.HTTP01.GatewayHTTPRoute, nil
+	}
+	return nil, fmt.Errorf("no HTTP01 gatewayHTTPRoute configuration found on challenge")
+}
+
+// usesGatewayHTTPRoute returns true if the given challenge should be solved
+// using a Gateway API HTTPRoute instead of an Ingress.
+func usesGatewayHTTPRoute(ch *cmacme.Challenge) bool {
+	return ch.Spec.Solver != nil &&
+		ch.Spec.Solver.HTTP01 != nil &&
+		ch.Spec.Solver.HTTP01.GatewayHTTPRoute != nil
+}
+
+// getGatewayHTTPRoutesForChallenge returns a list of HTTPRoutes that were
+// created to solve http challenges for the given domain
+func (s *Solver) getGatewayHTTPRoutesForChallenge(ctx context.Context, ch *cmacme.Challenge) ([]*unstructured.Unstructured, error) {
+	log := logf.FromContext(ctx)
+
+	log.V(logf.DebugLevel).Info("checking for existing HTTP01 solver HTTPRoutes")
+	routeList, err := s.DynamicClient.Resource(httpRouteGVR).Namespace(ch.Namespace).List(metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(podLabels(ch)).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var relevantRoutes []*unstructured.Unstructured
+	for i := range routeList.Items {
+		route := &routeList.Items[i]
+		if !metav1.IsControlledBy(route, ch) {
+			logf.WithRelatedResource(log, route).Info("found existing solver HTTPRoute for this challenge resource, however " +
+				"it does not have an appropriate OwnerReference referencing this challenge. Skipping it altogether.")
+			continue
+		}
+		relevantRoutes = append(relevantRoutes, route)
+	}
+
+	return relevantRoutes, nil
+}
+
+// ensureGatewayHTTPRoute will ensure the HTTPRoute required to solve this
+// challenge exists and routes requests to the given service
+func (s *Solver) ensureGatewayHTTPRoute(ctx context.Context, ch *cmacme.Challenge, svcName string) (*unstructured.Unstructured, error) {
+	log := logf.FromContext(ctx).WithName("ensureGatewayHTTPRoute")
+
+	existingRoutes, err := s.getGatewayHTTPRoutesForChallenge(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+	if len(existingRoutes) == 1 && httpRouteServiceName(existingRoutes[0]) == svcName {
+		logf.WithRelatedResource(log, existingRoutes[0]).Info("found one existing HTTP01 solver HTTPRoute")
+		return existingRoutes[0], nil
+	}
+	if len(existingRoutes) == 1 && httpRouteServiceName(existingRoutes[0]) != svcName {
+		log.Info("service name changed. cleaning up all existing HTTPRoutes.")
+		err := s.cleanupGatewayHTTPRoutes(ctx, ch)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("service name changed, existing challenge solver HTTPRoutes found and cleaned up. retrying challenge sync")
+	}
+	if len(existingRoutes) > 1 {
+		log.Info("multiple challenge solver HTTPRoutes found for challenge. cleaning up all existing HTTPRoutes.")
+		err := s.cleanupGatewayHTTPRoutes(ctx, ch)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("multiple existing challenge solver HTTPRoutes found and cleaned up. retrying challenge sync")
+	}
+
+	log.Info("creating HTTP01 challenge solver HTTPRoute")
+	route, err := buildGatewayHTTPRoute(ch, svcName)
+	if err != nil {
+		return nil, err
+	}
+	return s.DynamicClient.Resource(httpRouteGVR).Namespace(ch.Namespace).Create(route, metav1.CreateOptions{})
+}
+
+// httpRouteServiceName returns the name of the service the first rule of the
+// given HTTPRoute routes requests to
+func httpRouteServiceName(route *unstructured.Unstructured) string {
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	if len(rules) == 0 {
+		return ""
+	}
+	rule, ok := rules[0].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	backendRefs, _, _ := unstructured.NestedSlice(rule, "backendRefs")
+	if len(backendRefs) == 0 {
+		return ""
+	}
+	backendRef, ok := backendRefs[0].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	name, _, _ := unstructured.NestedString(backendRef, "name")
+	return name
+}
+
+func buildGatewayHTTPRoute(ch *cmacme.Challenge, svcName string) (*unstructured.Unstructured, error) {
+	cfg, err := gatewayHTTPRouteCfgForChallenge(ch)
+	if err != nil {
+		return nil, err
+	}
+
+	routeLabels := make(map[string]string)
+	for k, v := range cfg.Labels {
+		routeLabels[k] = v
+	}
+	// the solver labels are always set, as they are used to find the
+	// HTTPRoutes created for a challenge
+	for k, v := range podLabels(ch) {
+		routeLabels[k] = v
+	}
+
+	var parentRefs []interface{}
+	for _, ref := range cfg.ParentRefs {
+		parentRef := map[string]interface{}{
+			"name": ref.Name,
+		}
+		if ref.Group != "" {
+			parentRef["group"] = ref.Group
+		}
+		if ref.Kind != "" {
+			parentRef["kind"] = ref.Kind
+		}
+		if ref.Namespace != "" {
+			parentRef["namespace"] = ref.Namespace
+		}
+		if ref.SectionName != "" {
+			parentRef["sectionName"] = ref.SectionName
+		}
+		if ref.Port != nil {
+			parentRef["port"] = int64(*ref.Port)
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	route := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"parentRefs": parentRefs,
+				"hostnames":  []interface{}{ch.Spec.DNSName},
+				"rules": []interface{}{
+					map[string]interface{}{
+						"matches": []interface{}{
+							map[string]interface{}{
+								"path": map[string]interface{}{
+									"type":  "Exact",
+									"value": solverPathFn(ch.Spec.Token),
+								},
+							},
+						},
+						"backendRefs": []interface{}{
+							map[string]interface{}{
+								"name": svcName,
+								"port": int64(acmeSolverListenPort),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	route.SetGroupVersionKind(httpRouteGVK)
+	route.SetGenerateName("cm-acme-http-solver-")
+	route.SetNamespace(ch.Namespace)
+	route.SetLabels(routeLabels)
+	route.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(ch, challengeGvk)})
+
+	return route, nil
+}
+
+// cleanupGatewayHTTPRoutes will delete the HTTPRoutes cert-manager has
+// created to solve the challenge
+func (s *Solver) cleanupGatewayHTTPRoutes(ctx context.Context, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx, "cleanupGatewayHTTPRoutes")
+
+	routes, err := s.getGatewayHTTPRoutesForChallenge(ctx, ch)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, route := range routes {
+		log := logf.WithRelatedResource(log, route).V(logf.DebugLevel)
+
+		log.Info("deleting HTTPRoute resource")
+		err := s.DynamicClient.Resource(httpRouteGVR).Namespace(route.GetNamespace()).Delete(route.GetName(), nil)
+		if err != nil {
+			log.Info("failed to delete HTTPRoute resource", "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		log.Info("successfully deleted HTTPRoute resource")
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+)
+
+func gatewayHTTPRouteChallenge() *cmacme.Challenge {
+	port := int32(80)
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: defaultTestNamespace,
+			UID:       "test-uid",
+		},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Token:   "token",
+			Solver: &cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
+						Labels: map[string]string{"gateway": "public"},
+						ParentRefs: []cmacme.ACMEChallengeSolverHTTP01GatewayParentRef{
+							{Name: "public", Namespace: "gateways", SectionName: "http", Port: &port},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildGatewayHTTPRoute(t *testing.T) {
+	ch := gatewayHTTPRouteChallenge()
+
+	route, err := buildGatewayHTTPRoute(ch, "fakeservice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if route.GetKind() != "HTTPRoute" || route.GetAPIVersion() != "gateway.networking.k8s.io/v1" {
+		t.Errorf("unexpected type %s %s", route.GetAPIVersion(), route.GetKind())
+	}
+	if route.GetLabels()["gateway"] != "public" {
+		t.Errorf("expected custom labels to be set, got %v", route.GetLabels())
+	}
+	for k, v := range podLabels(ch) {
+		if route.GetLabels()[k] != v {
+			t.Errorf("expected solver label %q to be %q, got %v", k, v, route.GetLabels())
+		}
+	}
+	if !metav1.IsControlledBy(route, ch) {
+		t.Errorf("expected HTTPRoute to be controlled by the challenge")
+	}
+
+	expectedSpec := map[string]interface{}{
+		"parentRefs": []interface{}{
+			map[string]interface{}{
+				"name":        "public",
+				"namespace":   "gateways",
+				"sectionName": "http",
+				"port":        int64(80),
+			},
+		},
+		"hostnames": []interface{}{"example.com"},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"matches": []interface{}{
+					map[string]interface{}{
+						"path": map[string]interface{}{
+							"type":  "Exact",
+							"value": "/.well-known/acme-challenge/token",
+						},
+					},
+				},
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": "fakeservice",
+						"port": int64(acmeSolverListenPort),
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(route.Object["spec"], expectedSpec) {
+		t.Errorf("unexpected HTTPRoute spec, exp=%v got=%v", expectedSpec, route.Object["spec"])
+	}
+	if name := httpRouteServiceName(route); name != "fakeservice" {
+		t.Errorf("unexpected service name %q", name)
+	}
+}
+
+func TestEnsureGatewayHTTPRoute(t *testing.T) {
+	tests := map[string]solverFixture{
+		"should create a HTTPRoute if none exists": {
+			Challenge: gatewayHTTPRouteChallenge(),
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				routes, err := s.Solver.getGatewayHTTPRoutesForChallenge(context.TODO(), s.Challenge)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(routes) != 1 {
+					t.Errorf("expected one HTTPRoute to exist, got %d", len(routes))
+				}
+			},
+		},
+		"should return an existing HTTPRoute": {
+			Challenge: gatewayHTTPRouteChallenge(),
+			PreFn: func(t *testing.T, s *solverFixture) {
+				if _, err := s.Solver.ensureGatewayHTTPRoute(context.TODO(), s.Challenge, "fakeservice"); err != nil {
+					t.Errorf("error preparing test: %v", err)
+				}
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				routes, err := s.Solver.getGatewayHTTPRoutesForChallenge(context.TODO(), s.Challenge)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(routes) != 1 {
+					t.Errorf("expected one HTTPRoute to exist, got %d", len(routes))
+				}
+				if route := args[0].(*unstructured.Unstructured); route == nil || route.GetName() != routes[0].GetName() {
+					t.Errorf("expected the existing HTTPRoute to be returned")
+				}
+			},
+		},
+		"should clean up a HTTPRoute for a different service": {
+			Challenge: gatewayHTTPRouteChallenge(),
+			PreFn: func(t *testing.T, s *solverFixture) {
+				if _, err := s.Solver.ensureGatewayHTTPRoute(context.TODO(), s.Challenge, "oldservice"); err != nil {
+					t.Errorf("error preparing test: %v", err)
+				}
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				routes, err := s.Solver.getGatewayHTTPRoutesForChallenge(context.TODO(), s.Challenge)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(routes) != 0 {
+					t.Errorf("expected existing HTTPRoutes to be cleaned up, got %d", len(routes))
+				}
+			},
+			Err: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.Setup(t)
+			resp, err := test.Solver.ensureGatewayHTTPRoute(context.TODO(), test.Challenge, "fakeservice")
+			if err != nil && !test.Err {
+				t.Errorf("Expected function to not error, but got: %v", err)
+			}
+			if err == nil && test.Err {
+				t.Errorf("Expected function to get an error, but got: %v", err)
+			}
+			test.Finish(t, resp, err)
+		})
+	}
+}
+
+func TestCleanupGatewayHTTPRoutes(t *testing.T) {
+	tests := map[string]solverFixture{
+		"should delete the HTTPRoutes created for the challenge": {
+			Challenge: gatewayHTTPRouteChallenge(),
+			PreFn: func(t *testing.T, s *solverFixture) {
+				if _, err := s.Solver.ensureGatewayHTTPRoute(context.TODO(), s.Challenge, "fakeservice"); err != nil {
+					t.Errorf("error preparing test: %v", err)
+				}
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				routes, err := s.Solver.getGatewayHTTPRoutesForChallenge(context.TODO(), s.Challenge)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(routes) != 0 {
+					t.Errorf("expected HTTPRoutes to be deleted, got %d", len(routes))
+				}
+			},
+		},
+		"should not delete HTTPRoutes belonging to other challenges": {
+			Challenge: gatewayHTTPRouteChallenge(),
+			PreFn: func(t *testing.T, s *solverFixture) {
+				other := s.Challenge.DeepCopy()
+				other.UID = "other-uid"
+				if _, err := s.Solver.ensureGatewayHTTPRoute(context.TODO(), other, "fakeservice"); err != nil {
+					t.Errorf("error preparing test: %v", err)
+				}
+				s.testResources["other"] = other
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				other := s.testResources["other"].(*cmacme.Challenge)
+				routes, err := s.Solver.getGatewayHTTPRoutesForChallenge(context.TODO(), other)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(routes) != 1 {
+					t.Errorf("expected HTTPRoute of other challenge to be retained, got %d", len(routes))
+				}
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.Setup(t)
+			err := test.Solver.cleanupGatewayHTTPRoutes(context.TODO(), test.Challenge)
+			if err != nil && !test.Err {
+				t.Errorf("Expected function to not error, but got: %v", err)
+			}
+			if err == nil && test.Err {
+				t.Errorf("Expected function to get an error, but got: %v", err)
+			}
+			test.Finish(t, err)
+		})
+	}
+}
@@ -148,12 +148,34 @@ func (s *Solver) buildPod(ch *cmacme.Challenge) *corev1.Pod {
 		pod = s.mergePodObjectMetaWithPodTemplate(pod,
 			ch.Spec.Solver.HTTP01.Ingress.PodTemplate)
 	}
+	if ch.Spec.Solver != nil &&
+		ch.Spec.Solver.HTTP01 != nil &&
+		ch.Spec.Solver.HTTP01.GatewayHTTPRoute != nil {
+		pod = s.mergePodObjectMetaWithPodTemplate(pod,
+			ch.Spec.Solver.HTTP01.GatewayHTTPRoute.PodTemplate)
+	}
+	if ch.Spec.Solver != nil &&
+		ch.Spec.Solver.TLSALPN01 != nil {
+		pod = s.mergePodObjectMetaWithPodTemplate(pod,
+			ch.Spec.Solver.TLSALPN01.PodTemplate)
+	}
 
 	return pod
 }
 
 func (s *Solver) buildDefaultPod(ch *cmacme.Challenge) *corev1.Pod {
 	podLabels := podLabels(ch)
+	portName, port := solverPort(ch)
+
+	args := []string{
+		fmt.Sprintf("--listen-port=%d", port),
+		fmt.Sprintf("--domain=%s", ch.Spec.DNSName),
+		fmt.Sprintf("--token=%s", ch.Spec.Token),
+		fmt.Sprintf("--key=%s", ch.Spec.Key),
+	}
+	if ch.Spec.Type == cmacme.ACMEChallengeTypeTLSALPN01 {
+		args = append(args, fmt.Sprintf("--challenge-type=%s", ch.Spec.Type))
+	}
 
 	return &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -174,12 +196,7 @@ func (s *Solver) buildDefaultPod(ch *cmacme.Challenge) *corev1.Pod {
 					Image:           s.Context.HTTP01SolverImage,
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					// TODO: replace this with some kind of cmdline generator
-					Args: []string{
-						fmt.Sprintf("--listen-port=%d", acmeSolverListenPort),
-						fmt.Sprintf("--domain=%s", ch.Spec.DNSName),
-						fmt.Sprintf("--token=%s", ch.Spec.Token),
-						fmt.Sprintf("--key=%s", ch.Spec.Key),
-					},
+					Args: args,
 					Resources: corev1.ResourceRequirements{
 						Requests: corev1.ResourceList{
 							corev1.ResourceCPU:    s.ACMEOptions.HTTP01SolverResourceRequestCPU,
@@ -192,8 +209,8 @@ func (s *Solver) buildDefaultPod(ch *cmacme.Challenge) *corev1.Pod {
 					},
 					Ports: []corev1.ContainerPort{
 						{
-							Name:          "http",
-							ContainerPort: acmeSolverListenPort,
+							Name:          portName,
+							ContainerPort: int32(port),
 						},
 					},
 				},
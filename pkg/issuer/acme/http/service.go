@@ -101,13 +101,14 @@ func (s *Solver) createService(ch *cmacme.Challenge) (*corev1.Service, error) {
 
 func buildService(ch *cmacme.Challenge) (*corev1.Service, error) {
 	podLabels := podLabels(ch)
+	portName, port := solverPort(ch)
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "cm-acme-http-solver-",
 			Namespace:    ch.Namespace,
 			Labels:       podLabels,
 			Annotations: map[string]string{
-				"auth.istio.io/8089": "NONE",
+				fmt.Sprintf("auth.istio.io/%d", port): "NONE",
 			},
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ch, challengeGvk)},
 		},
@@ -115,27 +116,51 @@ func buildService(ch *cmacme.Challenge) (*corev1.Service, error) {
 			Type: corev1.ServiceTypeNodePort,
 			Ports: []corev1.ServicePort{
 				{
-					Name:       "http",
-					Port:       acmeSolverListenPort,
-					TargetPort: intstr.FromInt(acmeSolverListenPort),
+					Name:       portName,
+					Port:       int32(port),
+					TargetPort: intstr.FromInt(port),
 				},
 			},
 			Selector: podLabels,
 		},
 	}
 
-	// checking for presence of http01 config and if set serviceType is set, override our default (NodePort)
-	httpDomainCfg, err := httpDomainCfgForChallenge(ch)
+	// checking for presence of the solver config and if set serviceType is set, override our default (NodePort)
+	serviceType, err := serviceTypeForChallenge(ch)
 	if err != nil {
 		return nil, err
 	}
-	if httpDomainCfg.ServiceType != "" {
-		service.Spec.Type = httpDomainCfg.ServiceType
+	if serviceType != "" {
+		service.Spec.Type = serviceType
 	}
 
 	return service, nil
 }
 
+func serviceTypeForChallenge(ch *cmacme.Challenge) (corev1.ServiceType, error) {
+	if ch.Spec.Type == cmacme.ACMEChallengeTypeTLSALPN01 {
+		cfg, err := tlsALPNCfgForChallenge(ch)
+		if err != nil {
+			return "", err
+		}
+		return cfg.ServiceType, nil
+	}
+
+	if usesGatewayHTTPRoute(ch) {
+		cfg, err := gatewayHTTPRouteCfgForChallenge(ch)
+		if err != nil {
+			return "", err
+		}
+		return cfg.ServiceType, nil
+	}
+
+	cfg, err := httpDomainCfgForChallenge(ch)
+	if err != nil {
+		return "", err
+	}
+	return cfg.ServiceType, nil
+}
+
 func (s *Solver) cleanupServices(ctx context.Context, ch *cmacme.Challenge) error {
 	log := logf.FromContext(ctx, "cleanupPods")
 
@@ -0,0 +1,198 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solver
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+// idPeACMEIdentifier is the OID of the acmeIdentifier certificate extension
+// defined in RFC 8737.
+var idPeACMEIdentifier = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
+
+type TLSALPN01Solver struct {
+	ListenPort int
+
+	Domain string
+	Key    string
+}
+
+func (t *TLSALPN01Solver) Listen(ctx context.Context) error {
+	log := logf.FromContext(ctx)
+	log.Info("starting listener",
+		"expected_domain", t.Domain,
+		"expected_key", t.Key,
+		"listen_port", t.ListenPort,
+	)
+
+	cert, err := TLSALPN01ChallengeCert(t.Domain, t.Key)
+	if err != nil {
+		return err
+	}
+
+	cfg := &tls.Config{
+		NextProtos: []string{ACMETLS1Protocol},
+		GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			log := log.WithValues(
+				"server_name", hello.ServerName,
+				"protocols", hello.SupportedProtos,
+			)
+			log.Info("validating request")
+
+			if !containsProtocol(hello.SupportedProtos, ACMETLS1Protocol) {
+				log.Info("client did not request the acme-tls/1 protocol")
+				return nil, fmt.Errorf("the %q ALPN protocol must be requested", ACMETLS1Protocol)
+			}
+
+			log.Info("comparing server name", "expected_server_name", t.Domain)
+			if !strings.EqualFold(hello.ServerName, t.Domain) {
+				log.Info("invalid server name", "expected_server_name", t.Domain)
+				return nil, fmt.Errorf("unexpected server name %q", hello.ServerName)
+			}
+
+			log.Info("got successful challenge request, presenting certificate")
+			return &cert, nil
+		},
+	}
+
+	l, err := tls.Listen("tcp", fmt.Sprintf(":%d", t.ListenPort), cfg)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			// the ACME server closes the connection once the handshake has
+			// completed, so no application data is ever exchanged
+			if err := conn.(*tls.Conn).Handshake(); err != nil {
+				log.Info("TLS handshake failed", "remote_addr", conn.RemoteAddr().String(), "error", err)
+			}
+		}()
+	}
+}
+
+// TLSALPN01ChallengeCert returns a self-signed certificate for the given
+// domain containing the acmeIdentifier extension for the given key
+// authorization, as required to solve a tls-alpn-01 challenge.
+func TLSALPN01ChallengeCert(domain, keyAuth string) (tls.Certificate, error) {
+	digest := sha256.Sum256([]byte(keyAuth))
+	extValue, err := asn1.Marshal(digest[:])
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: domain},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              []string{domain},
+		ExtraExtensions: []pkix.Extension{
+			{
+				Id:       idPeACMEIdentifier,
+				Critical: true,
+				Value:    extValue,
+			},
+		},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}, nil
+}
+
+// VerifyTLSALPN01ChallengeCert checks that the given certificate is valid
+// for the given domain and contains the acmeIdentifier extension for the
+// given key authorization.
+func VerifyTLSALPN01ChallengeCert(cert *x509.Certificate, domain, keyAuth string) error {
+	if len(cert.DNSNames) != 1 || !strings.EqualFold(cert.DNSNames[0], domain) {
+		return fmt.Errorf("certificate DNS names %v do not match expected domain %q", cert.DNSNames, domain)
+	}
+
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(idPeACMEIdentifier) {
+			continue
+		}
+		if !ext.Critical {
+			return fmt.Errorf("acmeIdentifier extension is not marked critical")
+		}
+
+		var presented []byte
+		if rest, err := asn1.Unmarshal(ext.Value, &presented); err != nil || len(rest) > 0 {
+			return fmt.Errorf("failed to decode acmeIdentifier extension")
+		}
+
+		expected := sha256.Sum256([]byte(keyAuth))
+		if !bytes.Equal(presented, expected[:]) {
+			return fmt.Errorf("presented key authorization digest (%x) did not match expected (%x)", presented, expected)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("certificate does not contain the acmeIdentifier extension")
+}
+
+func containsProtocol(protos []string, proto string) bool {
+	for _, p := range protos {
+		if p == proto {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solver
+
+import (
+	"crypto/x509"
+	"testing"
+)
+
+func TestTLSALPN01ChallengeCert(t *testing.T) {
+	const (
+		domain  = "example.com"
+		keyAuth = "token.thumbprint"
+	)
+
+	cert, err := TLSALPN01ChallengeCert(domain, keyAuth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x509Cert, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifyTLSALPN01ChallengeCert(x509Cert, domain, keyAuth); err != nil {
+		t.Errorf("expected certificate to be valid, got: %v", err)
+	}
+	if err := VerifyTLSALPN01ChallengeCert(x509Cert, "other.example.com", keyAuth); err == nil {
+		t.Errorf("expected an error verifying the certificate for another domain")
+	}
+	if err := VerifyTLSALPN01ChallengeCert(x509Cert, domain, "other.thumbprint"); err == nil {
+		t.Errorf("expected an error verifying the certificate for another key authorization")
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/http/solver"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	// TLSALPN01Timeout is the max amount of time to wait for a TLSALPN01
+	// challenge to succeed
+	TLSALPN01Timeout = time.Minute * 15
+)
+
+// TLSALPNSolver is an implementation of the acme tls-alpn-01 challenge
+// solver protocol. It provisions the same solver pods and services as the
+// HTTP01 solver, configured to present the challenge certificate on the
+// 'acme-tls/1' ALPN protocol instead.
+type TLSALPNSolver struct {
+	solver *Solver
+
+	testReachability tlsReachabilityTest
+	requiredPasses   int
+}
+
+type tlsReachabilityTest func(ctx context.Context, domain, key string) error
+
+// NewTLSALPNSolver returns a new ACME TLSALPN01 solver.
+func NewTLSALPNSolver(ctx *controller.Context) *TLSALPNSolver {
+	return &TLSALPNSolver{
+		solver:           NewSolver(ctx),
+		testReachability: testTLSReachability,
+		requiredPasses:   5,
+	}
+}
+
+func tlsalpn01LogCtx(ctx context.Context) context.Context {
+	return logf.NewContext(ctx, nil, "tlsalpn01")
+}
+
+// Present will realise the resources required to solve the given TLSALPN01
+// challenge validation in the apiserver. If those resources already exist, it
+// will return nil (i.e. this function is idempotent).
+func (t *TLSALPNSolver) Present(ctx context.Context, issuer v1alpha2.GenericIssuer, ch *cmacme.Challenge) error {
+	ctx = tlsalpn01LogCtx(ctx)
+
+	_, podErr := t.solver.ensurePod(ctx, ch)
+	_, svcErr := t.solver.ensureService(ctx, ch)
+	return utilerrors.NewAggregate([]error{podErr, svcErr})
+}
+
+func (t *TLSALPNSolver) Check(ctx context.Context, issuer v1alpha2.GenericIssuer, ch *cmacme.Challenge) error {
+	ctx = logf.NewContext(tlsalpn01LogCtx(ctx), nil, "selfCheck")
+	log := logf.FromContext(ctx)
+
+	// Present is idempotent and the state of the system may have changed
+	// since present was called by the controllers (killed pods, drained
+	// nodes). Call present again to be certain.
+	// if the listers are nil, that means we're in the present checks
+	// test
+	if t.solver.podLister != nil && t.solver.serviceLister != nil {
+		log.V(logf.DebugLevel).Info("calling Present function before running self check to ensure required resources exist")
+		err := t.Present(ctx, issuer, ch)
+		if err != nil {
+			log.V(logf.DebugLevel).Info("failed to call Present function", "error", err)
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, TLSALPN01Timeout)
+	defer cancel()
+	log = log.WithValues("domain", ch.Spec.DNSName)
+	ctx = logf.NewContext(ctx, log)
+
+	log.V(logf.DebugLevel).Info("running self check multiple times to ensure challenge has propagated", "required_passes", t.requiredPasses)
+	for i := 0; i < t.requiredPasses; i++ {
+		err := t.testReachability(ctx, ch.Spec.DNSName, ch.Spec.Key)
+		if err != nil {
+			return err
+		}
+		log.V(logf.DebugLevel).Info("reachability test passed, re-checking in 2s time")
+		time.Sleep(time.Second * 2)
+	}
+
+	log.V(logf.DebugLevel).Info("self check succeeded")
+
+	return nil
+}
+
+// CleanUp will ensure the created service and pod are clean/deleted of any
+// cert-manager created data.
+func (t *TLSALPNSolver) CleanUp(ctx context.Context, issuer v1alpha2.GenericIssuer, ch *cmacme.Challenge) error {
+	var errs []error
+	errs = append(errs, t.solver.cleanupPods(ctx, ch))
+	errs = append(errs, t.solver.cleanupServices(ctx, ch))
+	return utilerrors.NewAggregate(errs)
+}
+
+// testTLSReachability will attempt a TLS handshake with 'domain' on port 443
+// using the 'acme-tls/1' ALPN protocol, and check if the presented certificate
+// contains the acmeIdentifier extension for 'key'
+func testTLSReachability(ctx context.Context, domain, key string) error {
+	log := logf.FromContext(ctx)
+	log.V(logf.DebugLevel).Info("performing TLSALPN01 reachability check")
+
+	addr := net.JoinHostPort(domain, "443")
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		log.V(logf.DebugLevel).Info("failed to connect", "error", err)
+		return fmt.Errorf("failed to connect to '%s': %v", addr, err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	tlsConn := tls.Client(conn, &tls.Config{
+		ServerName: domain,
+		NextProtos: []string{solver.ACMETLS1Protocol},
+		// the challenge certificate is self-signed, so is verified below
+		InsecureSkipVerify: true,
+	})
+	if err := tlsConn.Handshake(); err != nil {
+		log.V(logf.DebugLevel).Info("failed to perform TLS handshake", "error", err)
+		return fmt.Errorf("failed to perform self check TLS handshake with '%s': %v", addr, err)
+	}
+
+	state := tlsConn.ConnectionState()
+	if state.NegotiatedProtocol != solver.ACMETLS1Protocol {
+		log.V(logf.DebugLevel).Info("server did not negotiate the acme-tls/1 protocol", "protocol", state.NegotiatedProtocol)
+		return fmt.Errorf("wrong negotiated protocol '%s', expected '%s'", state.NegotiatedProtocol, solver.ACMETLS1Protocol)
+	}
+	if len(state.PeerCertificates) == 0 {
+		return fmt.Errorf("no certificate presented by '%s'", addr)
+	}
+
+	if err := solver.VerifyTLSALPN01ChallengeCert(state.PeerCertificates[0], domain, key); err != nil {
+		log.V(logf.DebugLevel).Info("presented certificate did not match expected", "error", err)
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("reachability test succeeded")
+
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/controller"
+)
+
+func TestTLSALPNCheck(t *testing.T) {
+	tests := map[string]struct {
+		reachabilityTest tlsReachabilityTest
+		expectedErr      bool
+	}{
+		"should pass": {
+			reachabilityTest: func(context.Context, string, string) error {
+				return nil
+			},
+		},
+		"should error": {
+			reachabilityTest: func(context.Context, string, string) error {
+				return fmt.Errorf("failed")
+			},
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			requiredCallsForPass := 2
+			s := TLSALPNSolver{
+				solver: &Solver{},
+				testReachability: func(ctx context.Context, domain, key string) error {
+					calls++
+					return test.reachabilityTest(ctx, domain, key)
+				},
+				requiredPasses: requiredCallsForPass,
+			}
+
+			err := s.Check(context.Background(), nil, &cmacme.Challenge{})
+			if (err != nil) != test.expectedErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+				return
+			}
+			if !test.expectedErr && calls != requiredCallsForPass {
+				t.Errorf("Expected Check to verify reachability test passes %d times, but only checked %d", requiredCallsForPass, calls)
+			}
+		})
+	}
+}
+
+func TestBuildTLSALPNSolverResources(t *testing.T) {
+	ch := &cmacme.Challenge{
+		Spec: cmacme.ChallengeSpec{
+			Type:    cmacme.ACMEChallengeTypeTLSALPN01,
+			DNSName: "example.com",
+			Token:   "token",
+			Key:     "key",
+			Solver: &cmacme.ACMEChallengeSolver{
+				TLSALPN01: &cmacme.ACMEChallengeSolverTLSALPN01{
+					ServiceType: corev1.ServiceTypeClusterIP,
+					PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+						ACMEChallengeSolverHTTP01IngressPodObjectMeta: cmacme.ACMEChallengeSolverHTTP01IngressPodObjectMeta{
+							Labels: map[string]string{"foo": "bar"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	svc, err := buildService(ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if svc.Spec.Type != corev1.ServiceTypeClusterIP {
+		t.Errorf("unexpected service type %q", svc.Spec.Type)
+	}
+	if port := svc.Spec.Ports[0]; port.Name != "https" || port.Port != acmeSolverTLSListenPort {
+		t.Errorf("unexpected service port %v", port)
+	}
+
+	s := &Solver{Context: &controller.Context{}}
+	pod := s.buildPod(ch)
+	if pod.Labels["foo"] != "bar" {
+		t.Errorf("expected pod template labels to be applied, got %v", pod.Labels)
+	}
+	container := pod.Spec.Containers[0]
+	if port := container.Ports[0]; port.Name != "https" || port.ContainerPort != acmeSolverTLSListenPort {
+		t.Errorf("unexpected container port %v", port)
+	}
+	expectedArgs := []string{
+		"--listen-port=8443",
+		"--domain=example.com",
+		"--token=token",
+		"--key=key",
+		"--challenge-type=tls-alpn-01",
+	}
+	if fmt.Sprint(container.Args) != fmt.Sprint(expectedArgs) {
+		t.Errorf("unexpected container args, exp=%v got=%v", expectedArgs, container.Args)
+	}
+}
+
+func TestBuildServiceTLSALPNMissingConfig(t *testing.T) {
+	ch := &cmacme.Challenge{
+		Spec: cmacme.ChallengeSpec{
+			Type: cmacme.ACMEChallengeTypeTLSALPN01,
+			Solver: &cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				},
+			},
+		},
+	}
+	if _, err := buildService(ch); err == nil {
+		t.Errorf("expected an error building a service without TLSALPN01 config")
+	}
+}
@@ -18,7 +18,7 @@ package acme
 
 import (
 	"context"
-	"crypto/rsa"
+	"crypto"
 	"encoding/base64"
 	"fmt"
 	"net/url"
@@ -32,9 +32,11 @@ import (
 	"github.com/jetstack/cert-manager/pkg/acme"
 	"github.com/jetstack/cert-manager/pkg/acme/client"
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util"
 	"github.com/jetstack/cert-manager/pkg/util/errors"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
@@ -43,15 +45,25 @@ const (
 	errorAccountRegistrationFailed = "ErrRegisterACMEAccount"
 	errorAccountVerificationFailed = "ErrVerifyACMEAccount"
 	errorAccountUpdateFailed       = "ErrUpdateACMEAccount"
+	errorAccountKeyRolloverFailed  = "ErrRolloverACMEAccountKey"
+	errorAccountKeyChanged         = "ErrACMEAccountKeyChanged"
 
-	successAccountRegistered = "ACMEAccountRegistered"
-	successAccountVerified   = "ACMEAccountVerified"
+	successAccountRegistered    = "ACMEAccountRegistered"
+	successAccountVerified      = "ACMEAccountVerified"
+	successAccountKeyRolledOver = "ACMEAccountKeyRolledOver"
 
 	messageAccountRegistrationFailed = "Failed to register ACME account: "
 	messageAccountVerificationFailed = "Failed to verify ACME account: "
 	messageAccountUpdateFailed       = "Failed to update ACME account:"
 	messageAccountRegistered         = "The ACME account was registered with the ACME server"
 	messageAccountVerified           = "The ACME account was verified with the ACME server"
+	messageAccountKeyRolloverFailed  = "Failed to roll over ACME account key: "
+	messageAccountKeyRolledOver      = "The ACME account key was rolled over to the new private key"
+	messageAccountKeyChanged         = "The account private key has changed and is not the key of ACME account %q. " +
+		"Add the previous private key to the Secret at key %q to roll over the account key to the new private key"
+
+	reasonTermsOfServiceChanged = "NewTermsOfService"
+	reasonTermsOfServiceAgreed  = "TermsOfServiceAgreed"
 )
 
 // Setup will verify an existing ACME registration, or create one if not
@@ -105,6 +117,26 @@ func (a *Acme) Setup(ctx context.Context) error {
 
 	}
 
+	// if the previous account private key is present in the secret, the
+	// account key is being rolled over to the new key.
+	previousPK, err := a.readPreviousPrivateKey(a.issuer.GetSpec().ACME.PrivateKey, ns)
+	switch {
+	case errors.IsInvalidData(err):
+		apiutil.SetIssuerCondition(a.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorAccountKeyRolloverFailed, fmt.Sprintf("Previous account private key is invalid: %v", err))
+		return nil
+
+	case err != nil:
+		s := messageAccountVerificationFailed + err.Error()
+		apiutil.SetIssuerCondition(a.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorAccountVerificationFailed, s)
+		return fmt.Errorf(s)
+	}
+
+	thumbprint, err := acmeapi.JWKThumbprint(pk.Public())
+	if err != nil {
+		apiutil.SetIssuerCondition(a.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorAccountVerificationFailed, fmt.Sprintf("Account private key is invalid: %v", err))
+		return nil
+	}
+
 	acme.ClearClientCache()
 
 	cl, err := acme.ClientWithKey(a.issuer, pk)
@@ -151,14 +183,26 @@ func (a *Acme) Setup(ctx context.Context) error {
 		Status: cmmeta.ConditionTrue,
 	})
 
+	// check whether the ACME server has published new terms of service since
+	// the account agreed to them. Failing to fetch the terms of service does
+	// not prevent the account from being used, so errors are only logged.
+	terms, err := termsOfService(ctx, cl)
+	if err != nil {
+		log.Error(err, "failed to fetch ACME server terms of service")
+	}
+	a.updateTermsOfService(terms)
+
 	// If the Host components of the server URL and the account URL match,
-	// and the cached email matches the registered email, then
+	// and the cached emails match the registered emails, then
 	// we skip re-checking the account status to save excess calls to the
 	// ACME api.
 	if hasReadyCondition &&
+		previousPK == nil &&
 		a.issuer.GetStatus().ACMEStatus().URI != "" &&
+		a.issuer.GetStatus().ACMEStatus().AccountKeyThumbprint == thumbprint &&
 		parsedAccountURL.Host == parsedServerURL.Host &&
-		a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail == a.issuer.GetSpec().ACME.Email {
+		a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail == a.issuer.GetSpec().ACME.Email &&
+		util.EqualSorted(a.issuer.GetStatus().ACMEStatus().LastRegisteredAdditionalEmails, a.issuer.GetSpec().ACME.AdditionalEmails) {
 		log.Info("skipping re-verifying ACME account as cached registration " +
 			"details look sufficient")
 		return nil
@@ -170,6 +214,29 @@ func (a *Acme) Setup(ctx context.Context) error {
 		a.issuer.GetStatus().ACMEStatus().URI = ""
 	}
 
+	// if the private key has been replaced without the previous key being
+	// added to the secret, the account key cannot be rolled over. Registering
+	// a new account with the new key would orphan the existing account, so
+	// the issuer is marked as not ready instead.
+	if previousPK == nil && a.accountKeyReplaced(thumbprint) {
+		matches, err := a.accountKeyMatches(ctx, cl)
+		if err != nil {
+			s := messageAccountVerificationFailed + err.Error()
+			log.Error(err, "failed to verify acme account")
+			apiutil.SetIssuerCondition(a.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorAccountVerificationFailed, s)
+			return err
+		}
+		if !matches {
+			s := fmt.Sprintf(messageAccountKeyChanged, a.issuer.GetStatus().ACMEStatus().URI, acme.PreviousPrivateKeySelector(a.issuer.GetSpec().ACME.PrivateKey).Key)
+			log.Info("ACME account private key has changed without the account key being rolled over")
+			a.Recorder.Event(a.issuer, v1.EventTypeWarning, errorAccountKeyChanged, s)
+			apiutil.SetIssuerCondition(a.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorAccountKeyChanged, s)
+			// return nil so that Setup only gets called again after the
+			// secret or the issuer is updated
+			return nil
+		}
+	}
+
 	var eabAccount *acmeapi.ExternalAccountBinding
 	if eabObj := a.issuer.GetSpec().ACME.ExternalAccountBinding; eabObj != nil {
 		eabKey, err := a.getEABKey(ns)
@@ -200,6 +267,38 @@ func (a *Acme) Setup(ctx context.Context) error {
 		}
 	}
 
+	// change the key of the existing account rather than registering a new
+	// account with the new key.
+	if previousPK != nil && a.issuer.GetStatus().ACMEStatus().URI != "" {
+		previousCl, err := acme.ClientWithKey(a.issuer, previousPK)
+		if err == nil {
+			err = a.rolloverAccountKey(ctx, previousCl, cl, pk)
+		}
+		if err != nil {
+			s := messageAccountKeyRolloverFailed + err.Error()
+			log.Error(err, "failed to roll over ACME account key")
+			a.Recorder.Event(a.issuer, v1.EventTypeWarning, errorAccountKeyRolloverFailed, s)
+			apiutil.SetIssuerCondition(a.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorAccountKeyRolloverFailed, s)
+
+			acmeErr, ok := err.(*acmeapi.Error)
+			// If this is not an ACME error, we will simply return it and retry later
+			if !ok {
+				return err
+			}
+
+			// If the status code is 400 (BadRequest), we will *not* retry the
+			// rollover as it implies that one of the keys is invalid.
+			if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
+				log.Error(acmeErr, "skipping retrying account key rollover as a "+
+					"BadRequest response was returned from the ACME server")
+				return nil
+			}
+
+			// Otherwise if we receive anything other than a 400, we will retry.
+			return err
+		}
+	}
+
 	// registerAccount will also verify the account exists if it already
 	// exists.
 	account, err := a.registerAccount(ctx, cl, eabAccount)
@@ -229,9 +328,8 @@ func (a *Acme) Setup(ctx context.Context) error {
 	}
 
 	// if we got an account successfully, we must check if the registered
-	// email is the same as in the issuer spec
-	specEmail := a.issuer.GetSpec().ACME.Email
-	account, registeredEmail, err := ensureEmailUpToDate(ctx, cl, account, specEmail)
+	// emails are the same as in the issuer spec
+	account, err = ensureContactsUpToDate(ctx, cl, account, accountContacts(a.issuer.GetSpec().ACME))
 	if err != nil {
 		s := messageAccountUpdateFailed + err.Error()
 		log.Error(err, "failed to update ACME account")
@@ -257,43 +355,188 @@ func (a *Acme) Setup(ctx context.Context) error {
 		return err
 	}
 
+	if previousPK != nil {
+		if err := a.removePreviousPrivateKey(a.issuer.GetSpec().ACME.PrivateKey, ns); err != nil {
+			s := messageAccountKeyRolloverFailed + err.Error()
+			apiutil.SetIssuerCondition(a.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorAccountKeyRolloverFailed, s)
+			return err
+		}
+	}
+
 	log.Info("verified existing registration with ACME server")
 	apiutil.SetIssuerCondition(a.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionTrue, successAccountRegistered, messageAccountRegistered)
 	a.issuer.GetStatus().ACMEStatus().URI = account.URI
-	a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail = registeredEmail
+	a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail = a.issuer.GetSpec().ACME.Email
+	a.issuer.GetStatus().ACMEStatus().LastRegisteredAdditionalEmails = a.issuer.GetSpec().ACME.AdditionalEmails
+	a.issuer.GetStatus().ACMEStatus().AccountKeyThumbprint = thumbprint
+	a.updateTermsOfService(terms)
 
 	return nil
 }
 
-func ensureEmailUpToDate(ctx context.Context, cl client.Interface, acc *acmeapi.Account, specEmail string) (*acmeapi.Account, string, error) {
+// rolloverAccountKey changes the key of the ACME account recorded on the
+// issuer's status to newKey, using previousCl which is configured with the
+// account's current key. If newKey is already the key of the account, e.g.
+// because the previous key could not be removed from its secret after an
+// earlier rollover, no change is made.
+func (a *Acme) rolloverAccountKey(ctx context.Context, previousCl, cl client.Interface, newKey crypto.Signer) error {
 	log := logf.FromContext(ctx)
+	accountURL := a.issuer.GetStatus().ACMEStatus().URI
 
-	// if no email was specified, then registeredEmail will remain empty
-	registeredEmail := ""
-	if len(acc.Contact) > 0 {
-		registeredEmail = strings.Replace(acc.Contact[0], "mailto:", "", 1)
+	acc, err := cl.GetReg(ctx, "")
+	if err == nil {
+		if acc.URI != accountURL {
+			return fmt.Errorf("new private key is already registered to another ACME account %q", acc.URI)
+		}
+		return nil
+	}
+	if err != acmeapi.ErrNoAccount {
+		return err
 	}
 
-	// if they are different, we update the account
-	if registeredEmail != specEmail {
-		log.Info("updating ACME account email address", "email", specEmail)
-		emailurl := []string(nil)
-		if specEmail != "" {
-			emailurl = []string{fmt.Sprintf("mailto:%s", strings.ToLower(specEmail))}
-		}
-		acc.Contact = emailurl
+	log.Info("rolling over ACME account key", "account", accountURL)
+	if err := previousCl.AccountKeyRollover(ctx, accountURL, newKey); err != nil {
+		return err
+	}
 
-		var err error
-		acc, err = cl.UpdateReg(ctx, acc)
-		if err != nil {
-			return nil, "", err
-		}
+	a.Recorder.Event(a.issuer, v1.EventTypeNormal, successAccountKeyRolledOver, messageAccountKeyRolledOver)
 
-		// update the registeredEmail var so it is updated properly in the status below
-		registeredEmail = specEmail
+	return nil
+}
+
+// accountKeyReplaced returns true if an ACME account is recorded on the
+// issuer's status and the thumbprint of its private key differs from the
+// given thumbprint of the current account private key.
+func (a *Acme) accountKeyReplaced(thumbprint string) bool {
+	status := a.issuer.GetStatus().ACMEStatus()
+	return status.URI != "" && status.AccountKeyThumbprint != "" && status.AccountKeyThumbprint != thumbprint
+}
+
+// accountKeyMatches returns true if the private key cl is configured with is
+// the key of the ACME account recorded on the issuer's status, e.g. because
+// the account key was rolled over outside of cert-manager.
+func (a *Acme) accountKeyMatches(ctx context.Context, cl client.Interface) (bool, error) {
+	acc, err := cl.GetReg(ctx, "")
+	if err == acmeapi.ErrNoAccount {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return acc.URI == a.issuer.GetStatus().ACMEStatus().URI, nil
+}
+
+// readPreviousPrivateKey reads the previous ACME account private key from
+// the account private key's secret, returning nil if it is not present.
+func (a *Acme) readPreviousPrivateKey(sel cmmeta.SecretKeySelector, ns string) (crypto.Signer, error) {
+	sel = acme.PreviousPrivateKeySelector(sel)
+
+	s, err := a.secretsLister.Secrets(ns).Get(sel.Name)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.Data[sel.Key]; !ok {
+		return nil, nil
 	}
 
-	return acc, registeredEmail, nil
+	return a.helper.ReadPrivateKey(sel, ns)
+}
+
+// removePreviousPrivateKey removes the previous ACME account private key from
+// the account private key's secret once the account key has been rolled over.
+func (a *Acme) removePreviousPrivateKey(sel cmmeta.SecretKeySelector, ns string) error {
+	sel = acme.PreviousPrivateKeySelector(sel)
+
+	s, err := a.secretsLister.Secrets(ns).Get(sel.Name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, ok := s.Data[sel.Key]; !ok {
+		return nil
+	}
+
+	s = s.DeepCopy()
+	delete(s.Data, sel.Key)
+	_, err = a.Client.CoreV1().Secrets(ns).Update(s)
+
+	return err
+}
+
+// accountContacts returns the contacts that should be registered with the
+// ACME account for the given issuer: mailto URLs for its email and any
+// additional emails.
+func accountContacts(spec *cmacme.ACMEIssuer) []string {
+	var contacts []string
+	if spec.Email != "" {
+		contacts = append(contacts, fmt.Sprintf("mailto:%s", strings.ToLower(spec.Email)))
+	}
+	for _, email := range spec.AdditionalEmails {
+		contacts = append(contacts, fmt.Sprintf("mailto:%s", strings.ToLower(email)))
+	}
+	return contacts
+}
+
+// ensureContactsUpToDate updates the contacts of the given account if they
+// differ from the given contacts.
+func ensureContactsUpToDate(ctx context.Context, cl client.Interface, acc *acmeapi.Account, contacts []string) (*acmeapi.Account, error) {
+	log := logf.FromContext(ctx)
+
+	if util.EqualSorted(acc.Contact, contacts) {
+		return acc, nil
+	}
+
+	log.Info("updating ACME account contacts", "contacts", contacts)
+	acc.Contact = contacts
+
+	return cl.UpdateReg(ctx, acc)
+}
+
+// termsOfService returns the URL of the terms of service currently published
+// by the ACME server, if any.
+func termsOfService(ctx context.Context, cl client.Interface) (string, error) {
+	dir, err := cl.Discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return dir.Terms, nil
+}
+
+// updateTermsOfService sets the TermsOfServiceChanged condition on the
+// issuer if the given terms of service published by the ACME server differ
+// from those the account agreed to. If the terms the account agreed to are
+// not known, e.g. because the account was registered by an earlier version
+// of cert-manager, the account is assumed to have agreed to the current
+// terms. New terms are recorded as agreed to once they are set in
+// spec.acme.agreeTermsOfService.
+func (a *Acme) updateTermsOfService(terms string) {
+	status := a.issuer.GetStatus().ACMEStatus()
+	if terms == "" || status.URI == "" {
+		return
+	}
+
+	if status.AgreedTermsOfService == "" || a.issuer.GetSpec().ACME.AgreeTermsOfService == terms {
+		status.AgreedTermsOfService = terms
+	}
+
+	if status.AgreedTermsOfService != terms {
+		apiutil.SetIssuerCondition(a.issuer, v1alpha2.IssuerConditionTermsOfServiceChanged, cmmeta.ConditionTrue, reasonTermsOfServiceChanged,
+			fmt.Sprintf("The ACME server has published new terms of service %q. The account agreed to %q. Set spec.acme.agreeTermsOfService to %q to agree to the new terms", terms, status.AgreedTermsOfService, terms))
+		return
+	}
+
+	if apiutil.IssuerHasCondition(a.issuer, v1alpha2.IssuerCondition{
+		Type:   v1alpha2.IssuerConditionTermsOfServiceChanged,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		apiutil.SetIssuerCondition(a.issuer, v1alpha2.IssuerConditionTermsOfServiceChanged, cmmeta.ConditionFalse, reasonTermsOfServiceAgreed,
+			"The account has agreed to the ACME server's current terms of service")
+	}
 }
 
 // registerAccount will register a new ACME account with the server. If an
@@ -310,20 +553,21 @@ func (a *Acme) registerAccount(ctx context.Context, cl client.Interface, eabAcco
 		return nil, err
 	}
 
-	emailurl := []string(nil)
-	if a.issuer.GetSpec().ACME.Email != "" {
-		emailurl = []string{fmt.Sprintf("mailto:%s", strings.ToLower(a.issuer.GetSpec().ACME.Email))}
-	}
-
 	acc = &acmeapi.Account{
-		Contact:                emailurl,
+		Contact:                accountContacts(a.issuer.GetSpec().ACME),
 		ExternalAccountBinding: eabAccount,
 	}
 
-	acc, err = cl.Register(ctx, acc, acmeapi.AcceptTOS)
+	// record the terms of service the new account agrees to
+	var agreedTerms string
+	acc, err = cl.Register(ctx, acc, func(tosURL string) bool {
+		agreedTerms = tosURL
+		return true
+	})
 	if err != nil {
 		return nil, err
 	}
+	a.issuer.GetStatus().ACMEStatus().AgreedTermsOfService = agreedTerms
 	// TODO: re-enable this check once this field is set by Pebble
 	// if acc.Status != acme.StatusValid {
 	// 	return nil, fmt.Errorf("acme account is not valid")
@@ -363,7 +607,7 @@ func (a *Acme) getEABKey(ns string) ([]byte, error) {
 
 // createAccountPrivateKey will generate a new RSA private key, and create it
 // as a secret resource in the apiserver.
-func (a *Acme) createAccountPrivateKey(sel cmmeta.SecretKeySelector, ns string) (*rsa.PrivateKey, error) {
+func (a *Acme) createAccountPrivateKey(sel cmmeta.SecretKeySelector, ns string) (crypto.Signer, error) {
 	sel = acme.PrivateKeySelector(sel)
 	accountPrivKey, err := pki.GenerateRSAPrivateKey(pki.MinRSAKeySize)
 	if err != nil {
@@ -18,10 +18,24 @@ package acme
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"reflect"
 	"testing"
 
+	acmeapi "golang.org/x/crypto/acme"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/jetstack/cert-manager/pkg/acme/client"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/controller"
 	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/test/unit/gen"
 )
 
 type testT struct {
@@ -51,3 +65,297 @@ func runSetupTest(t *testing.T, test testT) {
 
 	test.builder.CheckAndFinish(err)
 }
+
+func TestRolloverAccountKey(t *testing.T) {
+	const accountURL = "https://acme.example.com/account/1"
+
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		getRegAccount *acmeapi.Account
+		getRegErr     error
+		rolloverErr   error
+
+		expectedRollover bool
+		expectedErr      bool
+	}{
+		"the account key should be rolled over if the new key is not registered": {
+			getRegErr:        acmeapi.ErrNoAccount,
+			expectedRollover: true,
+		},
+		"the account key should not be rolled over if the new key is already the account's key": {
+			getRegAccount: &acmeapi.Account{URI: accountURL},
+		},
+		"an error should be returned if the new key is registered to another account": {
+			getRegAccount: &acmeapi.Account{URI: "https://acme.example.com/account/2"},
+			expectedErr:   true,
+		},
+		"an error looking up the new key's account should be returned": {
+			getRegErr:   errors.New("server unavailable"),
+			expectedErr: true,
+		},
+		"an error rolling over the account key should be returned": {
+			getRegErr:        acmeapi.ErrNoAccount,
+			rolloverErr:      &acmeapi.Error{StatusCode: 409},
+			expectedRollover: true,
+			expectedErr:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("test", gen.SetIssuerACME(cmacme.ACMEIssuer{}))
+			issuer.Status.ACME = &cmacme.ACMEIssuerStatus{URI: accountURL}
+
+			a := &Acme{
+				Context: &controller.Context{Recorder: record.NewFakeRecorder(10)},
+				issuer:  issuer,
+			}
+
+			var rolledOver bool
+			previousCl := &client.FakeACME{
+				FakeAccountKeyRollover: func(_ context.Context, url string, key crypto.Signer) error {
+					rolledOver = true
+					if url != accountURL || key != newKey {
+						t.Errorf("unexpected account key rollover of %q", url)
+					}
+					return test.rolloverErr
+				},
+			}
+			cl := &client.FakeACME{
+				FakeGetReg: func(context.Context, string) (*acmeapi.Account, error) {
+					return test.getRegAccount, test.getRegErr
+				},
+			}
+
+			err := a.rolloverAccountKey(context.Background(), previousCl, cl, newKey)
+			if (err != nil) != test.expectedErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+			if rolledOver != test.expectedRollover {
+				t.Errorf("unexpected account key rollover, exp=%t got=%t", test.expectedRollover, rolledOver)
+			}
+		})
+	}
+}
+
+func TestAccountKeyReplaced(t *testing.T) {
+	const accountURL = "https://acme.example.com/account/1"
+
+	tests := map[string]struct {
+		status     cmacme.ACMEIssuerStatus
+		thumbprint string
+		expected   bool
+	}{
+		"an unchanged key should not be reported as replaced": {
+			status:     cmacme.ACMEIssuerStatus{URI: accountURL, AccountKeyThumbprint: "abc"},
+			thumbprint: "abc",
+		},
+		"a changed key should be reported as replaced": {
+			status:     cmacme.ACMEIssuerStatus{URI: accountURL, AccountKeyThumbprint: "abc"},
+			thumbprint: "def",
+			expected:   true,
+		},
+		"a key should not be reported as replaced if no thumbprint is recorded": {
+			status:     cmacme.ACMEIssuerStatus{URI: accountURL},
+			thumbprint: "def",
+		},
+		"a key should not be reported as replaced if no account is recorded": {
+			status:     cmacme.ACMEIssuerStatus{AccountKeyThumbprint: "abc"},
+			thumbprint: "def",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("test", gen.SetIssuerACME(cmacme.ACMEIssuer{}))
+			issuer.Status.ACME = &test.status
+
+			a := &Acme{issuer: issuer}
+			if replaced := a.accountKeyReplaced(test.thumbprint); replaced != test.expected {
+				t.Errorf("unexpected result, exp=%t got=%t", test.expected, replaced)
+			}
+		})
+	}
+}
+
+func TestAccountKeyMatches(t *testing.T) {
+	const accountURL = "https://acme.example.com/account/1"
+
+	tests := map[string]struct {
+		getRegAccount *acmeapi.Account
+		getRegErr     error
+
+		expected    bool
+		expectedErr bool
+	}{
+		"the key should match if it is the key of the recorded account": {
+			getRegAccount: &acmeapi.Account{URI: accountURL},
+			expected:      true,
+		},
+		"the key should not match if it is not registered": {
+			getRegErr: acmeapi.ErrNoAccount,
+		},
+		"the key should not match if it is registered to another account": {
+			getRegAccount: &acmeapi.Account{URI: "https://acme.example.com/account/2"},
+		},
+		"an error looking up the key's account should be returned": {
+			getRegErr:   errors.New("server unavailable"),
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("test", gen.SetIssuerACME(cmacme.ACMEIssuer{}))
+			issuer.Status.ACME = &cmacme.ACMEIssuerStatus{URI: accountURL}
+
+			a := &Acme{issuer: issuer}
+			cl := &client.FakeACME{
+				FakeGetReg: func(context.Context, string) (*acmeapi.Account, error) {
+					return test.getRegAccount, test.getRegErr
+				},
+			}
+
+			matches, err := a.accountKeyMatches(context.Background(), cl)
+			if (err != nil) != test.expectedErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+			if matches != test.expected {
+				t.Errorf("unexpected result, exp=%t got=%t", test.expected, matches)
+			}
+		})
+	}
+}
+
+func TestAccountContacts(t *testing.T) {
+	spec := &cmacme.ACMEIssuer{
+		Email:            "Test@Example.com",
+		AdditionalEmails: []string{"ops@example.com", "Security@Example.com"},
+	}
+	exp := []string{"mailto:test@example.com", "mailto:ops@example.com", "mailto:security@example.com"}
+
+	if got := accountContacts(spec); !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected contacts, exp=%v got=%v", exp, got)
+	}
+
+	if got := accountContacts(&cmacme.ACMEIssuer{}); len(got) != 0 {
+		t.Errorf("expected no contacts, got=%v", got)
+	}
+}
+
+func TestUpdateTermsOfService(t *testing.T) {
+	const (
+		oldTerms = "https://acme.example.com/terms/1"
+		newTerms = "https://acme.example.com/terms/2"
+	)
+
+	tests := map[string]struct {
+		uri             string
+		agree           string
+		agreed          string
+		terms           string
+		condition       *cmapi.IssuerCondition
+		expectedAgreed  string
+		expectedStatus  cmmeta.ConditionStatus
+		expectCondition bool
+	}{
+		"no terms should leave the status untouched": {
+			uri:    "https://acme.example.com/account/1",
+			agreed: oldTerms,
+
+			expectedAgreed: oldTerms,
+		},
+		"an unregistered account should leave the status untouched": {
+			terms: newTerms,
+		},
+		"an existing account with no recorded terms should record the current terms": {
+			uri:   "https://acme.example.com/account/1",
+			terms: oldTerms,
+
+			expectedAgreed: oldTerms,
+		},
+		"new terms should set the condition": {
+			uri:    "https://acme.example.com/account/1",
+			agreed: oldTerms,
+			terms:  newTerms,
+
+			expectedAgreed:  oldTerms,
+			expectedStatus:  cmmeta.ConditionTrue,
+			expectCondition: true,
+		},
+		"new terms should keep the condition if different terms are agreed to in the spec": {
+			uri:    "https://acme.example.com/account/1",
+			agree:  oldTerms,
+			agreed: oldTerms,
+			terms:  newTerms,
+
+			expectedAgreed:  oldTerms,
+			expectedStatus:  cmmeta.ConditionTrue,
+			expectCondition: true,
+		},
+		"new terms agreed to in the spec should be recorded and clear an existing condition": {
+			uri:    "https://acme.example.com/account/1",
+			agree:  newTerms,
+			agreed: oldTerms,
+			terms:  newTerms,
+			condition: &cmapi.IssuerCondition{
+				Type:   cmapi.IssuerConditionTermsOfServiceChanged,
+				Status: cmmeta.ConditionTrue,
+			},
+
+			expectedAgreed:  newTerms,
+			expectedStatus:  cmmeta.ConditionFalse,
+			expectCondition: true,
+		},
+		"agreed terms should clear an existing condition": {
+			uri:    "https://acme.example.com/account/1",
+			agreed: newTerms,
+			terms:  newTerms,
+			condition: &cmapi.IssuerCondition{
+				Type:   cmapi.IssuerConditionTermsOfServiceChanged,
+				Status: cmmeta.ConditionTrue,
+			},
+
+			expectedAgreed:  newTerms,
+			expectedStatus:  cmmeta.ConditionFalse,
+			expectCondition: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("test", gen.SetIssuerACME(cmacme.ACMEIssuer{AgreeTermsOfService: test.agree}))
+			issuer.Status.ACME = &cmacme.ACMEIssuerStatus{
+				URI:                  test.uri,
+				AgreedTermsOfService: test.agreed,
+			}
+			if test.condition != nil {
+				issuer.Status.Conditions = []cmapi.IssuerCondition{*test.condition}
+			}
+
+			a := &Acme{issuer: issuer}
+			a.updateTermsOfService(test.terms)
+
+			if got := issuer.Status.ACME.AgreedTermsOfService; got != test.expectedAgreed {
+				t.Errorf("unexpected agreed terms of service, exp=%q got=%q", test.expectedAgreed, got)
+			}
+
+			var cond *cmapi.IssuerCondition
+			for i, c := range issuer.Status.Conditions {
+				if c.Type == cmapi.IssuerConditionTermsOfServiceChanged {
+					cond = &issuer.Status.Conditions[i]
+				}
+			}
+			if (cond != nil) != test.expectCondition {
+				t.Fatalf("unexpected condition, exp=%t got=%v", test.expectCondition, cond)
+			}
+			if cond != nil && cond.Status != test.expectedStatus {
+				t.Errorf("unexpected condition status, exp=%q got=%q", test.expectedStatus, cond.Status)
+			}
+		})
+	}
+}
@@ -18,7 +18,7 @@ package acme
 
 import (
 	"context"
-	"crypto/rsa"
+	"crypto"
 	"fmt"
 	"testing"
 	"time"
@@ -121,6 +121,6 @@ func (s *acmeFixture) ClientForIssuer(iss v1alpha2.GenericIssuer) (client.Interf
 	return s.Client, nil
 }
 
-func (s *acmeFixture) ReadPrivateKey(sel cmmeta.SecretKeySelector, ns string) (*rsa.PrivateKey, error) {
+func (s *acmeFixture) ReadPrivateKey(sel cmmeta.SecretKeySelector, ns string) (crypto.Signer, error) {
 	return nil, fmt.Errorf("not implemented")
 }
@@ -0,0 +1,206 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+// CRLResyncPeriod returns how often the CRL of a CA issuer should be checked
+// to ensure it is re-signed before it expires.
+func CRLResyncPeriod(crl *v1alpha2.CACRLConfig) time.Duration {
+	return apiutil.DefaultCRLDuration(crl.Duration) / 3
+}
+
+// updateCRL ensures the CRL stored in the Secret named in the CRL config
+// lists the issuer's revoked certificates, and is signed by the given CA with
+// at least a third of its validity period remaining. It returns true if a new
+// CRL was signed.
+func (c *CA) updateCRL(ctx context.Context, crl *v1alpha2.CACRLConfig, caCert *x509.Certificate, caKey crypto.Signer) (bool, error) {
+	log := logf.FromContext(ctx, "crl")
+	log = logf.WithRelatedResourceName(log, crl.SecretName, c.resourceNamespace, "Secret")
+
+	secret, err := c.secretsLister.Secrets(c.resourceNamespace).Get(crl.SecretName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	if apierrors.IsNotFound(err) {
+		secret = nil
+	}
+
+	var existing *pkix.CertificateList
+	if secret != nil {
+		existing = parseCRL(caCert, secret.Data[v1alpha2.CRLSecretKey])
+	}
+
+	now := c.Clock.Now()
+	duration := apiutil.DefaultCRLDuration(crl.Duration)
+	revoked, err := revokedCertificates(crl.RevokedCertificates, existing, now)
+	if err != nil {
+		return false, err
+	}
+
+	if crlUpToDate(existing, revoked, now, duration) {
+		log.V(logf.DebugLevel).Info("CRL is up to date")
+		return false, nil
+	}
+
+	crlDER, err := caCert.CreateCRL(rand.Reader, caKey, revoked, now, now.Add(duration))
+	if err != nil {
+		return false, err
+	}
+
+	if secret == nil {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      crl.SecretName,
+				Namespace: c.resourceNamespace,
+			},
+		}
+	} else {
+		secret = secret.DeepCopy()
+	}
+
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[v1alpha2.IssuerNameAnnotationKey] = c.issuer.GetObjectMeta().Name
+	secret.Annotations[v1alpha2.IssuerKindAnnotationKey] = issuerKind(c.issuer)
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[v1alpha2.CRLSecretKey] = crlDER
+
+	if secret.ResourceVersion == "" {
+		_, err = c.Client.CoreV1().Secrets(c.resourceNamespace).Create(secret)
+	} else {
+		_, err = c.Client.CoreV1().Secrets(c.resourceNamespace).Update(secret)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	log.Info("signed new CRL", "revoked_certificates", len(revoked), "next_update", now.Add(duration))
+
+	return true, nil
+}
+
+func issuerKind(issuer v1alpha2.GenericIssuer) string {
+	if _, ok := issuer.(*v1alpha2.ClusterIssuer); ok {
+		return v1alpha2.ClusterIssuerKind
+	}
+	return v1alpha2.IssuerKind
+}
+
+// parseCRL parses a DER encoded CRL, returning nil if it cannot be parsed or
+// was not signed by the given CA.
+func parseCRL(caCert *x509.Certificate, der []byte) *pkix.CertificateList {
+	if len(der) == 0 {
+		return nil
+	}
+
+	crl, err := x509.ParseDERCRL(der)
+	if err != nil {
+		return nil
+	}
+
+	if err := caCert.CheckCRLSignature(crl); err != nil {
+		return nil
+	}
+
+	return crl
+}
+
+// revokedCertificates builds the list of revoked certificates to include in
+// the CRL. Certificates without a revocation time use the time they were
+// first published in the existing CRL, or now if they have not been
+// published yet.
+func revokedCertificates(certs []v1alpha2.CARevokedCertificate, existing *pkix.CertificateList, now time.Time) ([]pkix.RevokedCertificate, error) {
+	published := make(map[string]time.Time)
+	if existing != nil {
+		for _, rc := range existing.TBSCertList.RevokedCertificates {
+			published[rc.SerialNumber.String()] = rc.RevocationTime
+		}
+	}
+
+	var revoked []pkix.RevokedCertificate
+	for _, rc := range certs {
+		serial, err := pki.ParseSerialNumber(rc.SerialNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		revocationTime, ok := published[serial.String()]
+		if rc.RevocationTime != nil {
+			revocationTime = rc.RevocationTime.Time
+		} else if !ok {
+			revocationTime = now
+		}
+
+		revoked = append(revoked, pkix.RevokedCertificate{
+			SerialNumber: serial,
+			// CRLs encode times with a precision of one second
+			RevocationTime: revocationTime.UTC().Truncate(time.Second),
+		})
+	}
+
+	return revoked, nil
+}
+
+// crlUpToDate returns true if the existing CRL lists exactly the given revoked
+// certificates, was signed with the given validity period, and has more than
+// a third of that period remaining.
+func crlUpToDate(existing *pkix.CertificateList, revoked []pkix.RevokedCertificate, now time.Time, duration time.Duration) bool {
+	if existing == nil {
+		return false
+	}
+
+	tbs := existing.TBSCertList
+	if tbs.NextUpdate.Sub(tbs.ThisUpdate) != duration.Truncate(time.Second) {
+		return false
+	}
+
+	if !now.Before(tbs.NextUpdate.Add(-duration / 3)) {
+		return false
+	}
+
+	if len(tbs.RevokedCertificates) != len(revoked) {
+		return false
+	}
+
+	for i, rc := range tbs.RevokedCertificates {
+		if rc.SerialNumber.Cmp(revoked[i].SerialNumber) != 0 || !rc.RevocationTime.Equal(revoked[i].RevocationTime) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func generateTestCA(t *testing.T, cn string) (*x509.Certificate, crypto.Signer) {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour * 24 * 365),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert, key
+}
+
+func signTestCRL(t *testing.T, caCert *x509.Certificate, caKey crypto.Signer, revoked []pkix.RevokedCertificate, thisUpdate time.Time, duration time.Duration) []byte {
+	der, err := caCert.CreateCRL(rand.Reader, caKey, revoked, thisUpdate, thisUpdate.Add(duration))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestUpdateCRL(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	revokedAt := now.Add(-time.Hour * 48)
+
+	caCert, caKey := generateTestCA(t, "test-ca")
+	otherCACert, otherCAKey := generateTestCA(t, "other-ca")
+
+	crlSecret := func(der []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "ca-crl",
+				Namespace:       gen.DefaultTestNamespace,
+				ResourceVersion: "1",
+			},
+			Data: map[string][]byte{
+				v1alpha2.CRLSecretKey: der,
+			},
+		}
+	}
+
+	revokedSerial := []pkix.RevokedCertificate{{SerialNumber: big.NewInt(0x1a2b), RevocationTime: revokedAt}}
+
+	tests := map[string]struct {
+		crl            *v1alpha2.CACRLConfig
+		existingSecret *corev1.Secret
+
+		expectedUpdated    bool
+		expectedThisUpdate time.Time
+		expectedRevoked    []pkix.RevokedCertificate
+	}{
+		"a CRL should be signed and stored in a new Secret": {
+			crl: &v1alpha2.CACRLConfig{
+				SecretName: "ca-crl",
+				RevokedCertificates: []v1alpha2.CARevokedCertificate{
+					{SerialNumber: "1A2B"},
+				},
+			},
+			expectedUpdated:    true,
+			expectedThisUpdate: now,
+			expectedRevoked:    []pkix.RevokedCertificate{{SerialNumber: big.NewInt(0x1a2b), RevocationTime: now}},
+		},
+		"a CRL that is up to date should not be re-signed": {
+			crl: &v1alpha2.CACRLConfig{
+				SecretName: "ca-crl",
+				RevokedCertificates: []v1alpha2.CARevokedCertificate{
+					{SerialNumber: "1a:2b"},
+				},
+			},
+			existingSecret:     crlSecret(signTestCRL(t, caCert, caKey, revokedSerial, now.Add(-time.Hour), time.Hour*24)),
+			expectedUpdated:    false,
+			expectedThisUpdate: now.Add(-time.Hour),
+			expectedRevoked:    revokedSerial,
+		},
+		"a CRL close to expiry should be re-signed keeping existing revocation times": {
+			crl: &v1alpha2.CACRLConfig{
+				SecretName: "ca-crl",
+				RevokedCertificates: []v1alpha2.CARevokedCertificate{
+					{SerialNumber: "1a2b"},
+				},
+			},
+			existingSecret:     crlSecret(signTestCRL(t, caCert, caKey, revokedSerial, now.Add(-time.Hour*20), time.Hour*24)),
+			expectedUpdated:    true,
+			expectedThisUpdate: now,
+			expectedRevoked:    revokedSerial,
+		},
+		"a CRL should be re-signed when a certificate is revoked": {
+			crl: &v1alpha2.CACRLConfig{
+				SecretName: "ca-crl",
+				RevokedCertificates: []v1alpha2.CARevokedCertificate{
+					{SerialNumber: "1a2b"},
+					{SerialNumber: "3c4d", RevocationTime: &metav1.Time{Time: now.Add(-time.Minute)}},
+				},
+			},
+			existingSecret:     crlSecret(signTestCRL(t, caCert, caKey, revokedSerial, now.Add(-time.Hour), time.Hour*24)),
+			expectedUpdated:    true,
+			expectedThisUpdate: now,
+			expectedRevoked: []pkix.RevokedCertificate{
+				{SerialNumber: big.NewInt(0x1a2b), RevocationTime: revokedAt},
+				{SerialNumber: big.NewInt(0x3c4d), RevocationTime: now.Add(-time.Minute)},
+			},
+		},
+		"a CRL should be re-signed when the duration changes": {
+			crl: &v1alpha2.CACRLConfig{
+				SecretName: "ca-crl",
+				Duration:   &metav1.Duration{Duration: time.Hour * 12},
+				RevokedCertificates: []v1alpha2.CARevokedCertificate{
+					{SerialNumber: "1a2b"},
+				},
+			},
+			existingSecret:     crlSecret(signTestCRL(t, caCert, caKey, revokedSerial, now.Add(-time.Hour), time.Hour*24)),
+			expectedUpdated:    true,
+			expectedThisUpdate: now,
+			expectedRevoked:    revokedSerial,
+		},
+		"a CRL signed by another CA should be re-signed": {
+			crl: &v1alpha2.CACRLConfig{
+				SecretName: "ca-crl",
+				RevokedCertificates: []v1alpha2.CARevokedCertificate{
+					{SerialNumber: "1a2b"},
+				},
+			},
+			existingSecret:     crlSecret(signTestCRL(t, otherCACert, otherCAKey, revokedSerial, now.Add(-time.Hour), time.Hour*24)),
+			expectedUpdated:    true,
+			expectedThisUpdate: now,
+			expectedRevoked:    []pkix.RevokedCertificate{{SerialNumber: big.NewInt(0x1a2b), RevocationTime: now}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("test-issuer",
+				gen.SetIssuerCA(v1alpha2.CAIssuer{SecretName: "ca-key-pair", CRL: test.crl}),
+			)
+
+			var kubeObjects []runtime.Object
+			if test.existingSecret != nil {
+				kubeObjects = append(kubeObjects, test.existingSecret)
+			}
+
+			b := &testpkg.Builder{
+				T:           t,
+				KubeObjects: kubeObjects,
+				Clock:       fakeclock.NewFakeClock(now),
+			}
+			b.Init()
+			defer b.Stop()
+
+			c, err := NewCA(b.Context, issuer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.Start()
+
+			updated, err := c.(*CA).updateCRL(context.Background(), test.crl, caCert, caKey)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if updated != test.expectedUpdated {
+				t.Errorf("unexpected updated, exp=%t got=%t", test.expectedUpdated, updated)
+			}
+
+			secret, err := b.Client.CoreV1().Secrets(gen.DefaultTestNamespace).Get("ca-crl", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get CRL secret: %v", err)
+			}
+
+			crl := parseCRL(caCert, secret.Data[v1alpha2.CRLSecretKey])
+			if crl == nil {
+				t.Fatalf("expected a CRL signed by the CA to be stored in the secret")
+			}
+
+			if !crl.TBSCertList.ThisUpdate.Equal(test.expectedThisUpdate) {
+				t.Errorf("unexpected CRL thisUpdate, exp=%s got=%s", test.expectedThisUpdate, crl.TBSCertList.ThisUpdate)
+			}
+
+			duration := time.Hour * 24
+			if test.crl.Duration != nil {
+				duration = test.crl.Duration.Duration
+			}
+			if exp := test.expectedThisUpdate.Add(duration); !crl.TBSCertList.NextUpdate.Equal(exp) {
+				t.Errorf("unexpected CRL nextUpdate, exp=%s got=%s", exp, crl.TBSCertList.NextUpdate)
+			}
+
+			revoked := crl.TBSCertList.RevokedCertificates
+			if len(revoked) != len(test.expectedRevoked) {
+				t.Fatalf("unexpected revoked certificates, exp=%v got=%v", test.expectedRevoked, revoked)
+			}
+			for i, rc := range revoked {
+				exp := test.expectedRevoked[i]
+				if rc.SerialNumber.Cmp(exp.SerialNumber) != 0 || !rc.RevocationTime.Equal(exp.RevocationTime) {
+					t.Errorf("unexpected revoked certificate, exp=%v got=%v", exp, rc)
+				}
+			}
+
+			if updated && secret.Annotations[v1alpha2.IssuerNameAnnotationKey] != "test-issuer" {
+				t.Errorf("expected the CRL secret to be annotated with the issuer name, got %v", secret.Annotations)
+			}
+		})
+	}
+}
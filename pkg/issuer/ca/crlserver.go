@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/controller"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	crlServerShutdownTimeout = 5 * time.Second
+	crlServerReadTimeout     = 8 * time.Second
+	crlServerWriteTimeout    = 8 * time.Second
+	crlServerMaxHeaderBytes  = 1 << 20 // 1 MiB
+
+	crlContentType = "application/pkix-crl"
+)
+
+// CRLServer serves the DER encoded CRLs maintained for CA issuers over HTTP.
+// The CRL of an Issuer is served at /issuers/<namespace>/<name>.crl, and the
+// CRL of a ClusterIssuer at /clusterissuers/<name>.crl.
+type CRLServer struct {
+	ctx context.Context
+	http.Server
+
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	secretLister        corelisters.SecretLister
+
+	issuerOptions controller.IssuerOptions
+}
+
+// NewCRLServer returns a CRLServer listening on the given address. It must
+// be called before the shared informer factories of the context are started.
+func NewCRLServer(ctx *controller.Context, address string) *CRLServer {
+	router := mux.NewRouter()
+
+	s := &CRLServer{
+		ctx: logf.NewContext(ctx.RootContext, nil, "crl-server"),
+		Server: http.Server{
+			Addr:           address,
+			ReadTimeout:    crlServerReadTimeout,
+			WriteTimeout:   crlServerWriteTimeout,
+			MaxHeaderBytes: crlServerMaxHeaderBytes,
+			Handler:        router,
+		},
+		issuerLister:  ctx.SharedInformerFactory.Certmanager().V1alpha2().Issuers().Lister(),
+		secretLister:  ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		issuerOptions: ctx.IssuerOptions,
+	}
+
+	// ClusterIssuers are not watched if cert-manager is scoped to a single
+	// namespace
+	if ctx.Namespace == "" {
+		s.clusterIssuerLister = ctx.SharedInformerFactory.Certmanager().V1alpha2().ClusterIssuers().Lister()
+	}
+
+	router.HandleFunc("/issuers/{namespace}/{name}.crl", s.serveIssuerCRL).Methods(http.MethodGet)
+	router.HandleFunc("/clusterissuers/{name}.crl", s.serveClusterIssuerCRL).Methods(http.MethodGet)
+
+	return s
+}
+
+// Start runs the server until the stop channel is closed.
+func (s *CRLServer) Start(stopCh <-chan struct{}) {
+	log := logf.FromContext(s.ctx)
+
+	go func() {
+		log := log.WithValues("address", s.Addr)
+		log.Info("listening for connections on")
+		if err := s.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error(err, "error running CRL server")
+			return
+		}
+
+		log.Info("CRL server exited")
+	}()
+
+	<-stopCh
+	log.Info("stopping CRL server...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), crlServerShutdownTimeout)
+	defer cancel()
+
+	if err := s.Shutdown(ctx); err != nil {
+		log.Error(err, "CRL server shutdown failed")
+		return
+	}
+
+	log.Info("CRL server gracefully stopped")
+}
+
+func (s *CRLServer) serveIssuerCRL(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	issuer, err := s.issuerLister.Issuers(vars["namespace"]).Get(vars["name"])
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	s.serveCRL(w, issuer)
+}
+
+func (s *CRLServer) serveClusterIssuerCRL(w http.ResponseWriter, r *http.Request) {
+	if s.clusterIssuerLister == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	issuer, err := s.clusterIssuerLister.Get(mux.Vars(r)["name"])
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	s.serveCRL(w, issuer)
+}
+
+// serveCRL writes the CRL of the given issuer. Only the CRL data entry of
+// the Secret referenced by the issuer's CRL configuration is ever served.
+func (s *CRLServer) serveCRL(w http.ResponseWriter, issuer v1alpha2.GenericIssuer) {
+	spec := issuer.GetSpec()
+	if spec.CA == nil || spec.CA.CRL == nil {
+		http.Error(w, "issuer does not maintain a CRL", http.StatusNotFound)
+		return
+	}
+
+	secret, err := s.secretLister.Secrets(s.issuerOptions.ResourceNamespace(issuer)).Get(spec.CA.CRL.SecretName)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	crl, ok := secret.Data[v1alpha2.CRLSecretKey]
+	if !ok {
+		http.Error(w, "CRL has not been signed yet", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", crlContentType)
+	w.Write(crl)
+}
+
+func (s *CRLServer) writeError(w http.ResponseWriter, err error) {
+	if apierrors.IsNotFound(err) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	logf.FromContext(s.ctx).Error(err, "error serving CRL")
+	http.Error(w, "internal server error", http.StatusInternalServerError)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestCRLServer(t *testing.T) {
+	crlIssuer := gen.Issuer("crl-issuer",
+		gen.SetIssuerCA(v1alpha2.CAIssuer{
+			SecretName: "ca-key-pair",
+			CRL:        &v1alpha2.CACRLConfig{SecretName: "ca-crl"},
+		}),
+	)
+	unsignedIssuer := gen.Issuer("unsigned-issuer",
+		gen.SetIssuerCA(v1alpha2.CAIssuer{
+			SecretName: "ca-key-pair",
+			CRL:        &v1alpha2.CACRLConfig{SecretName: "ca-key-pair"},
+		}),
+	)
+	noCRLIssuer := gen.Issuer("no-crl-issuer",
+		gen.SetIssuerCA(v1alpha2.CAIssuer{SecretName: "ca-key-pair"}),
+	)
+
+	crlSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ca-crl", Namespace: gen.DefaultTestNamespace},
+		Data:       map[string][]byte{v1alpha2.CRLSecretKey: []byte("crl")},
+	}
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ca-key-pair", Namespace: gen.DefaultTestNamespace},
+		Data:       map[string][]byte{corev1.TLSPrivateKeyKey: []byte("private key")},
+	}
+
+	b := &testpkg.Builder{
+		T:                  t,
+		KubeObjects:        []runtime.Object{crlSecret, caSecret},
+		CertManagerObjects: []runtime.Object{crlIssuer, unsignedIssuer, noCRLIssuer},
+	}
+	b.Init()
+	defer b.Stop()
+
+	s := NewCRLServer(b.Context, "")
+	b.Start()
+
+	tests := map[string]struct {
+		path string
+
+		expectedStatus int
+		expectedBody   []byte
+	}{
+		"the CRL of an issuer should be served": {
+			path:           "/issuers/" + gen.DefaultTestNamespace + "/crl-issuer.crl",
+			expectedStatus: http.StatusOK,
+			expectedBody:   []byte("crl"),
+		},
+		"an issuer that does not exist should not be found": {
+			path:           "/issuers/" + gen.DefaultTestNamespace + "/missing.crl",
+			expectedStatus: http.StatusNotFound,
+		},
+		"an issuer without a CRL should not be found": {
+			path:           "/issuers/" + gen.DefaultTestNamespace + "/no-crl-issuer.crl",
+			expectedStatus: http.StatusNotFound,
+		},
+		"only the CRL data entry of a secret should be served": {
+			path:           "/issuers/" + gen.DefaultTestNamespace + "/unsigned-issuer.crl",
+			expectedStatus: http.StatusNotFound,
+		},
+		"a clusterissuer that does not exist should not be found": {
+			path:           "/clusterissuers/missing.crl",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			s.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+			if rec.Code != test.expectedStatus {
+				t.Errorf("unexpected status code, exp=%d got=%d", test.expectedStatus, rec.Code)
+			}
+
+			if test.expectedBody != nil {
+				if !bytes.Equal(rec.Body.Bytes(), test.expectedBody) {
+					t.Errorf("unexpected body, exp=%q got=%q", test.expectedBody, rec.Body.Bytes())
+				}
+				if ct := rec.Header().Get("Content-Type"); ct != crlContentType {
+					t.Errorf("unexpected content type, exp=%s got=%s", crlContentType, ct)
+				}
+			}
+		})
+	}
+}
@@ -31,13 +31,17 @@ import (
 const (
 	errorGetKeyPair     = "ErrGetKeyPair"
 	errorInvalidKeyPair = "ErrInvalidKeyPair"
+	errorUpdateCRL      = "ErrUpdateCRL"
 
 	successKeyPairVerified = "KeyPairVerified"
+	successCRLUpdated      = "CRLUpdated"
 
 	messageErrorGetKeyPair     = "Error getting keypair for CA issuer: "
 	messageErrorInvalidKeyPair = "Invalid signing key pair: "
+	messageErrorUpdateCRL      = "Error updating CRL: "
 
 	messageKeyPairVerified = "Signing CA verified"
+	messageCRLUpdated      = "Signed new CRL"
 )
 
 func (c *CA) Setup(ctx context.Context) error {
@@ -52,7 +56,7 @@ func (c *CA) Setup(ctx context.Context) error {
 		return err
 	}
 
-	_, err = kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	key, err := kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
 	if err != nil {
 		log.Error(err, "error getting signing CA private key")
 		s := messageErrorGetKeyPair + err.Error()
@@ -75,5 +79,22 @@ func (c *CA) Setup(ctx context.Context) error {
 	c.Recorder.Event(c.issuer, v1.EventTypeNormal, successKeyPairVerified, messageKeyPairVerified)
 	apiutil.SetIssuerCondition(c.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionTrue, successKeyPairVerified, messageKeyPairVerified)
 
+	crl := c.issuer.GetSpec().CA.CRL
+	if crl == nil {
+		return nil
+	}
+
+	updated, err := c.updateCRL(ctx, crl, cert, key)
+	if err != nil {
+		log.Error(err, "error updating CRL")
+		s := messageErrorUpdateCRL + err.Error()
+		c.Recorder.Event(c.issuer, v1.EventTypeWarning, errorUpdateCRL, s)
+		return err
+	}
+
+	if updated {
+		c.Recorder.Event(c.issuer, v1.EventTypeNormal, successCRLUpdated, messageCRLUpdated)
+	}
+
 	return nil
 }
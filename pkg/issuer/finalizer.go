@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"context"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+)
+
+// finalizerNames are the names of the finalizers that issuer implementations
+// may add to issuer resources. They are removed from deleted issuer
+// resources even if the issuer implementation that added them can no longer
+// be used, e.g. because the resource is invalid or its issuer type changed.
+var finalizerNames = []string{
+	cmacme.ACMEAccountDeactivationFinalizer,
+}
+
+// SyncFinalizer ensures the issuer resource has the finalizer required by its
+// issuer implementation, if it implements Finalizer. If the resource is being
+// deleted, the issuer is finalized and the finalizer removed.
+// It returns true if the finalizers of the resource were changed, in which
+// case the resource must be updated.
+func SyncFinalizer(ctx context.Context, iss cmapi.GenericIssuer, i Interface) (bool, error) {
+	meta := iss.GetObjectMeta()
+	if meta.DeletionTimestamp != nil {
+		return FinalizeDeleted(ctx, iss, i)
+	}
+
+	f, ok := i.(Finalizer)
+	if !ok {
+		return false, nil
+	}
+
+	name := f.FinalizerName()
+	has := hasFinalizer(meta.Finalizers, name)
+
+	switch needs := f.NeedsFinalizer(); {
+	case needs && !has:
+		meta.Finalizers = append(meta.Finalizers, name)
+		return true, nil
+	case !needs && has:
+		meta.Finalizers = removeFinalizer(meta.Finalizers, name)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// FinalizeDeleted finalizes an issuer resource that is being deleted if its
+// issuer implementation implements Finalizer and the resource has its
+// finalizer, and removes the finalizers added by issuer implementations.
+// i may be nil if no issuer implementation could be obtained for the
+// resource, in which case the finalizers are removed without finalizing it.
+// It returns true if the finalizers of the resource were changed, in which
+// case the resource must be updated.
+func FinalizeDeleted(ctx context.Context, iss cmapi.GenericIssuer, i Interface) (bool, error) {
+	meta := iss.GetObjectMeta()
+	names := finalizerNames
+
+	if f, ok := i.(Finalizer); ok {
+		if hasFinalizer(meta.Finalizers, f.FinalizerName()) && f.NeedsFinalizer() {
+			if err := f.Finalize(ctx); err != nil {
+				return false, err
+			}
+		}
+		names = append([]string{f.FinalizerName()}, names...)
+	}
+
+	finalizers := meta.Finalizers
+	for _, name := range names {
+		finalizers = removeFinalizer(finalizers, name)
+	}
+	if len(finalizers) == len(meta.Finalizers) {
+		return false, nil
+	}
+
+	meta.Finalizers = finalizers
+	return true, nil
+}
+
+func hasFinalizer(finalizers []string, name string) bool {
+	for _, f := range finalizers {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+func removeFinalizer(finalizers []string, name string) []string {
+	var out []string
+	for _, f := range finalizers {
+		if f != name {
+			out = append(out, f)
+		}
+	}
+	return out
+}
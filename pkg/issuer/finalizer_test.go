@@ -0,0 +1,153 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+const testFinalizer = "test.cert-manager.io"
+
+type fakeIssuer struct {
+	needsFinalizer bool
+	finalizeErr    error
+	finalized      bool
+}
+
+func (f *fakeIssuer) Setup(context.Context) error { return nil }
+func (f *fakeIssuer) FinalizerName() string       { return testFinalizer }
+func (f *fakeIssuer) NeedsFinalizer() bool        { return f.needsFinalizer }
+
+func (f *fakeIssuer) Finalize(context.Context) error {
+	f.finalized = true
+	return f.finalizeErr
+}
+
+type setupOnlyIssuer struct{}
+
+func (setupOnlyIssuer) Setup(context.Context) error { return nil }
+
+func TestSyncFinalizer(t *testing.T) {
+	now := metav1.Now()
+
+	tests := map[string]struct {
+		issuer        Interface
+		finalizers    []string
+		deleting      bool
+		expUpdated    bool
+		expFinalizers []string
+		expFinalized  bool
+		expErr        bool
+	}{
+		"an issuer that does not implement Finalizer should be ignored": {
+			issuer:        setupOnlyIssuer{},
+			finalizers:    []string{"other"},
+			expFinalizers: []string{"other"},
+		},
+		"the finalizer should be added if needed": {
+			issuer:        &fakeIssuer{needsFinalizer: true},
+			finalizers:    []string{"other"},
+			expUpdated:    true,
+			expFinalizers: []string{"other", testFinalizer},
+		},
+		"the finalizer should be removed if no longer needed": {
+			issuer:        &fakeIssuer{},
+			finalizers:    []string{testFinalizer, "other"},
+			expUpdated:    true,
+			expFinalizers: []string{"other"},
+		},
+		"nothing should change if the finalizer is already present": {
+			issuer:        &fakeIssuer{needsFinalizer: true},
+			finalizers:    []string{testFinalizer},
+			expFinalizers: []string{testFinalizer},
+		},
+		"a deleted issuer should be finalized and the finalizer removed": {
+			issuer:       &fakeIssuer{needsFinalizer: true},
+			finalizers:   []string{testFinalizer},
+			deleting:     true,
+			expUpdated:   true,
+			expFinalized: true,
+		},
+		"a deleted issuer should not be finalized if it no longer needs it": {
+			issuer:     &fakeIssuer{},
+			finalizers: []string{testFinalizer},
+			deleting:   true,
+			expUpdated: true,
+		},
+		"a deleted issuer without the finalizer should not be finalized": {
+			issuer:   &fakeIssuer{needsFinalizer: true},
+			deleting: true,
+		},
+		"a deleted issuer that no longer implements Finalizer should have known finalizers removed": {
+			issuer:        setupOnlyIssuer{},
+			finalizers:    []string{cmacme.ACMEAccountDeactivationFinalizer, "other"},
+			deleting:      true,
+			expUpdated:    true,
+			expFinalizers: []string{"other"},
+		},
+		"a deleted issuer without an implementation should have known finalizers removed": {
+			finalizers: []string{cmacme.ACMEAccountDeactivationFinalizer},
+			deleting:   true,
+			expUpdated: true,
+		},
+		"a deleted issuer without known finalizers should not be updated": {
+			finalizers:    []string{"other"},
+			deleting:      true,
+			expFinalizers: []string{"other"},
+		},
+		"a failed finalization should keep the finalizer": {
+			issuer:        &fakeIssuer{needsFinalizer: true, finalizeErr: errors.New("failed")},
+			finalizers:    []string{testFinalizer},
+			deleting:      true,
+			expFinalizers: []string{testFinalizer},
+			expFinalized:  true,
+			expErr:        true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := gen.Issuer("test")
+			iss.Finalizers = test.finalizers
+			if test.deleting {
+				iss.DeletionTimestamp = &now
+			}
+
+			updated, err := SyncFinalizer(context.Background(), iss, test.issuer)
+			if (err != nil) != test.expErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if updated != test.expUpdated {
+				t.Errorf("unexpected updated, exp=%t got=%t", test.expUpdated, updated)
+			}
+			if !reflect.DeepEqual(iss.Finalizers, test.expFinalizers) {
+				t.Errorf("unexpected finalizers, exp=%v got=%v", test.expFinalizers, iss.Finalizers)
+			}
+			if f, ok := test.issuer.(*fakeIssuer); ok && f.finalized != test.expFinalized {
+				t.Errorf("unexpected finalization, exp=%t got=%t", test.expFinalized, f.finalized)
+			}
+		})
+	}
+}
@@ -27,6 +27,22 @@ type Interface interface {
 	Setup(ctx context.Context) error
 }
 
+// Finalizer is implemented by issuers which must clean up external state,
+// such as an account with a remote server, before their issuer resource is
+// deleted.
+type Finalizer interface {
+	// FinalizerName is the name of the finalizer added to the issuer resource.
+	FinalizerName() string
+
+	// NeedsFinalizer returns true if the issuer resource should have the
+	// finalizer, i.e. if Finalize must be called before it is deleted.
+	NeedsFinalizer() bool
+
+	// Finalize cleans up the issuer's external state. It is called when the
+	// issuer resource is being deleted, before the finalizer is removed.
+	Finalize(ctx context.Context) error
+}
+
 type IssueResponse struct {
 	// Certificate is the certificate resource that should be stored in the
 	// target secret.
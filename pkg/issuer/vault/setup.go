@@ -39,7 +39,7 @@ const (
 	messageVaultStatusVerificationFailed = "Vault is not initialized or is sealed"
 	messageVaultConfigRequired           = "Vault config cannot be empty"
 	messageServerAndPathRequired         = "Vault server and path are required fields"
-	messsageAuthFieldsRequired           = "Vault tokenSecretRef, appRole, or kubernetes is required"
+	messsageAuthFieldsRequired           = "Vault tokenSecretRef, appRole, kubernetes or clientCertificate is required"
 	messageAuthFieldRequired             = "Multiple auth methods cannot be set on the same Vault issuer"
 )
 
@@ -61,18 +61,23 @@ func (v *Vault) Setup(ctx context.Context) error {
 	tokenAuth := v.issuer.GetSpec().Vault.Auth.TokenSecretRef
 	appRoleAuth := v.issuer.GetSpec().Vault.Auth.AppRole
 	kubeAuth := v.issuer.GetSpec().Vault.Auth.Kubernetes
+	certAuth := v.issuer.GetSpec().Vault.Auth.ClientCertificate
 
 	// check if at least one auth method is specified.
-	if tokenAuth == nil && appRoleAuth == nil && kubeAuth == nil {
+	if tokenAuth == nil && appRoleAuth == nil && kubeAuth == nil && certAuth == nil {
 		klog.Infof("%s: %s", v.issuer.GetObjectMeta().Name, messsageAuthFieldsRequired)
 		apiutil.SetIssuerCondition(v.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messsageAuthFieldsRequired)
 		return nil
 	}
 
 	// check only one auth method set
-	if (tokenAuth != nil && appRoleAuth != nil) ||
-		(tokenAuth != nil && kubeAuth != nil) ||
-		(appRoleAuth != nil && kubeAuth != nil) {
+	authMethods := 0
+	for _, set := range []bool{tokenAuth != nil, appRoleAuth != nil, kubeAuth != nil, certAuth != nil} {
+		if set {
+			authMethods++
+		}
+	}
+	if authMethods > 1 {
 		klog.Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageAuthFieldRequired)
 		apiutil.SetIssuerCondition(v.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageAuthFieldRequired)
 		return nil
@@ -99,6 +104,13 @@ func (v *Vault) Setup(ctx context.Context) error {
 		return nil
 	}
 
+	// check if all mandatory Vault client certificate fields are set.
+	if certAuth != nil && len(certAuth.SecretName) == 0 {
+		klog.Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageAuthFieldRequired)
+		apiutil.SetIssuerCondition(v.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageAuthFieldRequired)
+		return nil
+	}
+
 	client, err := vaultinternal.New(v.resourceNamespace, v.secretsLister, v.issuer)
 	if err != nil {
 		s := messageVaultClientInitFailed + err.Error()
@@ -18,6 +18,11 @@ limitations under the License.
 // cert-manager exposes the following metrics:
 // certificate_expiration_timestamp_seconds{name, namespace}
 // certificate_ready_status{name, namespace, condition}
+// vault_token_cache_operation_count{operation}
+// certificaterequest_issuance_duration_seconds{issuer_name, issuer_kind, issuer_group}
+// certificaterequest_failure_count{issuer_name, issuer_kind, issuer_group, reason}
+// acme_challenges{type, state, solver}
+// acme_challenge_state_transition_count{type, state, solver}
 package metrics
 
 import (
@@ -36,6 +41,8 @@ import (
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
@@ -111,6 +118,70 @@ var ControllerSyncCallCount = prometheus.NewCounterVec(
 	[]string{"controller"},
 )
 
+// VaultTokenCacheOperationCount is a Prometheus counter to collect the number
+// of operations performed by the Vault token cache, by operation. Operations
+// are one of hit, login, renew and revoke.
+var VaultTokenCacheOperationCount = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "token_cache_operation_count",
+		Help:      "The number of operations performed by the Vault token cache.",
+		Subsystem: "vault",
+	},
+	[]string{"operation"},
+)
+
+// CertificateRequestIssuanceDurationSeconds is a Prometheus histogram to
+// collect the time taken from the creation of a CertificateRequest until it
+// is Ready, by issuer.
+var CertificateRequestIssuanceDurationSeconds = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "certificaterequest_issuance_duration_seconds",
+		Help:      "The time taken in seconds from the creation of a CertificateRequest until it is Ready.",
+		Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600, 7200},
+	},
+	[]string{"issuer_name", "issuer_kind", "issuer_group"},
+)
+
+// CertificateRequestFailureCount is a Prometheus counter to collect the
+// number of CertificateRequests which have failed, by issuer and the reason
+// given for the failure.
+var CertificateRequestFailureCount = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "certificaterequest_failure_count",
+		Help:      "The number of CertificateRequests which have failed.",
+	},
+	[]string{"issuer_name", "issuer_kind", "issuer_group", "reason"},
+)
+
+// ACMEChallenges is a Prometheus gauge to collect the number of ACME
+// Challenges which currently exist, by type, state and solver.
+var ACMEChallenges = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "challenges",
+		Help:      "The number of ACME Challenges, by type, state and solver.",
+		Subsystem: "acme",
+	},
+	[]string{"type", "state", "solver"},
+)
+
+// ACMEChallengeStateTransitionCount is a Prometheus counter to collect the
+// number of times ACME Challenges have entered each state, by type, state and
+// solver. The final states valid, invalid, expired and errored give the
+// outcomes of challenges.
+var ACMEChallengeStateTransitionCount = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "challenge_state_transition_count",
+		Help:      "The number of times ACME Challenges have entered each state.",
+		Subsystem: "acme",
+	},
+	[]string{"type", "state", "solver"},
+)
+
 // registeredCertificates holds the set of all certificates which are currently
 // registered by Prometheus
 var registeredCertificates = &struct {
@@ -120,6 +191,16 @@ var registeredCertificates = &struct {
 	certificates: make(map[string]struct{}),
 }
 
+// registeredChallenges holds the labels each ACME Challenge is currently
+// counted under in ACMEChallenges, so that the count can be moved when the
+// Challenge changes and removed when it is deleted
+var registeredChallenges = &struct {
+	challenges map[string]prometheus.Labels
+	mtx        sync.Mutex
+}{
+	challenges: make(map[string]prometheus.Labels),
+}
+
 // cleanUpFunctions are functions called to clean up metrics which refer to
 // deleted certificates, inputs are name and namespace of the certificate
 var cleanUpFunctions = []func(string, string){
@@ -143,6 +224,12 @@ type Metrics struct {
 	ACMEClientRequestDurationSeconds *prometheus.SummaryVec
 	ACMEClientRequestCount           *prometheus.CounterVec
 	ControllerSyncCallCount          *prometheus.CounterVec
+	VaultTokenCacheOperationCount    *prometheus.CounterVec
+
+	CertificateRequestIssuanceDurationSeconds *prometheus.HistogramVec
+	CertificateRequestFailureCount            *prometheus.CounterVec
+	ACMEChallenges                            *prometheus.GaugeVec
+	ACMEChallengeStateTransitionCount         *prometheus.CounterVec
 }
 
 func New(ctx context.Context) *Metrics {
@@ -165,6 +252,12 @@ func New(ctx context.Context) *Metrics {
 		ACMEClientRequestDurationSeconds: ACMEClientRequestDurationSeconds,
 		ACMEClientRequestCount:           ACMEClientRequestCount,
 		ControllerSyncCallCount:          ControllerSyncCallCount,
+		VaultTokenCacheOperationCount:    VaultTokenCacheOperationCount,
+
+		CertificateRequestIssuanceDurationSeconds: CertificateRequestIssuanceDurationSeconds,
+		CertificateRequestFailureCount:            CertificateRequestFailureCount,
+		ACMEChallenges:                            ACMEChallenges,
+		ACMEChallengeStateTransitionCount:         ACMEChallengeStateTransitionCount,
 	}
 
 	router.Handle("/metrics", promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{}))
@@ -196,6 +289,11 @@ func (m *Metrics) Start(stopCh <-chan struct{}) {
 	m.registry.MustRegister(m.ACMEClientRequestDurationSeconds)
 	m.registry.MustRegister(m.ACMEClientRequestCount)
 	m.registry.MustRegister(m.ControllerSyncCallCount)
+	m.registry.MustRegister(m.VaultTokenCacheOperationCount)
+	m.registry.MustRegister(m.CertificateRequestIssuanceDurationSeconds)
+	m.registry.MustRegister(m.CertificateRequestFailureCount)
+	m.registry.MustRegister(m.ACMEChallenges)
+	m.registry.MustRegister(m.ACMEChallengeStateTransitionCount)
 
 	go func() {
 		log := log.WithValues("address", m.Addr)
@@ -385,3 +483,142 @@ func (m *Metrics) IncrementSyncCallCount(controllerName string) {
 	log.V(logf.DebugLevel).Info("incrementing controller sync call count", "controllerName", controllerName)
 	ControllerSyncCallCount.WithLabelValues(controllerName).Inc()
 }
+
+// ObserveCertificateRequestIssuance records the time taken from the creation
+// of a CertificateRequest until the given time, at which it became Ready.
+func (m *Metrics) ObserveCertificateRequestIssuance(cr *v1alpha2.CertificateRequest, readyTime time.Time) {
+	if cr.CreationTimestamp.IsZero() {
+		return
+	}
+
+	CertificateRequestIssuanceDurationSeconds.With(issuerLabels(cr.Spec.IssuerRef)).
+		Observe(readyTime.Sub(cr.CreationTimestamp.Time).Seconds())
+}
+
+// IncrementCertificateRequestFailureCount records the failure of a
+// CertificateRequest with the given reason.
+func (m *Metrics) IncrementCertificateRequestFailureCount(cr *v1alpha2.CertificateRequest, reason string) {
+	labels := issuerLabels(cr.Spec.IssuerRef)
+	labels["reason"] = reason
+	CertificateRequestFailureCount.With(labels).Inc()
+}
+
+// issuerLabels returns the labels identifying the issuer referenced by a
+// CertificateRequest, defaulting the kind and group as the issuers do.
+func issuerLabels(ref cmmeta.ObjectReference) prometheus.Labels {
+	kind, group := ref.Kind, ref.Group
+	if kind == "" {
+		kind = v1alpha2.IssuerKind
+	}
+	if group == "" {
+		group = certmanager.GroupName
+	}
+
+	return prometheus.Labels{
+		"issuer_name":  ref.Name,
+		"issuer_kind":  kind,
+		"issuer_group": group,
+	}
+}
+
+// UpdateChallenge updates the metrics for an ACME Challenge after it has been
+// synced, counting it under its current type, state and solver and recording
+// any change in its state since old.
+func (m *Metrics) UpdateChallenge(old, ch *cmacme.Challenge) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(ch)
+	if err != nil {
+		return
+	}
+
+	labels := challengeLabels(ch)
+
+	registeredChallenges.mtx.Lock()
+	defer registeredChallenges.mtx.Unlock()
+
+	if ch.Status.State != "" && ch.Status.State != old.Status.State {
+		ACMEChallengeStateTransitionCount.With(labels).Inc()
+	}
+
+	if existing, ok := registeredChallenges.challenges[key]; ok {
+		if challengeLabelsEqual(existing, labels) {
+			return
+		}
+		ACMEChallenges.With(existing).Dec()
+	}
+
+	ACMEChallenges.With(labels).Inc()
+	registeredChallenges.challenges[key] = labels
+}
+
+// RemoveChallenge stops counting the ACME Challenge with the given key, once
+// it has been deleted.
+func (m *Metrics) RemoveChallenge(key string) {
+	registeredChallenges.mtx.Lock()
+	defer registeredChallenges.mtx.Unlock()
+
+	existing, ok := registeredChallenges.challenges[key]
+	if !ok {
+		return
+	}
+
+	ACMEChallenges.With(existing).Dec()
+	delete(registeredChallenges.challenges, key)
+}
+
+func challengeLabels(ch *cmacme.Challenge) prometheus.Labels {
+	state := string(ch.Status.State)
+	if state == "" {
+		state = "unknown"
+	}
+
+	return prometheus.Labels{
+		"type":   string(ch.Spec.Type),
+		"state":  state,
+		"solver": challengeSolver(ch.Spec.Solver),
+	}
+}
+
+func challengeLabelsEqual(a, b prometheus.Labels) bool {
+	return a["type"] == b["type"] && a["state"] == b["state"] && a["solver"] == b["solver"]
+}
+
+// challengeSolver returns the name of the solver configured to solve an ACME
+// Challenge, e.g. "ingress" or "gatewayHTTPRoute" for HTTP01 challenges,
+// "tlsalpn01" for TLSALPN01 challenges or the name of the DNS provider for
+// DNS01 challenges.
+func challengeSolver(s *cmacme.ACMEChallengeSolver) string {
+	switch {
+	case s == nil:
+		return "unknown"
+	case s.HTTP01 != nil && s.HTTP01.Ingress != nil:
+		return "ingress"
+	case s.HTTP01 != nil && s.HTTP01.GatewayHTTPRoute != nil:
+		return "gatewayHTTPRoute"
+	case s.TLSALPN01 != nil:
+		return "tlsalpn01"
+	case s.DNS01 != nil:
+		d := s.DNS01
+		switch {
+		case d.Akamai != nil:
+			return "akamai"
+		case d.CloudDNS != nil:
+			return "clouddns"
+		case d.Cloudflare != nil:
+			return "cloudflare"
+		case d.Route53 != nil:
+			return "route53"
+		case d.AzureDNS != nil:
+			return "azuredns"
+		case d.DigitalOcean != nil:
+			return "digitalocean"
+		case d.AcmeDNS != nil:
+			return "acmedns"
+		case d.RFC2136 != nil:
+			return "rfc2136"
+		case d.Webhook != nil:
+			return "webhook"
+		}
+	}
+
+	return "unknown"
+}
@@ -17,14 +17,17 @@ limitations under the License.
 package metrics
 
 import (
+	"context"
 	"crypto/x509"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 )
@@ -266,3 +269,147 @@ func TestCleanUp(t *testing.T) {
 		})
 	}
 }
+
+func TestIncrementCertificateRequestFailureCount(t *testing.T) {
+	const metadata = `
+	# HELP certmanager_certificaterequest_failure_count The number of CertificateRequests which have failed.
+	# TYPE certmanager_certificaterequest_failure_count counter
+`
+	defer CertificateRequestFailureCount.Reset()
+
+	buildRequest := func(ref cmmeta.ObjectReference) *v1alpha2.CertificateRequest {
+		return &v1alpha2.CertificateRequest{Spec: v1alpha2.CertificateRequestSpec{IssuerRef: ref}}
+	}
+
+	m := New(context.Background())
+	m.IncrementCertificateRequestFailureCount(buildRequest(cmmeta.ObjectReference{Name: "ca"}), "Failed")
+	m.IncrementCertificateRequestFailureCount(buildRequest(cmmeta.ObjectReference{Name: "ca", Kind: "Issuer"}), "Failed")
+	m.IncrementCertificateRequestFailureCount(buildRequest(cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer"}), "Denied")
+	m.IncrementCertificateRequestFailureCount(buildRequest(cmmeta.ObjectReference{Name: "external", Kind: "External", Group: "example.com"}), "Failed")
+
+	expected := `
+	certmanager_certificaterequest_failure_count{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",reason="Denied"} 1
+	certmanager_certificaterequest_failure_count{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca",reason="Failed"} 2
+	certmanager_certificaterequest_failure_count{issuer_group="example.com",issuer_kind="External",issuer_name="external",reason="Failed"} 1
+`
+	if err := testutil.CollectAndCompare(
+		CertificateRequestFailureCount,
+		strings.NewReader(metadata+expected),
+		"certmanager_certificaterequest_failure_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestUpdateChallenge(t *testing.T) {
+	const metadataChallenges = `
+	# HELP certmanager_acme_challenges The number of ACME Challenges, by type, state and solver.
+	# TYPE certmanager_acme_challenges gauge
+`
+	const metadataTransitions = `
+	# HELP certmanager_acme_challenge_state_transition_count The number of times ACME Challenges have entered each state.
+	# TYPE certmanager_acme_challenge_state_transition_count counter
+`
+	defer func() {
+		ACMEChallenges.Reset()
+		ACMEChallengeStateTransitionCount.Reset()
+		registeredChallenges.challenges = make(map[string]prometheus.Labels)
+	}()
+
+	buildChallenge := func(name string, solver *cmacme.ACMEChallengeSolver, state cmacme.State) *cmacme.Challenge {
+		return &cmacme.Challenge{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       cmacme.ChallengeSpec{Type: cmacme.ACMEChallengeTypeHTTP01, Solver: solver},
+			Status:     cmacme.ChallengeStatus{State: state},
+		}
+	}
+	ingress := &cmacme.ACMEChallengeSolver{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{}}}
+
+	m := New(context.Background())
+
+	// a new challenge, then the same challenge synced again with no change
+	first := buildChallenge("first", ingress, "")
+	m.UpdateChallenge(first, first)
+	m.UpdateChallenge(first, first)
+
+	// a challenge which becomes pending and then valid
+	second := buildChallenge("second", ingress, "")
+	pending := buildChallenge("second", ingress, cmacme.Pending)
+	valid := buildChallenge("second", ingress, cmacme.Valid)
+	m.UpdateChallenge(second, pending)
+	m.UpdateChallenge(pending, valid)
+
+	// a challenge which is deleted
+	third := buildChallenge("third", nil, cmacme.Invalid)
+	m.UpdateChallenge(third, third)
+	m.RemoveChallenge("default/third")
+	m.RemoveChallenge("default/unknown")
+
+	expectedChallenges := `
+	certmanager_acme_challenges{solver="ingress",state="pending",type="http-01"} 0
+	certmanager_acme_challenges{solver="ingress",state="unknown",type="http-01"} 1
+	certmanager_acme_challenges{solver="ingress",state="valid",type="http-01"} 1
+	certmanager_acme_challenges{solver="unknown",state="invalid",type="http-01"} 0
+`
+	if err := testutil.CollectAndCompare(
+		ACMEChallenges,
+		strings.NewReader(metadataChallenges+expectedChallenges),
+		"certmanager_acme_challenges",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	expectedTransitions := `
+	certmanager_acme_challenge_state_transition_count{solver="ingress",state="pending",type="http-01"} 1
+	certmanager_acme_challenge_state_transition_count{solver="ingress",state="valid",type="http-01"} 1
+`
+	if err := testutil.CollectAndCompare(
+		ACMEChallengeStateTransitionCount,
+		strings.NewReader(metadataTransitions+expectedTransitions),
+		"certmanager_acme_challenge_state_transition_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestChallengeSolver(t *testing.T) {
+	tests := map[string]struct {
+		solver   *cmacme.ACMEChallengeSolver
+		expected string
+	}{
+		"no solver": {
+			expected: "unknown",
+		},
+		"http01 ingress solver": {
+			solver:   &cmacme.ACMEChallengeSolver{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{}}},
+			expected: "ingress",
+		},
+		"http01 gatewayHTTPRoute solver": {
+			solver:   &cmacme.ACMEChallengeSolver{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{}}},
+			expected: "gatewayHTTPRoute",
+		},
+		"tlsalpn01 solver": {
+			solver:   &cmacme.ACMEChallengeSolver{TLSALPN01: &cmacme.ACMEChallengeSolverTLSALPN01{}},
+			expected: "tlsalpn01",
+		},
+		"dns01 cloudflare solver": {
+			solver:   &cmacme.ACMEChallengeSolver{DNS01: &cmacme.ACMEChallengeSolverDNS01{Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{}}},
+			expected: "cloudflare",
+		},
+		"dns01 webhook solver": {
+			solver:   &cmacme.ACMEChallengeSolver{DNS01: &cmacme.ACMEChallengeSolverDNS01{Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{}}},
+			expected: "webhook",
+		},
+		"dns01 solver without a provider": {
+			solver:   &cmacme.ACMEChallengeSolver{DNS01: &cmacme.ACMEChallengeSolverDNS01{}},
+			expected: "unknown",
+		},
+	}
+	for n, test := range tests {
+		t.Run(n, func(t *testing.T) {
+			if got := challengeSolver(test.solver); got != test.expected {
+				t.Errorf("unexpected solver, exp=%s got=%s", test.expected, got)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package pki
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -26,6 +27,7 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"fmt"
+	"math/big"
 	"strings"
 	"testing"
 	"time"
@@ -141,6 +143,11 @@ func TestGeneratePrivateKeyForCertificate(t *testing.T) {
 			keyAlgo:   v1alpha2.ECDSAKeyAlgorithm,
 			expectErr: false,
 		},
+		{
+			name:      "ed25519 key",
+			keyAlgo:   v1alpha2.Ed25519KeyAlgorithm,
+			expectErr: false,
+		},
 	}
 
 	testFn := func(test testT) func(*testing.T) {
@@ -214,6 +221,13 @@ func TestGeneratePrivateKeyForCertificate(t *testing.T) {
 						return
 					}
 				}
+
+				if test.keyAlgo == "ed25519" {
+					if _, ok := privateKey.(ed25519.PrivateKey); !ok {
+						t.Errorf("expected ed25519 private key, but got %T", privateKey)
+						return
+					}
+				}
 			}
 		}
 	}
@@ -284,6 +298,47 @@ func TestPublicKeyMatchesCertificate(t *testing.T) {
 	}
 }
 
+func TestPublicKeyMatchesCertificateEd25519(t *testing.T) {
+	privKey1, err := GenerateEd25519PrivateKey()
+	if err != nil {
+		t.Errorf("error generating private key: %v", err)
+	}
+	privKey2, err := GenerateEd25519PrivateKey()
+	if err != nil {
+		t.Errorf("error generating private key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		Version:      3,
+		SerialNumber: big.NewInt(1),
+		Subject: pkix.Name{
+			CommonName: "testingcert",
+		},
+		NotBefore: time.Now(),
+		NotAfter:  time.Now().Add(v1alpha2.DefaultCertificateDuration),
+	}
+	_, testCert1, err := SignCertificate(template, template, privKey1.Public(), privKey1)
+	if err != nil {
+		t.Fatalf("error signing test cert: %v", err)
+	}
+
+	matches, err := PublicKeyMatchesCertificate(privKey1.Public(), testCert1)
+	if err != nil {
+		t.Errorf("expected no error, but got: %v", err)
+	}
+	if !matches {
+		t.Errorf("expected private key to match certificate, but it did not")
+	}
+
+	matches, err = PublicKeyMatchesCertificate(privKey2.Public(), testCert1)
+	if err != nil {
+		t.Errorf("expected no error, but got: %v", err)
+	}
+	if matches {
+		t.Errorf("expected private key to not match certificate, but it did")
+	}
+}
+
 func TestPublicKeyMatchesCertificateRequest(t *testing.T) {
 	privKey1, err := GenerateRSAPrivateKey(2048)
 	if err != nil {
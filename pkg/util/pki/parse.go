@@ -21,12 +21,16 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
 
 	"github.com/jetstack/cert-manager/pkg/util/errors"
 )
 
 // DecodePrivateKeyBytes will decode a PEM encoded private key into a crypto.Signer.
-// It supports ECDSA and RSA private keys only. All other types will return err.
+// It supports ECDSA, RSA and Ed25519 private keys only. All other types will
+// return err. Ed25519 private keys must be PKCS#8 encoded.
 func DecodePrivateKeyBytes(keyBytes []byte) (crypto.Signer, error) {
 	// decode the private key pem
 	block, _ := pem.Decode(keyBytes)
@@ -140,3 +144,20 @@ func DecodeX509CertificateRequestBytes(csrBytes []byte) (*x509.CertificateReques
 
 	return csr, nil
 }
+
+// ParseSerialNumber parses a hex encoded certificate serial number, as
+// printed by 'openssl x509 -serial'. Each byte may optionally be separated
+// by colons.
+func ParseSerialNumber(serial string) (*big.Int, error) {
+	hex := strings.Replace(serial, ":", "", -1)
+	if hex == "" {
+		return nil, fmt.Errorf("serial number must not be empty")
+	}
+
+	n, ok := new(big.Int).SetString(hex, 16)
+	if !ok || n.Sign() < 0 {
+		return nil, fmt.Errorf("invalid serial number %q: must be hex encoded", serial)
+	}
+
+	return n, nil
+}
@@ -18,8 +18,10 @@ package pki
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"encoding/pem"
+	"math/big"
 	"strings"
 	"testing"
 
@@ -77,6 +79,12 @@ func TestDecodePrivateKeyBytes(t *testing.T) {
 		return
 	}
 
+	ed25519KeyBytes, err := generatePrivateKeyBytes(v1alpha2.Ed25519KeyAlgorithm, 0)
+	if err != nil {
+		t.Errorf("error generating key bytes: %s", err)
+		return
+	}
+
 	block := &pem.Block{Type: "BLAH BLAH BLAH", Bytes: []byte("blahblahblah")}
 	blahKeyBytes := pem.EncodeToMemory(block)
 
@@ -110,6 +118,12 @@ func TestDecodePrivateKeyBytes(t *testing.T) {
 			keyAlgo:   v1alpha2.ECDSAKeyAlgorithm,
 			expectErr: false,
 		},
+		{
+			name:      "decode pkcs#8 encoded ed25519 private key bytes",
+			keyBytes:  ed25519KeyBytes,
+			keyAlgo:   v1alpha2.Ed25519KeyAlgorithm,
+			expectErr: false,
+		},
 		{
 			name:         "fail to decode unknown pem encoded key bytes",
 			keyBytes:     blahKeyBytes,
@@ -166,6 +180,14 @@ func TestDecodePrivateKeyBytes(t *testing.T) {
 						return
 					}
 				}
+
+				if test.keyAlgo == v1alpha2.Ed25519KeyAlgorithm {
+					_, ok := privateKey.(ed25519.PrivateKey)
+					if !ok {
+						t.Errorf("expected ed25519 private key, but got %T", privateKey)
+						return
+					}
+				}
 			}
 		}
 	}
@@ -174,3 +196,44 @@ func TestDecodePrivateKeyBytes(t *testing.T) {
 		t.Run(test.name, testFn(test))
 	}
 }
+
+func TestParseSerialNumber(t *testing.T) {
+	tests := map[string]struct {
+		serial      string
+		expected    *big.Int
+		expectedErr bool
+	}{
+		"hex serial number": {
+			serial:   "1A2b",
+			expected: big.NewInt(0x1a2b),
+		},
+		"colon separated serial number": {
+			serial:   "01:1a:2b",
+			expected: big.NewInt(0x11a2b),
+		},
+		"empty serial number": {
+			serial:      "",
+			expectedErr: true,
+		},
+		"serial number that is not hex encoded": {
+			serial:      "xyz",
+			expectedErr: true,
+		},
+		"negative serial number": {
+			serial:      "-1a",
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			n, err := ParseSerialNumber(test.serial)
+			if test.expectedErr != (err != nil) {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+			if test.expected != nil && n.Cmp(test.expected) != 0 {
+				t.Errorf("got unexpected serial number, exp=%s got=%s", test.expected, n)
+			}
+		})
+	}
+}
@@ -68,6 +68,14 @@ func (c *SchemeBackedDefaulter) Mutate(admissionSpec *admissionv1beta1.Admission
 	defaultedObj := obj.DeepCopyObject()
 	// apply defaults to the object
 	c.scheme.Default(defaultedObj)
+	// record the identity of the user that created CertificateRequests
+	if err := c.setCertificateRequestUserInfo(defaultedObj, admissionSpec); err != nil {
+		status.Result = &metav1.Status{
+			Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+			Message: fmt.Sprintf("Failed to decode old object: %v", err.Error()),
+		}
+		return status
+	}
 	// encode the default object to JSON
 	buf := bytes.Buffer{}
 	if err := c.codec.Encode(defaultedObj, &buf); err != nil {
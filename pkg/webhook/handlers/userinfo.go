@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmapiv1alpha2 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmapiv1alpha3 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha3"
+)
+
+// userInfo is the identity of the user that created a CertificateRequest.
+type userInfo struct {
+	username string
+	uid      string
+	groups   []string
+}
+
+// setCertificateRequestUserInfo records the identity of the user that created
+// a CertificateRequest on the resource. On update, the identity recorded at
+// creation is preserved so that it cannot be modified by users.
+// Resources that are not CertificateRequests are left unmodified.
+func (c *SchemeBackedDefaulter) setCertificateRequestUserInfo(obj runtime.Object, admissionSpec *admissionv1beta1.AdmissionRequest) error {
+	if _, ok := certificateRequestUserInfo(obj); !ok {
+		return nil
+	}
+
+	var info userInfo
+	switch admissionSpec.Operation {
+	case admissionv1beta1.Create:
+		info = userInfo{
+			username: admissionSpec.UserInfo.Username,
+			uid:      admissionSpec.UserInfo.UID,
+			groups:   admissionSpec.UserInfo.Groups,
+		}
+	case admissionv1beta1.Update:
+		oldObj, _, err := c.codec.Decode(admissionSpec.OldObject.Raw, nil, nil)
+		if err != nil {
+			return err
+		}
+		info, _ = certificateRequestUserInfo(oldObj)
+	default:
+		return nil
+	}
+
+	switch cr := obj.(type) {
+	case *cmapiv1alpha2.CertificateRequest:
+		cr.Spec.Username, cr.Spec.UID, cr.Spec.Groups = info.username, info.uid, info.groups
+	case *cmapiv1alpha3.CertificateRequest:
+		cr.Spec.Username, cr.Spec.UID, cr.Spec.Groups = info.username, info.uid, info.groups
+	}
+
+	return nil
+}
+
+// certificateRequestUserInfo returns the identity recorded on the given
+// object, and false if the object is not a CertificateRequest.
+func certificateRequestUserInfo(obj runtime.Object) (userInfo, bool) {
+	switch cr := obj.(type) {
+	case *cmapiv1alpha2.CertificateRequest:
+		return userInfo{username: cr.Spec.Username, uid: cr.Spec.UID, groups: cr.Spec.Groups}, true
+	case *cmapiv1alpha3.CertificateRequest:
+		return userInfo{username: cr.Spec.Username, uid: cr.Spec.UID, groups: cr.Spec.Groups}, true
+	default:
+		return userInfo{}, false
+	}
+}
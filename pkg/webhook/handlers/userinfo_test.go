@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"testing"
+
+	"github.com/mattbaird/jsonpatch"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/klogr"
+
+	"github.com/jetstack/cert-manager/pkg/internal/apis/certmanager/install"
+)
+
+func TestCertificateRequestUserInfo(t *testing.T) {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+
+	log := klogr.New()
+	c := NewSchemeBackedDefaulter(log, scheme)
+	tests := map[string]admissionTestT{
+		"set user info on create": {
+			inputRequest: admissionv1beta1.AdmissionRequest{
+				UID:       types.UID("abc"),
+				Operation: admissionv1beta1.Create,
+				UserInfo: authenticationv1.UserInfo{
+					Username: "system:serviceaccount:abc:app",
+					UID:      "1234",
+					Groups:   []string{"system:serviceaccounts"},
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+{
+	"apiVersion": "cert-manager.io/v1alpha2",
+	"kind": "CertificateRequest",
+	"metadata": {
+		"name": "testing",
+		"namespace": "abc",
+		"creationTimestamp": null
+	},
+	"spec": {
+		"csr": null,
+		"issuerRef": {
+			"name": "issuer"
+		}
+	},
+	"status": {}
+}
+`),
+				},
+			},
+			expectedResponse: admissionv1beta1.AdmissionResponse{
+				UID:     types.UID("abc"),
+				Allowed: true,
+				Patch: responseForOperations(
+					jsonpatch.JsonPatchOperation{
+						Operation: "add",
+						Path:      "/spec/groups",
+						Value:     []interface{}{"system:serviceaccounts"},
+					},
+					jsonpatch.JsonPatchOperation{
+						Operation: "add",
+						Path:      "/spec/uid",
+						Value:     "1234",
+					},
+					jsonpatch.JsonPatchOperation{
+						Operation: "add",
+						Path:      "/spec/username",
+						Value:     "system:serviceaccount:abc:app",
+					},
+				),
+				PatchType: &jsonPatchType,
+			},
+		},
+		"preserve user info on update": {
+			inputRequest: admissionv1beta1.AdmissionRequest{
+				UID:       types.UID("abc"),
+				Operation: admissionv1beta1.Update,
+				UserInfo: authenticationv1.UserInfo{
+					Username: "mallory",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+{
+	"apiVersion": "cert-manager.io/v1alpha2",
+	"kind": "CertificateRequest",
+	"metadata": {
+		"name": "testing",
+		"namespace": "abc",
+		"creationTimestamp": null
+	},
+	"spec": {
+		"csr": null,
+		"issuerRef": {
+			"name": "issuer"
+		},
+		"username": "mallory"
+	},
+	"status": {}
+}
+`),
+				},
+				OldObject: runtime.RawExtension{
+					Raw: []byte(`
+{
+	"apiVersion": "cert-manager.io/v1alpha2",
+	"kind": "CertificateRequest",
+	"metadata": {
+		"name": "testing",
+		"namespace": "abc",
+		"creationTimestamp": null
+	},
+	"spec": {
+		"csr": null,
+		"issuerRef": {
+			"name": "issuer"
+		},
+		"username": "system:serviceaccount:abc:app"
+	},
+	"status": {}
+}
+`),
+				},
+			},
+			expectedResponse: admissionv1beta1.AdmissionResponse{
+				UID:     types.UID("abc"),
+				Allowed: true,
+				Patch: responseForOperations(
+					jsonpatch.JsonPatchOperation{
+						Operation: "replace",
+						Path:      "/spec/username",
+						Value:     "system:serviceaccount:abc:app",
+					},
+				),
+				PatchType: &jsonPatchType,
+			},
+		},
+	}
+
+	for n, test := range tests {
+		t.Run(n, func(t *testing.T) {
+			runAdmissionTest(t, c.Mutate, test)
+		})
+	}
+}
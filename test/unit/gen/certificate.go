@@ -100,12 +100,27 @@ func SetCertificateKeyEncoding(keyEncoding v1alpha2.KeyEncoding) CertificateModi
 	}
 }
 
+func SetCertificatePrivateKeyRotationPolicy(policy v1alpha2.PrivateKeyRotationPolicy) CertificateModifier {
+	return func(crt *v1alpha2.Certificate) {
+		crt.Spec.PrivateKey = &v1alpha2.CertificatePrivateKey{RotationPolicy: policy}
+	}
+}
+
 func SetCertificateSecretName(secretName string) CertificateModifier {
 	return func(crt *v1alpha2.Certificate) {
 		crt.Spec.SecretName = secretName
 	}
 }
 
+func SetCertificateSecretTemplate(labels, annotations map[string]string) CertificateModifier {
+	return func(crt *v1alpha2.Certificate) {
+		crt.Spec.SecretTemplate = &v1alpha2.CertificateSecretTemplate{
+			Labels:      labels,
+			Annotations: annotations,
+		}
+	}
+}
+
 func SetCertificateDuration(duration time.Duration) CertificateModifier {
 	return func(crt *v1alpha2.Certificate) {
 		crt.Spec.Duration = &metav1.Duration{Duration: duration}
@@ -146,6 +161,42 @@ func SetCertificateNotAfter(p metav1.Time) CertificateModifier {
 	}
 }
 
+func SetCertificateFailedIssuanceAttempts(attempts int) CertificateModifier {
+	return func(crt *v1alpha2.Certificate) {
+		crt.Status.FailedIssuanceAttempts = &attempts
+	}
+}
+
+func SetCertificateNextRetryTime(p metav1.Time) CertificateModifier {
+	return func(crt *v1alpha2.Certificate) {
+		crt.Status.NextRetryTime = &p
+	}
+}
+
+func SetCertificateNotBefore(p metav1.Time) CertificateModifier {
+	return func(crt *v1alpha2.Certificate) {
+		crt.Status.NotBefore = &p
+	}
+}
+
+func SetCertificateRenewalTime(p metav1.Time) CertificateModifier {
+	return func(crt *v1alpha2.Certificate) {
+		crt.Status.RenewalTime = &p
+	}
+}
+
+func SetCertificateRevision(revision int) CertificateModifier {
+	return func(crt *v1alpha2.Certificate) {
+		crt.Status.Revision = &revision
+	}
+}
+
+func SetCertificateRevisionHistoryLimit(limit int32) CertificateModifier {
+	return func(crt *v1alpha2.Certificate) {
+		crt.Spec.RevisionHistoryLimit = &limit
+	}
+}
+
 func SetCertificateOrganization(orgs ...string) CertificateModifier {
 	return func(ch *v1alpha2.Certificate) {
 		ch.Spec.Organization = orgs
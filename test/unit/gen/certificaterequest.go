@@ -114,6 +114,12 @@ func SetCertificateRequestKeyUsages(usages ...v1alpha2.KeyUsage) CertificateRequ
 	}
 }
 
+func SetCertificateRequestUsername(username string) CertificateRequestModifier {
+	return func(cr *v1alpha2.CertificateRequest) {
+		cr.Spec.Username = username
+	}
+}
+
 func AddCertificateRequestAnnotations(annotations map[string]string) CertificateRequestModifier {
 	return func(cr *v1alpha2.CertificateRequest) {
 		// Make sure to do a merge here with new annotations overriding.
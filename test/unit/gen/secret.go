@@ -48,3 +48,12 @@ func SetSecretAnnotations(an map[string]string) SecretModifier {
 		}
 	}
 }
+
+func SetSecretData(data map[string][]byte) SecretModifier {
+	return func(sec *corev1.Secret) {
+		sec.Data = make(map[string][]byte)
+		for k, v := range data {
+			sec.Data[k] = v
+		}
+	}
+}